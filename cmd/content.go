@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+// resolveContent returns the content to write and whether it is raw. With
+// --stdin, or "-" as the content, standard input is read as is; otherwise the
+// --content value is returned for escape processing.
+func resolveContent(content string, fromStdin bool) (string, bool) {
+	if !fromStdin && content != "-" {
+		return content, false
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("Failed to read content from stdin: %v", err)
+	}
+	return string(data), true
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"log"
+	"os"
 
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
@@ -11,23 +12,63 @@ import (
 var shouldAppend bool
 var shouldOverwrite bool
 var content string
+var createValidate bool
+var createUnder string
+var createPrepend bool
+var createTimestamp bool
+var createTimestampFormat string
+var createTemplate string
+var createProperties []string
+var createStdin bool
+var createSanitize bool
 var createNoteCmd = &cobra.Command{
 	Use:     "create",
 	Aliases: []string{"c"},
 	Short:   "Creates note in vault",
-	Args:    cobra.ExactArgs(1),
+	Long: `Creates a note in the vault, or adds content to an existing one.
+
+--template starts a new note from a template in the Templates folder
+(.obsidian/templates.json), with {{title}}, {{date}} and {{time}}
+expanded as Obsidian's "Insert template" does. --property merges
+key=value properties into the new note's frontmatter, typed as declared
+in .obsidian/types.json. --content is added to the template's body.
+
+Names of new notes may not contain characters that break links
+(# ^ [ ] |) or that Windows does not allow (: * ? " < > \). --sanitize
+replaces them as configured in .notesmd/names.yaml.
+
+--stdin (or --content -) reads the content from standard input as is,
+without the escape processing applied to --content (\n, \t, ...).
+
+Examples:
+  notesmd-cli create "Inbox/idea" --content "- try the new parser"
+  git log --oneline -5 | notesmd-cli create "Releases/1.4" --stdin --under "## Changes"
+  notesmd-cli create "Meeting with ACME" --template Meeting
+  notesmd-cli create "Meeting with ACME" --template Meeting --property client=ACME --property "attendees=[Ann, Bo]"`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		vault := obsidian.Vault{Name: vaultName}
 		uri := obsidian.Uri{}
 		noteName := args[0]
+		noteContent, rawContent := resolveContent(content, createStdin)
 
 		params := actions.CreateParams{
 			NoteName:        noteName,
-			Content:         content,
+			Content:         noteContent,
+			RawContent:      rawContent,
 			ShouldAppend:    shouldAppend,
 			ShouldOverwrite: shouldOverwrite,
 			ShouldOpen:      shouldOpen,
 			UseEditor:       resolveUseEditor(cmd, &vault),
+			Validate:        createValidate,
+			Warnings:        os.Stderr,
+			Under:           createUnder,
+			Prepend:         createPrepend,
+			Timestamp:       createTimestamp,
+			TimestampFormat: createTimestampFormat,
+			Template:        createTemplate,
+			Properties:      createProperties,
+			Sanitize:        createSanitize,
 		}
 		err := actions.CreateNote(&vault, &uri, params)
 		if err != nil {
@@ -39,10 +80,21 @@ var createNoteCmd = &cobra.Command{
 func init() {
 	createNoteCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	createNoteCmd.Flags().BoolVarP(&shouldOpen, "open", "", false, "open created note")
-	createNoteCmd.Flags().StringVarP(&content, "content", "c", "", "text to add to note")
+	createNoteCmd.Flags().StringVarP(&content, "content", "c", "", "text to add to note (\"-\" reads standard input)")
+	createNoteCmd.Flags().BoolVar(&createStdin, "stdin", false, "read the text to add from standard input, without escape processing")
 	createNoteCmd.Flags().BoolVarP(&shouldAppend, "append", "a", false, "append to note")
 	createNoteCmd.Flags().BoolVarP(&shouldOverwrite, "overwrite", "o", false, "overwrite note")
 	createNoteCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
+	createNoteCmd.Flags().BoolVar(&createValidate, "validate", false, "warn if the note's frontmatter violates its folder schema (.notesmd/schema.yaml)")
+	createNoteCmd.Flags().StringVar(&createUnder, "under", "", "add content at the end of this heading's section, creating it if missing")
+	createNoteCmd.Flags().BoolVar(&createPrepend, "prepend", false, "add content directly after the frontmatter")
+	createNoteCmd.Flags().BoolVar(&createTimestamp, "timestamp", false, "prefix content with the current time")
+	createNoteCmd.Flags().StringVar(&createTimestampFormat, "timestamp-format", "", "Moment.js format for --timestamp (default: Templates time format, HH:mm)")
+	createNoteCmd.Flags().StringVar(&createTemplate, "template", "", "start a new note from this template in the Templates folder")
+	createNoteCmd.Flags().StringArrayVar(&createProperties, "property", nil, "key=value frontmatter property for a new note (repeatable)")
+	createNoteCmd.Flags().BoolVar(&createSanitize, "sanitize", false, "replace characters that are unsafe in note names instead of failing")
+	createNoteCmd.MarkFlagsMutuallyExclusive("content", "stdin")
 	createNoteCmd.MarkFlagsMutuallyExclusive("append", "overwrite")
+	createNoteCmd.MarkFlagsMutuallyExclusive("under", "prepend", "overwrite")
 	rootCmd.AddCommand(createNoteCmd)
 }
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"log"
+	"os"
+	"time"
 
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
@@ -12,14 +14,97 @@ var DailyCmd = &cobra.Command{
 	Use:     "daily",
 	Aliases: []string{"d"},
 	Short:   "Creates or opens daily note in vault",
-	Args:    cobra.ExactArgs(0),
+	Long: `Creates or opens a daily note. Without --date this is today's note.
+
+--date accepts ISO dates and expressions such as "yesterday", "-2d",
+"+1w", "3 days ago", "last friday" or "next monday". Use "daily prev"
+and "daily next" to jump to the nearest existing daily note before or
+after that day.
+
+--rollover copies unchecked tasks from the most recent earlier daily
+note into a newly created one, like the Rollover Daily Todos plugin.
+The originals can be kept, marked as moved ("- [>]") or deleted.
+
+--stdin (or --content -) reads the content from standard input as is,
+without the escape processing applied to --content.
+
+--no-open, --print and --path skip opening the note, for scripts and
+servers without an obsidian:// handler.
+
+Examples:
+  notesmd-cli daily --date 2026-10-01
+  notesmd-cli daily --date yesterday --print
+  notesmd-cli daily prev
+  notesmd-cli daily --content "- standup notes" --no-open
+  notesmd-cli daily --content "- shipped release" --under "## Log" --timestamp
+  make test 2>&1 | tail -5 | notesmd-cli daily --stdin --under "## Log" --no-open
+  notesmd-cli daily --rollover --rollover-under "## Tasks" --rollover-originals mark
+  notesmd-cli daily --path --tz Europe/Berlin`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPeriodic(cmd, obsidian.PeriodDay, "")
+	},
+}
+
+var dailyPrevCmd = &cobra.Command{
+	Use:   "prev",
+	Short: "Opens the nearest existing daily note before today (or --date)",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPeriodic(cmd, obsidian.PeriodDay, actions.DailyPrev)
+	},
+}
+
+var dailyNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Opens the nearest existing daily note after today (or --date)",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPeriodic(cmd, obsidian.PeriodDay, actions.DailyNext)
+	},
+}
+
+var dailyDigestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Collects the daily notes in a date range into one digest",
+	Long: `Collects every daily note from --from to --to (inclusive), or in the
+week containing today (or --date) with --week, and concatenates them
+under a date heading and a link per note; headings in the notes are
+moved one level down. Daily notes are found by parsing note names
+with the configured daily note format. --heading-format sets the
+Moment.js format of the headings. Of the daily flags, only --vault,
+--date and --tz apply.
+
+--section keeps only one heading's section of each note and --completed
+only its completed tasks. --write saves the Markdown digest as a note.
+
+Examples:
+  notesmd-cli daily digest --from 2026-10-01 --to 2026-10-07
+  notesmd-cli daily digest --week --section "## Log"
+  notesmd-cli daily digest --from -7d --completed --format json
+  notesmd-cli daily digest --week --date 2026-10-05 --write Weekly/2026-W41`,
+	Args: cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
+		for _, name := range digestIgnoredFlags {
+			if cmd.Flags().Changed(name) {
+				log.Fatalf("--%s cannot be used with daily digest", name)
+			}
+		}
+
 		vault := obsidian.Vault{Name: vaultName}
-		uri := obsidian.Uri{}
 
-		err := actions.DailyNote(&vault, &uri, actions.DailyParams{
-			Content:   dailyContent,
-			UseEditor: resolveUseEditor(cmd, &vault),
+		err := actions.DailyDigest(&vault, actions.DigestParams{
+			From:          digestFrom,
+			To:            digestTo,
+			Week:          digestWeek,
+			Date:          dailyDate,
+			Location:      loadLocation(dailyTZ),
+			Section:       digestSection,
+			Completed:     digestCompleted,
+			Format:        digestFormat,
+			HeadingFormat: digestHeadingFormat,
+			Write:         digestWrite,
+			Output:        os.Stdout,
 		})
 		if err != nil {
 			log.Fatal(err)
@@ -27,11 +112,121 @@ var DailyCmd = &cobra.Command{
 	},
 }
 
+// digestIgnoredFlags are the daily flags that do not apply to daily digest,
+// which only uses --vault, --date and --tz.
+var digestIgnoredFlags = []string{
+	"content", "stdin", "editor", "no-open", "print", "path", "under", "prepend",
+	"timestamp", "timestamp-format", "rollover", "rollover-under", "rollover-originals",
+}
+
+var digestFrom string
+var digestTo string
+var digestWeek bool
+var digestSection string
+var digestCompleted bool
+var digestFormat string
+var digestWrite string
+var digestHeadingFormat string
+
 var dailyContent string
+var dailyStdin bool
+var dailyDate string
+var dailyNoOpen bool
+var dailyPrint bool
+var dailyPath bool
+var dailyTZ string
+var dailyUnder string
+var dailyPrepend bool
+var dailyTimestamp bool
+var dailyTimestampFormat string
+var dailyRollover bool
+var dailyRolloverUnder string
+var dailyRolloverOriginals string
+
+// runPeriodic creates or opens the periodic note for period using the shared
+// daily/periodic flags.
+func runPeriodic(cmd *cobra.Command, period, jump string) {
+	vault := obsidian.Vault{Name: vaultName}
+	uri := obsidian.Uri{}
+
+	location := loadLocation(dailyTZ)
+
+	var rollover *actions.RolloverParams
+	if dailyRollover {
+		rollover = &actions.RolloverParams{Under: dailyRolloverUnder, Originals: dailyRolloverOriginals}
+	}
+
+	noteContent, rawContent := resolveContent(dailyContent, dailyStdin)
+
+	err := actions.PeriodicNote(&vault, &uri, period, actions.DailyParams{
+		Content:         noteContent,
+		RawContent:      rawContent,
+		UseEditor:       resolveUseEditor(cmd, &vault),
+		Date:            dailyDate,
+		Jump:            jump,
+		Location:        location,
+		NoOpen:          dailyNoOpen,
+		Under:           dailyUnder,
+		Prepend:         dailyPrepend,
+		Timestamp:       dailyTimestamp,
+		TimestampFormat: dailyTimestampFormat,
+		Rollover:        rollover,
+		Print:           dailyPrint,
+		PrintPath:       dailyPath,
+		Output:          os.Stdout,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadLocation loads the --tz time zone, or returns nil for local time.
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("invalid time zone %q: %v", name, err)
+	}
+	return loc
+}
+
+// addPeriodicFlags registers the flags shared by daily and the other periodic
+// note commands, inherited by their subcommands.
+func addPeriodicFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name (not required if default is set)")
+	cmd.PersistentFlags().StringVarP(&dailyContent, "content", "c", "", "text to add to the note (appends if note exists; \"-\" reads standard input)")
+	cmd.PersistentFlags().BoolVar(&dailyStdin, "stdin", false, "read the text to add from standard input, without escape processing")
+	cmd.PersistentFlags().BoolP("editor", "e", false, "open in editor instead of Obsidian")
+	cmd.PersistentFlags().StringVar(&dailyDate, "date", "", "a day in the note's period (e.g. 2026-10-01, yesterday, -2d, next monday)")
+	cmd.PersistentFlags().BoolVar(&dailyNoOpen, "no-open", false, "create or update the note without opening it")
+	cmd.PersistentFlags().BoolVar(&dailyPrint, "print", false, "print the note's contents instead of opening it")
+	cmd.PersistentFlags().BoolVar(&dailyPath, "path", false, "print the note's path instead of opening it")
+	cmd.PersistentFlags().StringVar(&dailyTZ, "tz", "", "time zone for today's date (e.g. Europe/Berlin)")
+	cmd.PersistentFlags().StringVar(&dailyUnder, "under", "", "add content at the end of this heading's section (e.g. \"## Log\")")
+	cmd.PersistentFlags().BoolVar(&dailyPrepend, "prepend", false, "add content directly after the frontmatter")
+	cmd.PersistentFlags().BoolVar(&dailyTimestamp, "timestamp", false, "prefix content with the current time")
+	cmd.PersistentFlags().StringVar(&dailyTimestampFormat, "timestamp-format", "", "Moment.js format for --timestamp (default: Templates time format, HH:mm)")
+	cmd.PersistentFlags().BoolVar(&dailyRollover, "rollover", false, "when creating the note, copy unfinished tasks from the previous one")
+	cmd.PersistentFlags().StringVar(&dailyRolloverUnder, "rollover-under", "", "heading for rolled over tasks (default: Rollover Daily Todos setting, or the end of the note)")
+	cmd.PersistentFlags().StringVar(&dailyRolloverOriginals, "rollover-originals", "", "what to do with the original tasks: keep|mark|delete (default: Rollover Daily Todos setting, or keep)")
+	cmd.MarkFlagsMutuallyExclusive("under", "prepend")
+	cmd.MarkFlagsMutuallyExclusive("content", "stdin")
+}
 
 func init() {
-	DailyCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name (not required if default is set)")
-	DailyCmd.Flags().StringVarP(&dailyContent, "content", "c", "", "text to add to daily note (appends if note exists)")
-	DailyCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian")
+	addPeriodicFlags(DailyCmd)
+	dailyDigestCmd.Flags().StringVar(&digestFrom, "from", "", "first day of the digest (e.g. 2026-10-01, -7d)")
+	dailyDigestCmd.Flags().StringVar(&digestTo, "to", "", "last day of the digest (default: today)")
+	dailyDigestCmd.Flags().BoolVar(&digestWeek, "week", false, "digest the week containing today (or --date)")
+	dailyDigestCmd.Flags().StringVar(&digestSection, "section", "", "only include this heading's section (e.g. \"## Log\")")
+	dailyDigestCmd.Flags().BoolVar(&digestCompleted, "completed", false, "only include completed tasks")
+	dailyDigestCmd.Flags().StringVar(&digestFormat, "format", "markdown", "output format: markdown|json")
+	dailyDigestCmd.Flags().StringVar(&digestHeadingFormat, "heading-format", "YYYY-MM-DD", "Moment.js date format of the per-day headings")
+	dailyDigestCmd.Flags().StringVar(&digestWrite, "write", "", "save the digest as this note (e.g. Weekly/2026-W41)")
+	dailyDigestCmd.MarkFlagsMutuallyExclusive("week", "from")
+	dailyDigestCmd.MarkFlagsMutuallyExclusive("week", "to")
+	DailyCmd.AddCommand(dailyPrevCmd, dailyNextCmd, dailyDigestCmd)
 	rootCmd.AddCommand(DailyCmd)
 }
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var exportOut string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export notes for use outside the vault",
+}
+
+var exportHTMLCmd = &cobra.Command{
+	Use:   "html [note|folder]",
+	Short: "Export a note or folder as standalone HTML pages",
+	Long: `Render a note, a folder or the whole vault as HTML pages that can be
+opened in any browser or served as a static site.
+
+Wikilinks, embeds and Markdown links between exported notes become
+relative links, embedded notes are inlined and linked or embedded
+attachments are copied to _attachments. Each page lists the pages that
+link to it, and folder exports get an index.html unless the folder has an
+"index" note.
+
+Notes excluded by userIgnoreFilters (.obsidian/app.json) or with
+"publish: false" in their frontmatter are not exported, and links to them
+are shown as unresolved.
+
+Examples:
+  notesmd-cli export html "Project Plan" --out ~/Desktop/plan
+  notesmd-cli export html Public --out site
+  notesmd-cli export html --out site --vault Work`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{
+			Target: target,
+			Out:    exportOut,
+			Output: os.Stdout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	exportHTMLCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	exportHTMLCmd.Flags().StringVar(&exportOut, "out", "", "directory to write the pages to")
+	exportHTMLCmd.MarkFlagRequired("out")
+	exportCmd.AddCommand(exportHTMLCmd)
+	rootCmd.AddCommand(exportCmd)
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
@@ -10,56 +12,181 @@ import (
 )
 
 var fmPrint bool
+var fmGet string
+var fmDefault string
+var fmFormat string
 var fmEdit bool
 var fmDelete bool
 var fmKey string
 var fmValue string
+var fmAdd []string
+var fmRemove []string
+var fmToggle []string
+var fmIncrement []string
+var fmRename string
+var fmGlob string
+var fmFolder string
+var fmTag string
+var fmWhere []string
+var fmStdin bool
+var fmDryRun bool
+var fmConvertTo string
 
 var frontmatterCmd = &cobra.Command{
-	Use:     "frontmatter <note>",
+	Use:     "frontmatter [note]",
 	Aliases: []string{"fm"},
 	Short:   "View or modify note frontmatter",
-	Long: `View or modify YAML frontmatter in a note.
+	Long: `View or modify frontmatter in a note. YAML ("---"), TOML ("+++")
+and JSON (";;;") frontmatter are supported; edits are written back in
+the note's format.
 
-Use --print to display frontmatter, --edit to modify a key,
-or --delete to remove a key.
+Use --print to display frontmatter, --get to print a single value,
+--edit to modify a key, or --delete to remove a key. Dotted keys such
+as "project.owner" address nested maps.
+
+--get exits with an error if the key is missing, unless --default is
+given. Use --format json with --print or --get for typed JSON output.
+
+Values are typed: true/false, null, numbers, ISO dates and JSON
+values are written as such; anything else is written as a string.
+Property types declared in .obsidian/types.json take precedence:
+values are coerced to them, or rejected if they cannot be.
+
+Use --add and --remove to change list properties, --toggle to flip
+booleans and --increment to bump numbers. These flags can be repeated.
+Use --rename old=new to rename a key. Edit flags can be combined and
+are applied in the order --edit, --delete, --rename, --add, --remove,
+--toggle, --increment; --print and --get cannot be combined with them.
+
+Instead of a note, select many notes with --glob, --folder, --tag
+and --where (key=value, or key to require the key), or pass note
+paths on stdin with --stdin. Use --dry-run, with a single note or a
+filter, to print a diff per note without writing anything.
 
 Examples:
   notesmd-cli frontmatter "My Note" --print
+  notesmd-cli frontmatter "My Note" --print --format json
+  notesmd-cli frontmatter "My Note" --get status --default todo
   notesmd-cli frontmatter "My Note" --edit --key "status" --value "done"
-  notesmd-cli frontmatter "My Note" --delete --key "draft"`,
-	Args: cobra.ExactArgs(1),
+  notesmd-cli frontmatter "My Note" --edit --key "project.owner" --value "ana"
+  notesmd-cli frontmatter "My Note" --delete --key "draft"
+  notesmd-cli frontmatter "My Note" --add tags=meeting --remove tags=draft
+  notesmd-cli frontmatter "My Note" --toggle done --increment reviews
+  notesmd-cli frontmatter --folder Meetings --rename attendees=people --dry-run
+  notesmd-cli frontmatter --tag project --where status=active --edit --key status --value open
+  find . -name "*.md" -newer ref | notesmd-cli frontmatter --stdin --add tags=recent`,
+	Args: cobra.RangeArgs(0, 1),
 	Run: func(cmd *cobra.Command, args []string) {
-		noteName := args[0]
-		vault := obsidian.Vault{Name: vaultName}
-		note := obsidian.Note{}
-
-		params := actions.FrontmatterParams{
-			NoteName: noteName,
-			Print:    fmPrint,
-			Edit:     fmEdit,
-			Delete:   fmDelete,
-			Key:      fmKey,
-			Value:    fmValue,
-		}
+		runFrontmatter(cmd, args, actions.FrontmatterParams{
+			Print:      fmPrint,
+			Get:        fmGet,
+			Default:    fmDefault,
+			HasDefault: cmd.Flags().Changed("default"),
+			Format:     fmFormat,
+			Edit:       fmEdit,
+			Delete:     fmDelete,
+			Key:        fmKey,
+			Value:      fmValue,
+			Add:        fmAdd,
+			Remove:     fmRemove,
+			Toggle:     fmToggle,
+			Increment:  fmIncrement,
+			Rename:     fmRename,
+		})
+	},
+}
+
+var frontmatterConvertCmd = &cobra.Command{
+	Use:   "convert [note]",
+	Short: "Convert frontmatter between YAML, TOML and JSON",
+	Long: `Rewrite the frontmatter of a note in another format: YAML ("---"),
+TOML ("+++") or JSON (";;;"). Comments in the old frontmatter are lost.
+
+Note filters (--glob, --folder, --tag, --where, --stdin) and --dry-run
+work as for the frontmatter command: with --dry-run the conversion is
+printed as a diff and no note is written.
 
-		output, err := actions.Frontmatter(&vault, &note, params)
-		if err != nil {
+Examples:
+  notesmd-cli frontmatter convert "My Post" --to yaml
+  notesmd-cli frontmatter convert --folder Blog --to yaml --dry-run`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runFrontmatter(cmd, args, actions.FrontmatterParams{ConvertTo: fmConvertTo})
+	},
+}
+
+// runFrontmatter completes params with the note or note filter and runs the
+// frontmatter action.
+func runFrontmatter(cmd *cobra.Command, args []string, params actions.FrontmatterParams) {
+	filter := actions.NoteFilter{
+		Glob:     fmGlob,
+		Folder:   fmFolder,
+		Tag:      fmTag,
+		Where:    fmWhere,
+		Warnings: os.Stderr,
+	}
+	if fmStdin {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			filter.Paths = append(filter.Paths, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
 			log.Fatal(err)
 		}
-
-		if output != "" {
-			fmt.Print(output)
+		if len(filter.Paths) == 0 {
+			log.Fatal("no note paths read from stdin")
 		}
-	},
+	}
+
+	if len(args) == 1 && filter.IsSet() {
+		log.Fatal("a note name cannot be combined with --glob, --folder, --tag, --where or --stdin")
+	}
+	if len(args) == 0 && !filter.IsSet() {
+		log.Fatal("a note name or a note filter (--glob, --folder, --tag, --where, --stdin) is required")
+	}
+
+	if len(args) == 1 {
+		params.NoteName = args[0]
+	}
+	params.Filter = filter
+	params.DryRun = fmDryRun
+	params.Output = os.Stdout
+
+	vault := obsidian.Vault{Name: vaultName}
+	note := obsidian.Note{}
+
+	output, err := actions.Frontmatter(&vault, &note, params)
+	if output != "" {
+		fmt.Print(output)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 func init() {
-	frontmatterCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	frontmatterCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	frontmatterCmd.Flags().BoolVarP(&fmPrint, "print", "p", false, "print frontmatter")
+	frontmatterCmd.Flags().StringVarP(&fmGet, "get", "g", "", "print the value of a frontmatter key")
+	frontmatterCmd.Flags().StringVar(&fmDefault, "default", "", "value to print with --get when the key is missing")
+	frontmatterCmd.Flags().StringVar(&fmFormat, "format", "yaml", "output format for --print and --get: yaml|json")
 	frontmatterCmd.Flags().BoolVarP(&fmEdit, "edit", "e", false, "edit a frontmatter key")
 	frontmatterCmd.Flags().BoolVarP(&fmDelete, "delete", "d", false, "delete a frontmatter key")
 	frontmatterCmd.Flags().StringVarP(&fmKey, "key", "k", "", "key to edit or delete")
 	frontmatterCmd.Flags().StringVar(&fmValue, "value", "", "value to set (required for --edit)")
+	frontmatterCmd.Flags().StringArrayVar(&fmAdd, "add", nil, "add an item to a list property (key=value)")
+	frontmatterCmd.Flags().StringArrayVar(&fmRemove, "remove", nil, "remove an item from a list property (key=value)")
+	frontmatterCmd.Flags().StringArrayVar(&fmToggle, "toggle", nil, "flip a boolean property")
+	frontmatterCmd.Flags().StringArrayVar(&fmIncrement, "increment", nil, "increment a number property (key or key=step)")
+	frontmatterCmd.Flags().StringVar(&fmRename, "rename", "", "rename a frontmatter key (old=new)")
+	frontmatterCmd.PersistentFlags().StringVar(&fmGlob, "glob", "", "apply to notes whose vault path matches a glob (e.g. \"Meetings/**/*.md\")")
+	frontmatterCmd.PersistentFlags().StringVar(&fmFolder, "folder", "", "apply to notes in a folder")
+	frontmatterCmd.PersistentFlags().StringVar(&fmTag, "tag", "", "apply to notes with a tag (frontmatter or inline)")
+	frontmatterCmd.PersistentFlags().StringArrayVar(&fmWhere, "where", nil, "apply to notes with a property (key=value, or key)")
+	frontmatterCmd.PersistentFlags().BoolVar(&fmStdin, "stdin", false, "read note paths from stdin, one per line")
+	frontmatterCmd.PersistentFlags().BoolVar(&fmDryRun, "dry-run", false, "print a diff per note instead of writing")
+	frontmatterConvertCmd.Flags().StringVar(&fmConvertTo, "to", "", "format to convert to: yaml|toml|json")
+	frontmatterConvertCmd.MarkFlagRequired("to")
+	frontmatterCmd.AddCommand(frontmatterConvertCmd)
 	rootCmd.AddCommand(frontmatterCmd)
 }
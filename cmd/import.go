@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var importTo string
+var importOverwrite bool
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import files from outside the vault as notes",
+}
+
+var importHTMLCmd = &cobra.Command{
+	Use:   "html <file...>",
+	Short: "Convert local HTML files to Markdown notes",
+	Long: `Convert saved web pages and HTML exports (Confluence, Google Docs
+"Download as HTML") to Markdown notes. Headings, lists, tables, code,
+links and images are kept; scripts and styles are dropped.
+
+Each note is named after the page title and gets source, title and
+imported properties. Local images are copied into the attachment folder
+(.obsidian/app.json) and embedded. Nothing is downloaded: remote images
+stay links. Links between the imported files become wikilinks.
+
+Examples:
+  notesmd-cli import html ~/Downloads/article.html --to Clippings
+  notesmd-cli import html export/*.html --to Confluence/Team --overwrite`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:     args,
+			Folder:    importTo,
+			Overwrite: importOverwrite,
+			Output:    os.Stdout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	importHTMLCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	importHTMLCmd.Flags().StringVar(&importTo, "to", "", "vault folder for the notes (default: the folder for new notes)")
+	importHTMLCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "replace notes that already exist")
+	importCmd.AddCommand(importHTMLCmd)
+	rootCmd.AddCommand(importCmd)
+}
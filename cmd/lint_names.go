@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var lintNamesFolder string
+var lintNamesFix bool
+var lintNamesDryRun bool
+var lintNamesFormat string
+
+var lintNamesCmd = &cobra.Command{
+	Use:   "lint-names",
+	Short: "Find notes with names that break links or cross-platform sync",
+	Long: `Find notes whose file names contain characters that break Obsidian
+links (# ^ [ ] |) or that Windows does not allow (: * ? " < > \, trailing
+dots and spaces, reserved names such as CON), and suggest safe names.
+
+--fix renames the notes and updates links to them. The replacement of
+unsafe characters can be configured in .notesmd/names.yaml:
+  replacement: "-"      # default: a space
+  replace:
+    ":": " -"
+  windows: false        # only check for link-breaking characters
+
+The command exits non-zero if unsafe names are left.
+
+Examples:
+  notesmd-cli lint-names
+  notesmd-cli lint-names --folder Meetings --format json
+  notesmd-cli lint-names --fix --dry-run
+  notesmd-cli lint-names --fix`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+
+		err := actions.LintNames(&vault, &note, actions.LintNamesParams{
+			Folder: lintNamesFolder,
+			Fix:    lintNamesFix,
+			DryRun: lintNamesDryRun,
+			Format: lintNamesFormat,
+			Output: os.Stdout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	lintNamesCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	lintNamesCmd.Flags().StringVar(&lintNamesFolder, "folder", "", "only check notes in this folder")
+	lintNamesCmd.Flags().BoolVar(&lintNamesFix, "fix", false, "rename notes to safe names and update links")
+	lintNamesCmd.Flags().BoolVar(&lintNamesDryRun, "dry-run", false, "with --fix, show the renames without making them")
+	lintNamesCmd.Flags().StringVar(&lintNamesFormat, "format", "text", "output format: text|json")
+	rootCmd.AddCommand(lintNamesCmd)
+}
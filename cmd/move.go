@@ -4,11 +4,13 @@ import (
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 )
 
 var shouldOpen bool
+var moveSanitize bool
 var moveCmd = &cobra.Command{
 	Use:     "move",
 	Aliases: []string{"m"},
@@ -25,6 +27,8 @@ var moveCmd = &cobra.Command{
 			NewNoteName:     newName,
 			ShouldOpen:      shouldOpen,
 			UseEditor:       resolveUseEditor(cmd, &vault),
+			Sanitize:        moveSanitize,
+			Output:          os.Stdout,
 		}
 		err := actions.MoveNote(&vault, &note, &uri, params)
 		if err != nil {
@@ -36,6 +40,7 @@ var moveCmd = &cobra.Command{
 func init() {
 	moveCmd.Flags().BoolVarP(&shouldOpen, "open", "o", false, "open new note")
 	moveCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	moveCmd.Flags().BoolVar(&moveSanitize, "sanitize", false, "replace characters that are unsafe in note names instead of failing")
 	moveCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
 	rootCmd.AddCommand(moveCmd)
 }
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var newTemplate string
+var newProperties []string
+var newContent string
+var newStdin bool
+var newSanitize bool
+
+var newNoteCmd = &cobra.Command{
+	Use:     "new [title]",
+	Aliases: []string{"unique"},
+	Short:   "Creates a note with a unique timestamp name",
+	Long: `Creates a note named with the current timestamp, like Obsidian's
+Unique note creator core plugin, and prints its path.
+
+The folder, timestamp format (default YYYYMMDDHHmm) and template are read
+from .obsidian/zk-prefixer.json. Without a configured folder the default
+location for new notes is used. If a note already uses the timestamp,
+the next free one is taken. An optional title is added after the
+timestamp.
+
+Examples:
+  notesmd-cli new
+  notesmd-cli new "Spaced repetition beats cramming"
+  notesmd-cli new Reading notes --template Literature --property source=book
+  echo "- first thought" | notesmd-cli new --stdin`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		noteContent, rawContent := resolveContent(newContent, newStdin)
+
+		notePath, err := actions.UniqueNote(&vault, &uri, actions.UniqueNoteParams{
+			Title:      strings.Join(args, " "),
+			Template:   newTemplate,
+			Properties: newProperties,
+			Content:    noteContent,
+			RawContent: rawContent,
+			ShouldOpen: shouldOpen,
+			UseEditor:  resolveUseEditor(cmd, &vault),
+			Sanitize:   newSanitize,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(notePath)
+	},
+}
+
+func init() {
+	newNoteCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	newNoteCmd.Flags().BoolVar(&shouldOpen, "open", false, "open created note")
+	newNoteCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
+	newNoteCmd.Flags().StringVarP(&newContent, "content", "c", "", "text to add to the note (\"-\" reads standard input)")
+	newNoteCmd.Flags().BoolVar(&newStdin, "stdin", false, "read the text to add from standard input, without escape processing")
+	newNoteCmd.Flags().StringVar(&newTemplate, "template", "", "template to use instead of the Unique note creator's")
+	newNoteCmd.Flags().StringArrayVar(&newProperties, "property", nil, "key=value frontmatter property (repeatable)")
+	newNoteCmd.Flags().BoolVar(&newSanitize, "sanitize", false, "replace characters that are unsafe in note names instead of failing")
+	newNoteCmd.MarkFlagsMutuallyExclusive("content", "stdin")
+	rootCmd.AddCommand(newNoteCmd)
+}
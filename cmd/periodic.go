@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var periodicPeriod string
+
+var periodicCmd = &cobra.Command{
+	Use:   "periodic",
+	Short: "Creates or opens a periodic note in vault",
+	Long: `Creates or opens the daily, weekly, monthly, quarterly or yearly note
+for the period containing today (or --date).
+
+Folders, formats and templates are read from the Periodic Notes plugin
+(.obsidian/plugins/periodic-notes/data.json); daily notes use the core
+Daily Notes settings. Without a config, notes go in the vault root named
+YYYY-MM-DD, gggg-[W]ww, YYYY-MM, YYYY-[Q]Q and YYYY.
+
+Examples:
+  notesmd-cli periodic --period week
+  notesmd-cli periodic --period month --date "-1m" --print`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		period, err := obsidian.ParsePeriod(periodicPeriod)
+		if err != nil {
+			log.Fatal(err)
+		}
+		runPeriodic(cmd, period, "")
+	},
+}
+
+// newPeriodCommand returns a shortcut command such as "weekly" for a period.
+func newPeriodCommand(use, alias, period string) *cobra.Command {
+	return &cobra.Command{
+		Use:     use,
+		Aliases: []string{alias},
+		Short:   "Creates or opens " + use + " note in vault",
+		Long: `Creates or opens the ` + use + ` note for the period containing today (or
+--date). Same as "periodic --period ` + period + `".`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			runPeriodic(cmd, period, "")
+		},
+	}
+}
+
+func init() {
+	addPeriodicFlags(periodicCmd)
+	periodicCmd.Flags().StringVarP(&periodicPeriod, "period", "p", obsidian.PeriodDay, "period: day|week|month|quarter|year")
+	rootCmd.AddCommand(periodicCmd)
+
+	for _, c := range []*cobra.Command{
+		newPeriodCommand("weekly", "w", obsidian.PeriodWeek),
+		newPeriodCommand("monthly", "mo", obsidian.PeriodMonth),
+		newPeriodCommand("quarterly", "q", obsidian.PeriodQuarter),
+		newPeriodCommand("yearly", "y", obsidian.PeriodYear),
+	} {
+		addPeriodicFlags(c)
+		rootCmd.AddCommand(c)
+	}
+}
@@ -10,12 +10,33 @@ import (
 )
 
 var includeMentions bool
+var printSection string
+var printBlock string
+var printOutline bool
+var printExpandEmbeds bool
 
 var printCmd = &cobra.Command{
 	Use:     "print",
 	Aliases: []string{"p"},
 	Short:   "Print contents of note",
-	Args:    cobra.ExactArgs(1),
+	Long: `Print the contents of a note, or just part of it.
+
+--section prints a heading and its subsections; nested headings can be
+given as a path ("Project#Decisions"). --block prints the paragraph or
+list item tagged with a block ID ("^abc123"), or the list, quote, table
+or code block followed by the ID on its own line. --outline prints only
+the headings.
+
+--expand-embeds replaces embeds of notes, sections and blocks
+(![[Note]], ![[Note#Heading]], ![[Note#^abc123]]) with what they embed,
+recursively. Image, PDF and other attachment embeds are left as they are.
+
+Examples:
+  notesmd-cli print "Meeting notes" --section Decisions
+  notesmd-cli print "Meeting notes" --block abc123
+  notesmd-cli print "Meeting notes" --outline
+  notesmd-cli print "Project brief" --expand-embeds`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		noteName := args[0]
 		vault := obsidian.Vault{Name: vaultName}
@@ -23,6 +44,10 @@ var printCmd = &cobra.Command{
 		params := actions.PrintParams{
 			NoteName:        noteName,
 			IncludeMentions: includeMentions,
+			Section:         printSection,
+			Block:           printBlock,
+			Outline:         printOutline,
+			ExpandEmbeds:    printExpandEmbeds,
 		}
 		contents, err := actions.PrintNote(&vault, &note, params)
 		if err != nil {
@@ -35,5 +60,10 @@ var printCmd = &cobra.Command{
 func init() {
 	printCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	printCmd.Flags().BoolVarP(&includeMentions, "mentions", "m", false, "include linked mentions at the end")
+	printCmd.Flags().StringVarP(&printSection, "section", "s", "", "print only this heading and its subsections")
+	printCmd.Flags().StringVarP(&printBlock, "block", "b", "", "print only the block with this ID")
+	printCmd.Flags().BoolVar(&printOutline, "outline", false, "print only the headings")
+	printCmd.Flags().BoolVar(&printExpandEmbeds, "expand-embeds", false, "inline embedded notes, sections and blocks")
+	printCmd.MarkFlagsMutuallyExclusive("section", "block", "outline")
 	rootCmd.AddCommand(printCmd)
 }
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var propertiesDryRun bool
+var propertiesMap string
+var propertiesFormat string
+
+var propertiesCmd = &cobra.Command{
+	Use:     "properties",
+	Aliases: []string{"props"},
+	Short:   "Manage frontmatter properties across the vault",
+	Long: `Rename, merge, remap and list frontmatter properties across every note
+in the vault. Use --dry-run to print a diff per note without writing.
+
+Examples:
+  notesmd-cli properties list
+  notesmd-cli properties rename attendees people
+  notesmd-cli properties merge tag tags
+  notesmd-cli properties set-values status --map "todo=open,doing=active" --dry-run`,
+}
+
+var propertiesRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a property in every note",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPropertiesEdit(func(vault *obsidian.Vault, note *obsidian.Note, params actions.PropertiesParams) (string, error) {
+			return actions.RenameProperty(vault, note, args[0], args[1], params)
+		})
+	},
+}
+
+var propertiesMergeCmd = &cobra.Command{
+	Use:   "merge <from> <into>",
+	Short: "Merge one property into another in every note",
+	Long: `Merge the values of one property into another and remove the first.
+When a note has both, the values are combined into a list without
+duplicates.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPropertiesEdit(func(vault *obsidian.Vault, note *obsidian.Note, params actions.PropertiesParams) (string, error) {
+			return actions.MergeProperties(vault, note, args[0], args[1], params)
+		})
+	},
+}
+
+var propertiesSetValuesCmd = &cobra.Command{
+	Use:   "set-values <key>",
+	Short: "Replace property values in every note",
+	Long: `Replace values of a property using --map "old=new,old2=new2". Items of
+list properties are mapped one by one; other values are left alone.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPropertiesEdit(func(vault *obsidian.Vault, note *obsidian.Note, params actions.PropertiesParams) (string, error) {
+			return actions.SetPropertyValues(vault, note, args[0], propertiesMap, params)
+		})
+	},
+}
+
+var propertiesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List properties with their types and usage counts",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+
+		properties, err := actions.ListProperties(&vault, &note)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch propertiesFormat {
+		case "json":
+			output, err := json.MarshalIndent(properties, "", "  ")
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(output))
+		case "text":
+			if len(properties) == 0 {
+				fmt.Println("No properties found.")
+				return
+			}
+			formatPropertiesTable(os.Stdout, properties)
+		default:
+			log.Fatalf("invalid format '%s': expected text or json", propertiesFormat)
+		}
+	},
+}
+
+func runPropertiesEdit(run func(*obsidian.Vault, *obsidian.Note, actions.PropertiesParams) (string, error)) {
+	vault := obsidian.Vault{Name: vaultName}
+	note := obsidian.Note{}
+
+	output, err := run(&vault, &note, actions.PropertiesParams{
+		DryRun:   propertiesDryRun,
+		Output:   os.Stdout,
+		Warnings: os.Stderr,
+	})
+	if output != "" {
+		fmt.Print(output)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func formatPropertiesTable(w io.Writer, properties []actions.PropertyUsage) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROPERTY\tTYPES\tNOTES")
+	for _, p := range properties {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", p.Name, strings.Join(p.Types, ", "), p.Count)
+	}
+	tw.Flush()
+}
+
+func init() {
+	propertiesCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	propertiesCmd.PersistentFlags().BoolVar(&propertiesDryRun, "dry-run", false, "print a diff per note instead of writing")
+	propertiesSetValuesCmd.Flags().StringVar(&propertiesMap, "map", "", "value mapping (old=new,old2=new2)")
+	propertiesSetValuesCmd.MarkFlagRequired("map")
+	propertiesListCmd.Flags().StringVar(&propertiesFormat, "format", "text", "output format: text|json")
+	propertiesCmd.AddCommand(propertiesRenameCmd, propertiesMergeCmd, propertiesSetValuesCmd, propertiesListCmd)
+	rootCmd.AddCommand(propertiesCmd)
+}
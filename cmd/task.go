@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var taskDue string
+var taskUnder string
+
+var taskCmd = &cobra.Command{
+	Use:     "task",
+	Aliases: []string{"t"},
+	Short:   "Add, complete or toggle tasks in notes",
+	Long: `Add, complete or toggle Markdown tasks ("- [ ] ...") in notes.
+
+Tasks are addressed as <note>:<line>, where line is the one-based line number
+of the task in the note. Completion dates (✅) and recurrence rules (🔁) follow
+the Obsidian Tasks plugin format.
+
+Examples:
+  notesmd-cli task done "Projects/Home:12"
+  notesmd-cli task toggle "Projects/Home:12"
+  notesmd-cli task add "Projects/Home" "Call plumber" --due friday --under "## Todo"`,
+}
+
+var taskDoneCmd = &cobra.Command{
+	Use:   "done <note>:<line>",
+	Short: "Mark a task as done",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskUpdate(args[0], actions.CompleteTask)
+	},
+}
+
+var taskToggleCmd = &cobra.Command{
+	Use:   "toggle <note>:<line>",
+	Short: "Toggle a task between open and done",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskUpdate(args[0], actions.ToggleTask)
+	},
+}
+
+var taskAddCmd = &cobra.Command{
+	Use:   "add <note> <text>",
+	Short: "Add a task to a note",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+
+		output, err := actions.AddTask(&vault, &note, actions.AddTaskParams{
+			NoteName: args[0],
+			Text:     args[1],
+			Due:      taskDue,
+			Under:    taskUnder,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(output)
+	},
+}
+
+func runTaskUpdate(ref string, update func(obsidian.VaultManager, obsidian.NoteManager, actions.TaskParams) (string, error)) {
+	noteName, line, err := actions.ParseTaskRef(ref)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	vault := obsidian.Vault{Name: vaultName}
+	note := obsidian.Note{}
+
+	output, err := update(&vault, &note, actions.TaskParams{NoteName: noteName, Line: line})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(output)
+}
+
+func init() {
+	taskCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	taskAddCmd.Flags().StringVar(&taskDue, "due", "", "due date (e.g. 2026-10-23, today, tomorrow, friday, next monday)")
+	taskAddCmd.Flags().StringVar(&taskUnder, "under", "", "heading to add the task under (e.g. \"## Todo\"), created if missing")
+	taskCmd.AddCommand(taskDoneCmd, taskToggleCmd, taskAddCmd)
+	rootCmd.AddCommand(taskCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var templateTitle string
+var templateDate string
+
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"tpl", "templates"},
+	Short:   "Work with Obsidian templates",
+}
+
+var templateRenderCmd = &cobra.Command{
+	Use:   "render <name>",
+	Short: "Print a template with its variables expanded",
+	Long: `Print a template from the Templates folder (.obsidian/templates.json)
+with {{title}}, {{date}}, {{time}}, {{date:FORMAT}}, {{time:FORMAT}},
+{{date+1d}}, {{yesterday}} and {{tomorrow}} expanded the way Obsidian
+does. {{date}} and {{time}} use the date and time formats configured
+for the Templates plugin.
+
+Examples:
+  notesmd-cli template render Meeting
+  notesmd-cli template render Daily --date tomorrow --title "2026-10-20"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+
+		output, err := actions.RenderTemplate(&vault, actions.TemplateParams{
+			Name:  args[0],
+			Title: templateTitle,
+			Date:  templateDate,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(output)
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the templates in the Templates folder",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+
+		names, err := actions.ListTemplates(&vault)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	},
+}
+
+func init() {
+	templateCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	templateRenderCmd.Flags().StringVar(&templateTitle, "title", "", "value of {{title}} (default: the template's name)")
+	templateRenderCmd.Flags().StringVar(&templateDate, "date", "", "value of {{date}} (e.g. 2026-10-01, tomorrow, -1d)")
+	templateCmd.AddCommand(templateRenderCmd, templateListCmd)
+	rootCmd.AddCommand(templateCmd)
+}
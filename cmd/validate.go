@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var validateFolder string
+var validateFormat string
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check note frontmatter against folder schemas",
+	Long: `Check note frontmatter against the property schemas in .notesmd/schema.yaml.
+
+Each schema applies to the notes in a folder and its subfolders (the most
+specific folder wins) and declares property types, required properties and
+allowed values. Missing, mistyped and unknown properties are reported per
+file, and the command exits non-zero if any note has problems.
+
+Example .notesmd/schema.yaml:
+  schemas:
+    - folder: Meetings
+      properties:
+        date: {type: date, required: true}
+        attendees: {type: list, required: true}
+        status: {type: text, enum: [planned, done, cancelled]}
+        tags: {type: list}
+
+Examples:
+  notesmd-cli validate
+  notesmd-cli validate --folder Meetings --format json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+
+		err := actions.Validate(&vault, &note, actions.ValidateParams{
+			Filter: actions.NoteFilter{Folder: validateFolder},
+			Format: validateFormat,
+			Output: os.Stdout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	validateCmd.Flags().StringVar(&validateFolder, "folder", "", "only check notes in this folder")
+	validateCmd.Flags().StringVar(&validateFormat, "format", "text", "output format: text|json")
+	rootCmd.AddCommand(validateCmd)
+}
@@ -1,6 +1,10 @@
 package mocks
 
-import "github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+import (
+	"io"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
 
 type MockNoteManager struct {
 	DeleteErr           error
@@ -12,13 +16,16 @@ type MockNoteManager struct {
 	FindBacklinksResult []obsidian.NoteMatch
 	NoMatches           bool
 	Contents            string
+	SetContentsValue    string
+	NotesList           []string          // overrides the default GetNotesList result
+	NoteContents        map[string]string // per-note contents, updated by SetContents
 }
 
 func (m *MockNoteManager) Delete(string) error {
 	return m.DeleteErr
 }
 
-func (m *MockNoteManager) Move(string, string) error {
+func (m *MockNoteManager) Move(string, string, io.Writer) error {
 	return m.MoveErr
 }
 
@@ -26,18 +33,28 @@ func (m *MockNoteManager) UpdateLinks(string, string, string) error {
 	return m.UpdateLinksError
 }
 
-func (m *MockNoteManager) GetContents(string, string) (string, error) {
+func (m *MockNoteManager) GetContents(_ string, noteName string) (string, error) {
+	if contents, ok := m.NoteContents[noteName]; ok {
+		return contents, m.GetContentsError
+	}
 	if m.Contents != "" {
 		return m.Contents, m.GetContentsError
 	}
 	return "example contents", m.GetContentsError
 }
 
-func (m *MockNoteManager) SetContents(string, string, string) error {
+func (m *MockNoteManager) SetContents(_ string, noteName string, content string) error {
+	m.SetContentsValue = content
+	if m.NoteContents != nil && m.SetContentsError == nil {
+		m.NoteContents[noteName] = content
+	}
 	return m.SetContentsError
 }
 
 func (m *MockNoteManager) GetNotesList(string) ([]string, error) {
+	if m.NotesList != nil {
+		return m.NotesList, m.GetContentsError
+	}
 	return []string{"note1", "note2", "note3"}, m.GetContentsError
 }
 
@@ -1,11 +1,18 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 )
 
@@ -14,8 +21,24 @@ type CreateParams struct {
 	ShouldAppend    bool
 	ShouldOverwrite bool
 	Content         string
+	RawContent      bool // write Content as is, without NormalizeContent
 	ShouldOpen      bool
 	UseEditor       bool
+	Validate        bool      // check the note against its folder's schema
+	Warnings        io.Writer // receives schema warnings when Validate is set
+	Under           string    // insert content under this heading
+	Prepend         bool      // insert content after the frontmatter
+	Timestamp       bool      // prefix content with the current time
+	TimestampFormat string    // Moment.js format; defaults to the Templates time format
+	// Template names a template in the core Templates plugin's folder to
+	// start a new note from. Properties are "key=value" frontmatter
+	// properties merged into a new note. Both are ignored when the note
+	// already exists, unless it is overwritten.
+	Template   string
+	Properties []string
+	// Sanitize replaces characters that are unsafe in the name of a new note
+	// instead of failing (see obsidian.NamePolicy).
+	Sanitize bool
 }
 
 func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params CreateParams) error {
@@ -30,6 +53,10 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		return err
 	}
 
+	if params.Under != "" && params.Prepend {
+		return errors.New("--under and --prepend cannot be used together")
+	}
+
 	// Prepend configured default folder when note name has no explicit path.
 	params.NoteName = obsidian.ApplyDefaultFolder(params.NoteName, vaultPath)
 
@@ -39,17 +66,58 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		return err
 	}
 
+	// Check the name of new notes for characters that break links or sync.
+	if _, statErr := os.Stat(notePath); os.IsNotExist(statErr) {
+		params.NoteName, err = checkNoteName(vaultPath, params.NoteName, params.Sanitize)
+		if err != nil {
+			return err
+		}
+		notePath, err = obsidian.ValidatePath(vaultPath, obsidian.AddMdSuffix(params.NoteName))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create any intermediate directories the note path requires.
 	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
 		return fmt.Errorf("failed to create note directory: %w", err)
 	}
 
 	// Write the file directly to disk — no Obsidian required.
-	normalizedContent := NormalizeContent(params.Content)
-	if err := WriteNoteFile(notePath, normalizedContent, params.ShouldAppend, params.ShouldOverwrite); err != nil {
+	normalizedContent := params.Content
+	if !params.RawContent {
+		normalizedContent = NormalizeContent(params.Content)
+	}
+	opts := WriteOptions{
+		Append:    params.ShouldAppend,
+		Overwrite: params.ShouldOverwrite,
+		Under:     params.Under,
+		Prepend:   params.Prepend,
+	}
+	if params.Timestamp {
+		opts.Timestamp = entryTimestamp(vaultPath, params.TimestampFormat, time.Now())
+	}
+	if params.Template != "" || len(params.Properties) > 0 {
+		if _, err := os.Stat(notePath); os.IsNotExist(err) || params.ShouldOverwrite {
+			initial, err := newNoteContent(vaultPath, params)
+			if err != nil {
+				return err
+			}
+			if normalizedContent != "" {
+				initial = placeContent(initial, normalizedContent, opts)
+			}
+			normalizedContent = initial
+			opts = WriteOptions{Overwrite: true}
+		}
+	}
+	if err := WriteNoteFile(notePath, normalizedContent, opts); err != nil {
 		return err
 	}
 
+	if params.Validate && params.Warnings != nil {
+		warnSchemaProblems(params.Warnings, vaultPath, obsidian.AddMdSuffix(params.NoteName), notePath)
+	}
+
 	if !params.ShouldOpen {
 		return nil
 	}
@@ -66,30 +134,146 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 	return uri.Execute(obsidianUri)
 }
 
-// WriteNoteFile writes content to notePath, respecting append/overwrite semantics.
-// If the file already exists and neither flag is set, it is left unchanged.
-func WriteNoteFile(notePath, content string, shouldAppend, shouldOverwrite bool) error {
-	_, err := os.Stat(notePath)
-	fileExists := err == nil
+// newNoteContent returns the starting content of a new note: its template
+// with variables expanded, and its properties merged into the frontmatter.
+func newNoteContent(vaultPath string, params CreateParams) (string, error) {
+	content := ""
+	if params.Template != "" {
+		config := obsidian.ReadTemplatesConfig(vaultPath)
+		templatePath, err := findTemplate(vaultPath, config.Folder, params.Template)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", err
+		}
+		now := time.Now()
+		title := strings.TrimSuffix(path.Base(params.NoteName), ".md")
+		content = config.Render(string(data), title, now, now)
+	}
 
-	if fileExists && shouldAppend {
-		f, err := os.OpenFile(notePath, os.O_APPEND|os.O_WRONLY, 0644)
+	types := frontmatter.PropertyTypes(obsidian.ReadPropertyTypes(vaultPath))
+	for _, property := range params.Properties {
+		key, value, err := splitKeyValue("--property", property)
 		if err != nil {
-			return fmt.Errorf("failed to open note for appending: %w", err)
+			return "", err
+		}
+		content, err = types.SetKey(content, key, value)
+		if err != nil {
+			return "", err
+		}
+	}
+	return content, nil
+}
+
+// warnSchemaProblems reports schema violations in a newly written note. They
+// are warnings only: the note has already been written.
+func warnSchemaProblems(w io.Writer, vaultPath, relPath, notePath string) {
+	config, err := frontmatter.LoadSchemaConfig(vaultPath)
+	if err != nil {
+		fmt.Fprintf(w, "warning: %v\n", err)
+		return
+	}
+	schema := config.ForNote(relPath)
+	if schema == nil {
+		return
+	}
+	contents, err := os.ReadFile(notePath)
+	if err != nil {
+		fmt.Fprintf(w, "warning: %v\n", err)
+		return
+	}
+	for _, problem := range ValidateContents(schema, string(contents)) {
+		fmt.Fprintf(w, "warning: %s: %s\n", relPath, problem.Message)
+	}
+}
+
+// WriteOptions controls where WriteNoteFile puts content.
+type WriteOptions struct {
+	Append    bool   // add content to the end of an existing note
+	Overwrite bool   // replace an existing note
+	Under     string // insert content at the end of this heading's section
+	Prepend   bool   // insert content directly after the frontmatter
+	Timestamp string // prefix the content's first entry with this text
+}
+
+// inserts reports whether the options add content to an existing note.
+func (o WriteOptions) inserts() bool {
+	return o.Append || o.Under != "" || o.Prepend
+}
+
+// WriteNoteFile writes content to notePath, respecting append/overwrite semantics.
+// Under and Prepend insert content into an existing note instead of appending it.
+// If the file already exists and no flag is set, it is left unchanged. The note
+// is replaced atomically, and re-read if it changes before it is written.
+func WriteNoteFile(notePath, content string, opts WriteOptions) error {
+	for attempt := 1; ; attempt++ {
+		existing, version, err := obsidian.ReadFileVersion(notePath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		fileExists := err == nil
+
+		if fileExists && !opts.Overwrite && !opts.inserts() {
+			// File exists but no modification requested — leave it as-is.
+			return nil
+		}
+
+		base := ""
+		if fileExists && !opts.Overwrite {
+			base = string(existing)
 		}
-		if _, err = f.WriteString(content); err != nil {
-			f.Close()
+		err = obsidian.WriteFileIfUnchanged(notePath, []byte(placeContent(base, content, opts)), 0644, version)
+		if err == nil || err.Error() != obsidian.NoteChangedError || attempt == obsidian.WriteAttempts {
 			return err
 		}
-		return f.Close()
 	}
+}
 
-	if fileExists && !shouldOverwrite {
-		// File exists but no modification requested — leave it as-is.
-		return nil
+// retryOnChange runs a read-modify-write of a note again when the note was
+// changed by another program between the read and the write.
+func retryOnChange(op func() (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := op()
+		if err == nil || err.Error() != obsidian.NoteChangedError || attempt == obsidian.WriteAttempts {
+			return result, err
+		}
+	}
+}
+
+// placeContent adds content to existing as WriteNoteFile does: under a heading
+// (created if missing), after the frontmatter, or at the end.
+func placeContent(existing, content string, opts WriteOptions) string {
+	if opts.Timestamp != "" {
+		content = timestampEntry(content, opts.Timestamp)
 	}
 
-	return os.WriteFile(notePath, []byte(content), 0644)
+	switch {
+	case opts.Under != "":
+		return markdown.InsertUnderHeading(existing, opts.Under, strings.Trim(content, "\n"))
+	case opts.Prepend:
+		return markdown.InsertAfterFrontmatter(existing, strings.Trim(content, "\n"))
+	}
+	return existing + content
+}
+
+// listMarker matches the marker of a list item or task: "- ", "1. ", "- [ ] ".
+var listMarker = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[.\]\s+)?`)
+
+// timestampEntry prefixes the first non-blank line of content with stamp,
+// after any list or task marker.
+func timestampEntry(content, stamp string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		marker := listMarker.FindString(line)
+		lines[i] = marker + stamp + " " + line[len(marker):]
+		break
+	}
+	return strings.Join(lines, "\n")
 }
 
 func NormalizeContent(content string) string {
@@ -103,3 +287,12 @@ func NormalizeContent(content string) string {
 	)
 	return replacer.Replace(content)
 }
+
+// entryTimestamp formats now for --timestamp, using format or else the time
+// format of the core Templates plugin.
+func entryTimestamp(vaultPath, format string, now time.Time) string {
+	if format == "" {
+		format = obsidian.ReadTemplatesConfig(vaultPath).TimeFormat
+	}
+	return obsidian.FormatMoment(now, format)
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Yakitrak/notesmd-cli/mocks"
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
@@ -289,6 +291,215 @@ func TestCreateNote(t *testing.T) {
 	})
 }
 
+func TestCreateNote_Validate(t *testing.T) {
+	t.Run("Warns about schema problems after writing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".notesmd/schema.yaml", meetingSchema)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+		var warnings strings.Builder
+
+		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+			NoteName: "Meetings/sync",
+			Content:  "---\nstatus: planned\n---\n",
+			Validate: true,
+			Warnings: &warnings,
+		})
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(tmpDir, "Meetings", "sync.md"))
+		assert.Equal(t, "warning: Meetings/sync.md: missing required property 'date'\n", warnings.String())
+	})
+
+	t.Run("Notes outside schema folders are not checked", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".notesmd/schema.yaml", meetingSchema)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+		var warnings strings.Builder
+
+		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+			NoteName: "inbox",
+			Validate: true,
+			Warnings: &warnings,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, warnings.String())
+	})
+}
+
+func TestCreateNote_Placement(t *testing.T) {
+	existing := "---\ntitle: Journal\n---\n## Log\n- 08:00 woke up\n\n## Notes\n"
+
+	tests := []struct {
+		testName string
+		params   actions.CreateParams
+		want     string
+	}{
+		{
+			testName: "Under an existing heading",
+			params:   actions.CreateParams{Content: "- coffee", Under: "## Log"},
+			want:     "---\ntitle: Journal\n---\n## Log\n- 08:00 woke up\n- coffee\n\n## Notes\n",
+		},
+		{
+			testName: "Under a missing heading",
+			params:   actions.CreateParams{Content: "\\n- call bank", Under: "## Tasks"},
+			want:     existing + "\n## Tasks\n- call bank\n",
+		},
+		{
+			testName: "After the frontmatter",
+			params:   actions.CreateParams{Content: "> pinned", Prepend: true},
+			want:     "---\ntitle: Journal\n---\n> pinned\n## Log\n- 08:00 woke up\n\n## Notes\n",
+		},
+		{
+			testName: "With a timestamp in a custom format",
+			params:   actions.CreateParams{Content: "- [ ] review", Under: "Log", Timestamp: true, TimestampFormat: "[at] YYYY"},
+			want:     "---\ntitle: Journal\n---\n## Log\n- 08:00 woke up\n- [ ] at " + time.Now().Format("2006") + " review\n\n## Notes\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeVaultFile(t, tmpDir, "journal.md", existing)
+			vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+			test.params.NoteName = "journal"
+			err := actions.CreateNote(&vault, &mocks.MockUriManager{}, test.params)
+
+			assert.NoError(t, err)
+			content, _ := os.ReadFile(filepath.Join(tmpDir, "journal.md"))
+			assert.Equal(t, test.want, string(content))
+		})
+	}
+
+	t.Run("New note gets the heading", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "new", Content: "- first", Under: "## Log"})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "new.md"))
+		assert.Equal(t, "## Log\n- first\n", string(content))
+	})
+
+	t.Run("Timestamp defaults to the Templates time format", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"timeFormat": "[T]YYYY"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "new", Content: "entry", Timestamp: true})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "new.md"))
+		assert.Equal(t, "T"+time.Now().Format("2006")+" entry", string(content))
+	})
+
+	t.Run("Raw content skips escape processing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, "log.md", "existing\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{
+			NoteName:     "log",
+			Content:      "grep 'a\\nb' \\t\n",
+			RawContent:   true,
+			ShouldAppend: true,
+		})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "log.md"))
+		assert.Equal(t, "existing\ngrep 'a\\nb' \\t\n", string(content))
+	})
+
+	t.Run("Under and prepend cannot be combined", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "new", Under: "Log", Prepend: true})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateNoteFromTemplate(t *testing.T) {
+	setup := func(t *testing.T) string {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"folder": "Templates"}`)
+		writeVaultFile(t, tmpDir, ".obsidian/types.json", `{"types": {"attendees": "multitext", "budget": "number"}}`)
+		writeVaultFile(t, tmpDir, "Templates/Meeting.md", "---\ntype: meeting\ndate: \"{{date}}\"\n---\n# {{title}}\n\n## Notes\n")
+		return tmpDir
+	}
+
+	t.Run("Expands the template's variables", func(t *testing.T) {
+		tmpDir := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "Meetings/Meeting with ACME", Template: "Meeting"})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "Meetings", "Meeting with ACME.md"))
+		assert.Equal(t, "---\ntype: meeting\ndate: \""+time.Now().Format("2006-01-02")+"\"\n---\n# Meeting with ACME\n\n## Notes\n", string(content))
+	})
+
+	t.Run("Merges typed properties into the frontmatter", func(t *testing.T) {
+		tmpDir := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{
+			NoteName:   "acme",
+			Template:   "Meeting",
+			Properties: []string{"type=call", "attendees=Ann", "budget=1200"},
+			Content:    "- agreed on scope",
+			Under:      "## Notes",
+		})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "acme.md"))
+		assert.Equal(t, "---\ntype: call\ndate: \""+time.Now().Format("2006-01-02")+"\"\nattendees:\n  - Ann\nbudget: 1200\n---\n"+
+			"# acme\n\n## Notes\n- agreed on scope\n", string(content))
+	})
+
+	t.Run("Properties without a template create frontmatter", func(t *testing.T) {
+		tmpDir := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "plain", Properties: []string{"status=draft"}, Content: "body\n"})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "plain.md"))
+		assert.Equal(t, "---\nstatus: draft\n---\nbody\n", string(content))
+	})
+
+	t.Run("Leaves existing notes alone", func(t *testing.T) {
+		tmpDir := setup(t)
+		writeVaultFile(t, tmpDir, "acme.md", "existing\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "acme", Template: "Meeting", Properties: []string{"a=b"}})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "acme.md"))
+		assert.Equal(t, "existing\n", string(content))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tmpDir := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "a", Template: "Missing"})
+		assert.EqualError(t, err, "template 'Missing' not found in Templates")
+
+		err = actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "b", Properties: []string{"novalue"}})
+		assert.Error(t, err)
+
+		err = actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "c", Properties: []string{"budget=lots"}})
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(tmpDir, "c.md"))
+	})
+}
+
 func TestNormalizeContent(t *testing.T) {
 	t.Run("Replaces escape sequences with actual characters", func(t *testing.T) {
 		// Arrange
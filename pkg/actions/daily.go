@@ -1,20 +1,61 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 )
 
+// Daily note directions for DailyParams.Jump.
+const (
+	DailyPrev = "prev"
+	DailyNext = "next"
+)
+
+// DailyParams configures DailyNote and PeriodicNote.
 type DailyParams struct {
-	Content   string
-	UseEditor bool
+	Content    string
+	RawContent bool // write Content as is, without NormalizeContent
+	UseEditor  bool
+	// Date selects the day: an ISO date or an expression understood by
+	// obsidian.ParseDate ("yesterday", "-2d", "next monday"). Defaults to today.
+	Date string
+	// Jump moves to the nearest existing note before (DailyPrev) or after
+	// (DailyNext) the selected day's period instead of creating one.
+	Jump      string
+	Location  *time.Location // time zone for "today"; defaults to local time
+	NoOpen    bool
+	Under     string // add Content under this heading instead of at the end
+	Prepend   bool   // add Content directly after the frontmatter
+	Timestamp bool   // prefix Content with the current time
+	// TimestampFormat is a Moment.js format for Timestamp; defaults to the
+	// core Templates plugin's time format.
+	TimestampFormat string
+	// Rollover copies open tasks from the previous note into a newly created
+	// note; nil to skip.
+	Rollover  *RolloverParams
+	Print     bool      // write the note's contents to Output instead of opening it
+	PrintPath bool      // write the note's path to Output instead of opening it
+	Output    io.Writer // receives Print and PrintPath output
 }
 
 func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager, params DailyParams) error {
+	return PeriodicNote(vault, uri, obsidian.PeriodDay, params)
+}
+
+// PeriodicNote creates or opens the daily, weekly, monthly, quarterly or
+// yearly note (see obsidian.PeriodSettings) for the period containing the
+// selected day.
+func PeriodicNote(vault obsidian.VaultManager, uri obsidian.UriManager, period string, params DailyParams) error {
 	vaultName, err := vault.DefaultName()
 	if err != nil {
 		return err
@@ -25,18 +66,41 @@ func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Dail
 		return err
 	}
 
-	config := obsidian.ReadDailyNotesConfig(vaultPath)
+	if params.Under != "" && params.Prepend {
+		return errors.New("--under and --prepend cannot be used together")
+	}
 
-	// Format today's date using the configured Moment.js format.
-	format := config.Format
-	if format == "" {
-		format = "YYYY-MM-DD"
+	settings := obsidian.PeriodSettings(vaultPath, period)
+
+	loc := params.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	date := now
+	if params.Date != "" {
+		date, err = obsidian.ParseDate(params.Date, date)
+		if err != nil {
+			return err
+		}
 	}
-	noteName := time.Now().Format(obsidian.MomentToGoFormat(format))
 
-	// Prepend configured daily notes folder.
-	if config.Folder != "" {
-		noteName = config.Folder + "/" + noteName
+	if params.Jump != "" {
+		date, err = nearestPeriodicNote(vaultPath, period, settings, date, params.Jump)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Name the note after the start of its period, using the configured
+	// Moment.js format.
+	start := obsidian.PeriodStart(date, period, settings.Format)
+	noteName := obsidian.FormatMoment(start, settings.Format)
+	title := path.Base(noteName)
+
+	// Prepend configured periodic notes folder.
+	if settings.Folder != "" {
+		noteName = settings.Folder + "/" + noteName
 	}
 
 	notePath, err := obsidian.ValidatePath(vaultPath, obsidian.AddMdSuffix(noteName))
@@ -45,36 +109,85 @@ func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Dail
 	}
 
 	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
-		return fmt.Errorf("failed to create daily note directory: %w", err)
+		return fmt.Errorf("failed to create %s note directory: %w", periodAdjective(period), err)
 	}
 
-	// Read template content if configured.
+	// Read template content if configured, expanding template variables as
+	// Obsidian does: {{date}} uses the periodic note's own format rather than
+	// the Templates plugin's.
 	templateContent := ""
-	if config.Template != "" {
-		templatePath := filepath.Join(vaultPath, obsidian.AddMdSuffix(config.Template))
+	if settings.Template != "" {
+		templatePath := filepath.Join(vaultPath, obsidian.AddMdSuffix(settings.Template))
 		if data, readErr := os.ReadFile(templatePath); readErr == nil {
-			templateContent = string(data)
+			config := obsidian.ReadTemplatesConfig(vaultPath)
+			config.DateFormat = settings.Format
+			templateContent = config.Render(string(data), title, start, now)
 		}
 	}
 
-	normalizedContent := NormalizeContent(params.Content)
+	normalizedContent := params.Content
+	if !params.RawContent {
+		normalizedContent = NormalizeContent(params.Content)
+	}
+
+	opts := WriteOptions{Append: true, Under: params.Under, Prepend: params.Prepend}
+	if params.Timestamp {
+		opts.Timestamp = entryTimestamp(vaultPath, params.TimestampFormat, now)
+	}
 
 	_, statErr := os.Stat(notePath)
 	fileExists := statErr == nil
 
 	if fileExists && normalizedContent != "" {
-		// Append user content to existing daily note.
-		if err := WriteNoteFile(notePath, normalizedContent, true, false); err != nil {
+		// Add user content to existing daily note.
+		if err := WriteNoteFile(notePath, normalizedContent, opts); err != nil {
 			return err
 		}
 	} else if !fileExists {
-		// Create new daily note with template + content.
-		newContent := templateContent + normalizedContent
-		if err := WriteNoteFile(notePath, newContent, false, false); err != nil {
+		// Names come from the configured format, so they cannot be sanitized
+		// without breaking the lookup of existing notes.
+		policy, err := obsidian.LoadNamePolicy(vaultPath)
+		if err != nil {
+			return err
+		}
+		if problems := policy.Problems(path.Base(noteName)); len(problems) > 0 {
+			return fmt.Errorf("%s note name %q %s: change the note format", periodAdjective(period), path.Base(noteName), strings.Join(problems, "; "))
+		}
+
+		// Create new daily note with template, rolled over tasks and content.
+		newContent := templateContent
+		finishRollover := func() error { return nil }
+		if params.Rollover != nil {
+			newContent, finishRollover, err = rolloverTasks(vaultPath, period, settings, start, newContent, *params.Rollover)
+			if err != nil {
+				return err
+			}
+		}
+		if normalizedContent != "" {
+			newContent = placeContent(newContent, normalizedContent, opts)
+		}
+		if err := WriteNoteFile(notePath, newContent, WriteOptions{}); err != nil {
+			return err
+		}
+		if err := finishRollover(); err != nil {
 			return err
 		}
 	}
 
+	if params.PrintPath {
+		fmt.Fprintln(params.Output, notePath)
+	}
+	if params.Print {
+		data, err := os.ReadFile(notePath)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(params.Output, string(data))
+	}
+	if params.NoOpen || params.Print || params.PrintPath {
+		return nil
+	}
+
 	// Open the note.
 	if params.UseEditor {
 		return obsidian.OpenInEditor(notePath)
@@ -86,3 +199,125 @@ func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Dail
 	})
 	return uri.Execute(obsidianUri)
 }
+
+// nearestPeriodicNote returns the date of the closest existing note of period
+// strictly before (DailyPrev) or after (DailyNext) the period containing from.
+// Notes are found by parsing note names in the period's folder with its format.
+func nearestPeriodicNote(vaultPath, period string, settings obsidian.PeriodConfig, from time.Time, jump string) (time.Time, error) {
+	if jump != DailyPrev && jump != DailyNext {
+		return time.Time{}, fmt.Errorf("invalid direction '%s': expected prev or next", jump)
+	}
+
+	dates, err := periodicNoteDates(vaultPath, settings.Folder, settings.Format, from.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	start := obsidian.PeriodStart(from, period, settings.Format)
+	if jump == DailyPrev {
+		for i := len(dates) - 1; i >= 0; i-- {
+			if dates[i].Before(start) {
+				return dates[i], nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("no %s note before %s", periodAdjective(period), from.Format("2006-01-02"))
+	}
+	end := obsidian.PeriodStart(start.AddDate(0, 0, periodDays(period)), period, settings.Format)
+	for _, date := range dates {
+		if !date.Before(end) {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no %s note after %s", periodAdjective(period), from.Format("2006-01-02"))
+}
+
+// periodicNote is an existing periodic note found by its name.
+type periodicNote struct {
+	Date time.Time
+	Name string // vault-relative, without the .md extension
+}
+
+// periodicNoteDates returns the dates of the notes in folder whose names
+// match format, oldest first.
+func periodicNoteDates(vaultPath, folder, format string, loc *time.Location) ([]time.Time, error) {
+	notes, err := periodicNotes(vaultPath, folder, format, loc)
+	if err != nil {
+		return nil, err
+	}
+	dates := make([]time.Time, len(notes))
+	for i, note := range notes {
+		dates[i] = note.Date
+	}
+	return dates, nil
+}
+
+// periodicNotes returns the notes in folder whose names match format, oldest
+// first.
+func periodicNotes(vaultPath, folder, format string, loc *time.Location) ([]periodicNote, error) {
+	root := filepath.Join(vaultPath, filepath.FromSlash(folder))
+	var notes []periodicNote
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), ".md")
+		if date, err := obsidian.ParseMoment(format, name, loc); err == nil {
+			if folder != "" {
+				name = folder + "/" + name
+			}
+			notes = append(notes, periodicNote{Date: date, Name: name})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Date.Before(notes[j].Date) })
+	return notes, nil
+}
+
+// periodDays returns a number of days that moves from the start of a period
+// into the next one.
+func periodDays(period string) int {
+	switch period {
+	case obsidian.PeriodWeek:
+		return 7
+	case obsidian.PeriodMonth:
+		return 31
+	case obsidian.PeriodQuarter:
+		return 92
+	case obsidian.PeriodYear:
+		return 366
+	}
+	return 1
+}
+
+func periodAdjective(period string) string {
+	switch period {
+	case obsidian.PeriodWeek:
+		return "weekly"
+	case obsidian.PeriodMonth:
+		return "monthly"
+	case obsidian.PeriodQuarter:
+		return "quarterly"
+	case obsidian.PeriodYear:
+		return "yearly"
+	}
+	return "daily"
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -245,7 +246,347 @@ func TestDailyNote(t *testing.T) {
 		err := actions.DailyNote(&vault, &uri, actions.DailyParams{})
 		assert.NoError(t, err)
 
-		expectedName := time.Now().Format(obsidian.MomentToGoFormat("DD-MM-YYYY"))
+		expectedName := obsidian.FormatMoment(time.Now(), "DD-MM-YYYY")
 		assert.FileExists(t, filepath.Join(tmpDir, expectedName+".md"))
 	})
+
+	t.Run("Creates daily note for an explicit date", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: "2026-10-01"})
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(tmpDir, "2026-10-01.md"))
+		assert.Equal(t, "2026-10-01", uri.LastParams["file"])
+	})
+
+	t.Run("Creates daily note for a relative date", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: "-2d"})
+		assert.NoError(t, err)
+
+		expected := time.Now().AddDate(0, 0, -2).Format("2006-01-02")
+		assert.FileExists(t, filepath.Join(tmpDir, expected+".md"))
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{Date: "someday"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Uses the given time zone for today", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		loc := time.FixedZone("UTC+14", 14*60*60)
+
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{Location: loc})
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(tmpDir, time.Now().In(loc).Format("2006-01-02")+".md"))
+	})
+
+	t.Run("Jumps to the nearest existing daily note", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "daily-notes.json"), []byte(`{
+			"folder": "Daily",
+			"format": "YYYY/MM/DD-MM-YYYY"
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"2026/09/28-09-2026.md", "2026/10/02-10-2026.md", "2026/10/09-10-2026.md", "2026/10/notes.md"} {
+			notePath := filepath.Join(tmpDir, "Daily", filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(notePath, []byte(name), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: "2026-10-05", Jump: actions.DailyPrev})
+		assert.NoError(t, err)
+		assert.Equal(t, "Daily/2026/10/02-10-2026", uri.LastParams["file"])
+
+		err = actions.DailyNote(&vault, &uri, actions.DailyParams{Date: "2026-10-02", Jump: actions.DailyNext})
+		assert.NoError(t, err)
+		assert.Equal(t, "Daily/2026/10/09-10-2026", uri.LastParams["file"])
+
+		err = actions.DailyNote(&vault, &uri, actions.DailyParams{Date: "2026-09-28", Jump: actions.DailyPrev})
+		assert.EqualError(t, err, "no daily note before 2026-09-28")
+	})
+
+	t.Run("Prints contents and path without opening", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		notePath := filepath.Join(tmpDir, "2026-10-01.md")
+		if err := os.WriteFile(notePath, []byte("existing content\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{ExecuteErr: errors.New("no obsidian:// handler")}
+		var out strings.Builder
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{
+			Date:      "2026-10-01",
+			Print:     true,
+			PrintPath: true,
+			Output:    &out,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, notePath+"\nexisting content\n", out.String())
+	})
+
+	t.Run("No-open creates the note without opening it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{ExecuteErr: errors.New("no obsidian:// handler")}
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Content: "hello", NoOpen: true})
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(tmpDir, today+".md"))
+		assert.Nil(t, uri.LastParams)
+	})
+
+	t.Run("Raw content skips escape processing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		notePath := filepath.Join(tmpDir, "2026-10-01.md")
+		if err := os.WriteFile(notePath, []byte("## Log\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{
+			Date:       "2026-10-01",
+			Content:    "C:\\new\\temp\nsecond line\n",
+			RawContent: true,
+			Under:      "## Log",
+			NoOpen:     true,
+		})
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "## Log\nC:\\new\\temp\nsecond line\n", string(content))
+	})
+}
+
+func TestPeriodicNote(t *testing.T) {
+	t.Run("Creates notes with default period formats", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		expected := map[string]string{
+			obsidian.PeriodWeek:    "2026-W43",
+			obsidian.PeriodMonth:   "2026-10",
+			obsidian.PeriodQuarter: "2026-Q4",
+			obsidian.PeriodYear:    "2026",
+		}
+		for period, name := range expected {
+			err := actions.PeriodicNote(&vault, &uri, period, actions.DailyParams{Date: "2026-10-22"})
+			assert.NoError(t, err)
+			assert.FileExists(t, filepath.Join(tmpDir, name+".md"))
+			assert.Equal(t, name, uri.LastParams["file"])
+		}
+	})
+
+	t.Run("Uses the Periodic Notes plugin config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		pluginDir := filepath.Join(tmpDir, ".obsidian", "plugins", "periodic-notes")
+		if err := os.MkdirAll(pluginDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pluginDir, "data.json"), []byte(`{
+			"weekly": {"enabled": true, "folder": "Weekly", "format": "[Week of] YYYY-MM-DD", "template": "Templates/Week"}
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(tmpDir, "Templates"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, "Templates", "Week.md"), []byte("# Week\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+
+		err := actions.PeriodicNote(&vault, &uri, obsidian.PeriodWeek, actions.DailyParams{Date: "2026-10-22", Content: "- goal"})
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(tmpDir, "Weekly", "Week of 2026-10-18.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "# Week\n- goal", string(data))
+	})
+
+	t.Run("Adds content under a template heading", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/daily-notes.json", `{"template": "Templates/Daily"}`)
+		writeVaultFile(t, tmpDir, "Templates/Daily.md", "## Log\n\n## Tasks\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		params := actions.DailyParams{Date: "2026-10-19", Under: "## Log", Timestamp: true, TimestampFormat: "[09:30]", NoOpen: true}
+
+		params.Content = "- standup"
+		assert.NoError(t, actions.DailyNote(&vault, &mocks.MockUriManager{}, params))
+		params.Content = "- lunch"
+		assert.NoError(t, actions.DailyNote(&vault, &mocks.MockUriManager{}, params))
+
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "2026-10-19.md"))
+		assert.Equal(t, "## Log\n- 09:30 standup\n- 09:30 lunch\n\n## Tasks\n", string(data))
+	})
+
+	t.Run("Prepends content after frontmatter", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, "2026-10-19.md", "---\nmood: ok\n---\nbody\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{Date: "2026-10-19", Content: "top", Prepend: true, NoOpen: true})
+		assert.NoError(t, err)
+
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "2026-10-19.md"))
+		assert.Equal(t, "---\nmood: ok\n---\ntop\nbody\n", string(data))
+	})
+
+	t.Run("Expands template dates in the daily note format", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/daily-notes.json", `{"template": "Templates/Daily", "format": "YYYY-MM-DD ddd"}`)
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"dateFormat": "dddd D MMMM"}`)
+		writeVaultFile(t, tmpDir, "Templates/Daily.md", "# {{title}}\n{{date}} [[{{yesterday}}]] {{date:YYYY}}\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{Date: "2026-10-19"})
+		assert.NoError(t, err)
+
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "2026-10-19 Mon.md"))
+		assert.Equal(t, "# 2026-10-19 Mon\n2026-10-19 Mon [[2026-10-18 Sun]] 2026\n", string(data))
+	})
+
+	t.Run("Appends content and prints the path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		notePath := filepath.Join(tmpDir, "2026-10.md")
+		if err := os.WriteFile(notePath, []byte("existing content"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+		uri := mocks.MockUriManager{}
+		var out strings.Builder
+
+		err := actions.PeriodicNote(&vault, &uri, obsidian.PeriodMonth, actions.DailyParams{
+			Date:      "2026-10-31",
+			Content:   "\\nnew line",
+			PrintPath: true,
+			Output:    &out,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, notePath+"\n", out.String())
+
+		data, _ := os.ReadFile(notePath)
+		assert.Equal(t, "existing content\nnew line", string(data))
+	})
+}
+
+func TestDailyNote_Rollover(t *testing.T) {
+	previous := "## Tasks\n- [ ] Write report\n  - [ ] Draft\n- [x] Shipped\n- [ ] Call bank\n"
+
+	setup := func(t *testing.T, plugin string) (string, *mocks.MockVaultOperator) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/daily-notes.json", `{"folder": "Daily", "template": "Templates/Daily"}`)
+		writeVaultFile(t, tmpDir, "Templates/Daily.md", "## Tasks\n\n## Log\n")
+		writeVaultFile(t, tmpDir, "Daily/2026-10-15.md", "- [ ] Too old\n")
+		writeVaultFile(t, tmpDir, "Daily/2026-10-16.md", previous)
+		if plugin != "" {
+			writeVaultFile(t, tmpDir, ".obsidian/plugins/rollover-daily-todos/data.json", plugin)
+		}
+		return tmpDir, &mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+	}
+	read := func(t *testing.T, path string) string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("Copies open tasks from the previous note", func(t *testing.T) {
+		tmpDir, vault := setup(t, "")
+
+		err := actions.DailyNote(vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:     "2026-10-19",
+			Rollover: &actions.RolloverParams{Under: "## Tasks"},
+			NoOpen:   true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "## Tasks\n- [ ] Write report\n  - [ ] Draft\n- [ ] Call bank\n\n## Log\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-19.md")))
+		assert.Equal(t, previous, read(t, filepath.Join(tmpDir, "Daily", "2026-10-16.md")))
+	})
+
+	t.Run("Marks originals as moved", func(t *testing.T) {
+		tmpDir, vault := setup(t, "")
+
+		err := actions.DailyNote(vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:     "2026-10-19",
+			Content:  "- note",
+			Under:    "## Log",
+			Rollover: &actions.RolloverParams{Originals: actions.RolloverMark},
+			NoOpen:   true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "## Tasks\n\n## Log\n- [ ] Write report\n  - [ ] Draft\n- [ ] Call bank\n- note\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-19.md")))
+		assert.Equal(t, "## Tasks\n- [>] Write report\n  - [>] Draft\n- [x] Shipped\n- [>] Call bank\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-16.md")))
+	})
+
+	t.Run("Uses the Rollover Daily Todos settings", func(t *testing.T) {
+		tmpDir, vault := setup(t, `{"templateHeading": "## Tasks", "deleteOnComplete": true, "rolloverChildren": true}`)
+
+		err := actions.DailyNote(vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:     "2026-10-19",
+			Rollover: &actions.RolloverParams{},
+			NoOpen:   true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "## Tasks\n- [ ] Write report\n  - [ ] Draft\n- [ ] Call bank\n\n## Log\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-19.md")))
+		assert.Equal(t, "## Tasks\n- [x] Shipped\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-16.md")))
+	})
+
+	t.Run("Existing notes are not rolled over into", func(t *testing.T) {
+		tmpDir, vault := setup(t, "")
+		writeVaultFile(t, tmpDir, "Daily/2026-10-19.md", "today\n")
+
+		err := actions.DailyNote(vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:     "2026-10-19",
+			Rollover: &actions.RolloverParams{Originals: actions.RolloverDelete},
+			NoOpen:   true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "today\n", read(t, filepath.Join(tmpDir, "Daily", "2026-10-19.md")))
+		assert.Equal(t, previous, read(t, filepath.Join(tmpDir, "Daily", "2026-10-16.md")))
+	})
+
+	t.Run("Invalid mode", func(t *testing.T) {
+		_, vault := setup(t, "")
+
+		err := actions.DailyNote(vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:     "2026-10-19",
+			Rollover: &actions.RolloverParams{Originals: "archive"},
+			NoOpen:   true,
+		})
+
+		assert.Error(t, err)
+	})
 }
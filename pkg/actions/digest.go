@@ -0,0 +1,261 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+)
+
+const (
+	digestFormatMarkdown = "markdown"
+	digestFormatJSON     = "json"
+)
+
+// DigestParams configures DailyDigest.
+type DigestParams struct {
+	// From and To bound the range of days, inclusive. Both accept the same
+	// expressions as DailyParams.Date; To defaults to today.
+	From string
+	To   string
+	// Week selects the week containing Date (today when empty) instead of
+	// From and To. Weeks start as configured for weekly notes.
+	Week      bool
+	Date      string
+	Location  *time.Location // time zone for "today"; defaults to local time
+	Section   string         // only include this heading's section (e.g. "## Log")
+	Completed bool           // only include completed tasks
+	Format    string         // markdown or json
+	// HeadingFormat is the Moment.js format of the per-day headings in the
+	// Markdown digest; defaults to "YYYY-MM-DD".
+	HeadingFormat string
+	// Write saves the Markdown digest as this note instead of writing it to
+	// Output.
+	Write  string
+	Output io.Writer
+}
+
+// DigestEntry is one daily note's contribution to a digest.
+type DigestEntry struct {
+	Date    string `json:"date"`
+	Note    string `json:"note"`
+	Content string `json:"content"`
+}
+
+// DailyDigest collects the daily notes in a range of days, found by parsing
+// note names with the configured daily note format, and concatenates them
+// under a date heading and a link per note. Notes with nothing to include are
+// left out.
+func DailyDigest(vault obsidian.VaultManager, params DigestParams) error {
+	format := params.Format
+	if format == "" {
+		format = digestFormatMarkdown
+	}
+	if format != digestFormatMarkdown && format != digestFormatJSON {
+		return fmt.Errorf("invalid format '%s': expected markdown or json", format)
+	}
+	if params.Write != "" && format == digestFormatJSON {
+		return errors.New("--write saves a Markdown digest and cannot be used with --format json")
+	}
+	if params.Week && (params.From != "" || params.To != "") {
+		return errors.New("--week cannot be used with --from or --to")
+	}
+	if !params.Week && params.From == "" {
+		return errors.New("either --from or --week is required")
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	loc := params.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	from, to, err := digestRange(vaultPath, params, time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+
+	settings := obsidian.PeriodSettings(vaultPath, obsidian.PeriodDay)
+	notes, err := periodicNotes(vaultPath, settings.Folder, settings.Format, loc)
+	if err != nil {
+		return err
+	}
+
+	entries := []DigestEntry{}
+	for _, note := range notes {
+		if note.Date.Before(from) || note.Date.After(to) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(vaultPath, filepath.FromSlash(obsidian.AddMdSuffix(note.Name))))
+		if err != nil {
+			return err
+		}
+		content := digestContent(string(data), params.Section, params.Completed)
+		if content == "" {
+			continue
+		}
+		entries = append(entries, DigestEntry{
+			Date:    note.Date.Format("2006-01-02"),
+			Note:    note.Name,
+			Content: content,
+		})
+	}
+
+	if format == digestFormatJSON {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(params.Output, string(data))
+		return nil
+	}
+
+	headingFormat := params.HeadingFormat
+	if headingFormat == "" {
+		headingFormat = "YYYY-MM-DD"
+	}
+	digest := formatDigest(entries, headingFormat, loc)
+	if params.Write == "" {
+		fmt.Fprint(params.Output, digest)
+		return nil
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("no daily notes between %s and %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	notePath, err := obsidian.ValidatePath(vaultPath, obsidian.AddMdSuffix(params.Write))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
+		return fmt.Errorf("failed to create digest directory: %w", err)
+	}
+	if err := WriteNoteFile(notePath, digest, WriteOptions{Overwrite: true}); err != nil {
+		return err
+	}
+	noun := "notes"
+	if len(entries) == 1 {
+		noun = "note"
+	}
+	fmt.Fprintf(params.Output, "Wrote digest of %d daily %s to %s\n", len(entries), noun, params.Write)
+	return nil
+}
+
+// digestRange returns the first and last day of the digest, at midnight.
+func digestRange(vaultPath string, params DigestParams, now time.Time) (time.Time, time.Time, error) {
+	if params.Week {
+		date := now
+		if params.Date != "" {
+			var err error
+			date, err = obsidian.ParseDate(params.Date, now)
+			if err != nil {
+				return time.Time{}, time.Time{}, err
+			}
+		}
+		weekly := obsidian.PeriodSettings(vaultPath, obsidian.PeriodWeek)
+		start := obsidian.PeriodStart(date, obsidian.PeriodWeek, weekly.Format)
+		return start, start.AddDate(0, 0, 6), nil
+	}
+
+	from, err := obsidian.ParseDate(params.From, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to := now
+	if params.To != "" {
+		to, err = obsidian.ParseDate(params.To, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	from = obsidian.PeriodStart(from, obsidian.PeriodDay, "")
+	to = obsidian.PeriodStart(to, obsidian.PeriodDay, "")
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+	return from, to, nil
+}
+
+// digestContent returns the part of a daily note that goes into a digest:
+// the body without frontmatter, or just one section, optionally narrowed to
+// completed tasks. Surrounding blank lines are trimmed.
+func digestContent(contents, section string, completed bool) string {
+	lines := strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n")
+	lines = lines[markdown.BodyStart(lines):]
+
+	if section != "" {
+		start, end, ok := markdown.FindSection(lines, section)
+		if !ok {
+			return ""
+		}
+		lines = lines[start+1 : end]
+	}
+
+	if completed {
+		var done []string
+		for _, line := range lines {
+			if task, ok := tasks.Parse(line); ok && task.IsDone() {
+				done = append(done, line)
+			}
+		}
+		lines = done
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatDigest renders entries as Markdown, each under a heading with its
+// date in headingFormat followed by a link to its note.
+func formatDigest(entries []DigestEntry, headingFormat string, loc *time.Location) string {
+	var sb strings.Builder
+	for i, entry := range entries {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		heading := entry.Date
+		if date, err := time.ParseInLocation("2006-01-02", entry.Date, loc); err == nil {
+			heading = obsidian.FormatMoment(date, headingFormat)
+		}
+		link := entry.Note
+		if name := path.Base(entry.Note); name != entry.Note {
+			link += "|" + name
+		}
+		fmt.Fprintf(&sb, "## %s\n\n[[%s]]\n\n%s\n", heading, link, demoteHeadings(entry.Content))
+	}
+	return sb.String()
+}
+
+// demoteHeadings moves the headings of content one level down, so that they
+// nest under the digest's date headings. Level 6 headings stay as they are.
+func demoteHeadings(content string) string {
+	lines := strings.Split(content, "\n")
+	for _, h := range markdown.Headings(lines) {
+		if h.Level < 6 {
+			lines[h.Line] = "#" + lines[h.Line]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
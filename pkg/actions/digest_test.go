@@ -0,0 +1,120 @@
+package actions_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyDigest(t *testing.T) {
+	setup := func(t *testing.T) string {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/daily-notes.json", `{"folder": "Daily"}`)
+		writeVaultFile(t, vaultPath, "Daily/2026-09-30.md", "outside the range\n")
+		writeVaultFile(t, vaultPath, "Daily/2026-10-01.md", "---\ntags: [daily]\n---\n\n## Tasks\n- [x] ship it\n- [ ] review\n\n## Log\n- standup\n")
+		writeVaultFile(t, vaultPath, "Daily/2026-10-02.md", "## Tasks\n- [ ] plan\n  - [x] draft\n")
+		writeVaultFile(t, vaultPath, "Daily/2026-10-03.md", "\n\n")
+		writeVaultFile(t, vaultPath, "Daily/Notes about days.md", "not a daily note\n")
+		return vaultPath
+	}
+
+	t.Run("Concatenates notes in the range under date headings, demoting their headings", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-07", Output: &out})
+		assert.NoError(t, err)
+		assert.Equal(t, "## 2026-10-01\n\n[[Daily/2026-10-01|2026-10-01]]\n\n### Tasks\n- [x] ship it\n- [ ] review\n\n### Log\n- standup\n\n"+
+			"## 2026-10-02\n\n[[Daily/2026-10-02|2026-10-02]]\n\n### Tasks\n- [ ] plan\n  - [x] draft\n", out.String())
+	})
+
+	t.Run("Extracts a single section", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-07", Section: "## Log", Output: &out})
+		assert.NoError(t, err)
+		assert.Equal(t, "## 2026-10-01\n\n[[Daily/2026-10-01|2026-10-01]]\n\n- standup\n", out.String())
+	})
+
+	t.Run("Keeps only completed tasks", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-07", Completed: true, Output: &out})
+		assert.NoError(t, err)
+		assert.Equal(t, "## 2026-10-01\n\n[[Daily/2026-10-01|2026-10-01]]\n\n- [x] ship it\n\n"+
+			"## 2026-10-02\n\n[[Daily/2026-10-02|2026-10-02]]\n\n  - [x] draft\n", out.String())
+	})
+
+	t.Run("Formats headings as dates, not note names", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/daily-notes.json", `{"format": "[Journal] DD.MM.YYYY"}`)
+		writeVaultFile(t, vaultPath, "Journal 01.10.2026.md", "- standup\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-01", HeadingFormat: "dddd, MMMM D", Output: &out})
+		assert.NoError(t, err)
+		assert.Equal(t, "## Thursday, October 1\n\n[[Journal 01.10.2026]]\n\n- standup\n", out.String())
+	})
+
+	t.Run("Selects the week containing the date", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		// Weeks start on Sunday by default, so 2026-10-01 (a Thursday) is
+		// in the week of 2026-09-27 to 2026-10-03.
+		err := actions.DailyDigest(&vault, actions.DigestParams{Week: true, Date: "2026-10-01", Output: &out})
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "## 2026-09-30")
+		assert.Contains(t, out.String(), "## 2026-10-02")
+	})
+
+	t.Run("Outputs JSON", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-02", Section: "Log", Format: "json", Output: &out})
+		assert.NoError(t, err)
+		var entries []actions.DigestEntry
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+		assert.Equal(t, []actions.DigestEntry{{Date: "2026-10-01", Note: "Daily/2026-10-01", Content: "- standup"}}, entries)
+	})
+
+	t.Run("Writes the digest to a note", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		err := actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", To: "2026-10-07", Section: "## Log", Write: "Weekly/2026-W41", Output: &out})
+		assert.NoError(t, err)
+		assert.Equal(t, "Wrote digest of 1 daily note to Weekly/2026-W41\n", out.String())
+		data, err := os.ReadFile(filepath.Join(vaultPath, "Weekly", "2026-W41.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "## 2026-10-01\n\n[[Daily/2026-10-01|2026-10-01]]\n\n- standup\n", string(data))
+	})
+
+	t.Run("Errors on invalid parameters", func(t *testing.T) {
+		vaultPath := setup(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out bytes.Buffer
+
+		assert.Error(t, actions.DailyDigest(&vault, actions.DigestParams{Output: &out}))
+		assert.Error(t, actions.DailyDigest(&vault, actions.DigestParams{Week: true, From: "2026-10-01", Output: &out}))
+		assert.Error(t, actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-07", To: "2026-10-01", Output: &out}))
+		assert.Error(t, actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", Format: "yaml", Output: &out}))
+		assert.Error(t, actions.DailyDigest(&vault, actions.DigestParams{From: "2026-10-01", Format: "json", Write: "Digest", Output: &out}))
+	})
+}
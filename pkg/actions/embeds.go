@@ -0,0 +1,180 @@
+package actions
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+// maxEmbedDepth limits how deeply embeds within embeds are expanded.
+const maxEmbedDepth = 10
+
+var embedPattern = regexp.MustCompile(`!\[\[([^\[\]|]*)(\|[^\[\]]*)?\]\]`)
+
+// attachmentExtensions are files Obsidian embeds as media rather than notes.
+var attachmentExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true, ".svg": true, ".webp": true, ".avif": true,
+	".mp3": true, ".wav": true, ".m4a": true, ".ogg": true, ".3gp": true, ".flac": true,
+	".mp4": true, ".webm": true, ".ogv": true, ".mov": true, ".mkv": true,
+	".pdf": true, ".canvas": true, ".base": true,
+}
+
+// embedExpander inlines note embeds ("![[Note]]", "![[Note#Heading]]",
+// "![[Note#^block]]") with the embedded note, section or block.
+type embedExpander struct {
+	note      obsidian.NoteManager
+	vaultPath string
+	notes     []string // vault-relative note paths, for resolving embeds
+}
+
+// expand replaces the embeds in content, which belongs to noteName, and the
+// embeds in what they embed, up to maxEmbedDepth levels. Embeds of
+// attachments, of missing notes, sections or blocks, and embeds that would
+// include themselves are left as they are.
+func (e embedExpander) expand(noteName, content string) string {
+	notes, err := e.note.GetNotesList(e.vaultPath)
+	if err != nil {
+		return content
+	}
+	e.notes = notes
+
+	if notePath, ok := e.resolve(noteName); ok {
+		noteName = notePath
+	}
+	return e.expandLevel(noteName, content, []string{embedKey(noteName, "")})
+}
+
+// resolve finds the note a link target refers to: the note at that path, or
+// else the note with the shortest path ending in it. It returns the note's
+// vault-relative path.
+func (e embedExpander) resolve(target string) (string, bool) {
+	want := strings.ToLower(obsidian.AddMdSuffix(strings.Trim(filepath.ToSlash(target), "/")))
+	best := ""
+	for _, note := range e.notes {
+		note = filepath.ToSlash(note)
+		lower := strings.ToLower(note)
+		if lower == want {
+			return note, true
+		}
+		if strings.HasSuffix(lower, "/"+want) && (best == "" || len(note) < len(best)) {
+			best = note
+		}
+	}
+	return best, best != ""
+}
+
+func (e embedExpander) expandLevel(noteName, content string, stack []string) string {
+	if len(stack) > maxEmbedDepth {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.Contains(line, "![[") {
+			continue
+		}
+
+		// Continuation lines keep the line's quote markers and indentation,
+		// and in a list item line up with the item's content.
+		prefix := line[:len(line)-len(strings.TrimLeft(line, " \t>"))]
+		if marker := listMarker.FindString(line[len(prefix):]); marker != "" {
+			prefix += strings.Repeat(" ", len(marker))
+		}
+		lines[i] = embedPattern.ReplaceAllStringFunc(line, func(embed string) string {
+			target := embedPattern.FindStringSubmatch(embed)[1]
+			expanded, ok := e.embedded(noteName, target, stack)
+			if !ok {
+				return embed
+			}
+			return strings.ReplaceAll(expanded, "\n", "\n"+prefix)
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// embedded returns the expanded contents that target ("Note#Heading")
+// refers to from noteName.
+func (e embedExpander) embedded(noteName, target string, stack []string) (string, bool) {
+	name, sub, _ := strings.Cut(strings.TrimSpace(target), "#")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = noteName
+	} else {
+		if ext := strings.ToLower(path.Ext(name)); attachmentExtensions[ext] {
+			return "", false
+		}
+		notePath, ok := e.resolve(name)
+		if !ok {
+			return "", false
+		}
+		name = notePath
+	}
+
+	key := embedKey(name, sub)
+	for _, open := range stack {
+		if open == key {
+			return "", false
+		}
+	}
+
+	contents, err := e.note.GetContents(e.vaultPath, name)
+	if err != nil {
+		return "", false
+	}
+	part, ok := embedPart(contents, sub)
+	if !ok {
+		return "", false
+	}
+	// Trim again in case an embed at either end expanded to nothing.
+	expanded := e.expandLevel(name, part, append(stack, key))
+	return trimBlankLines(strings.Split(expanded, "\n")), true
+}
+
+// embedPart returns the part of a note's contents that an embed's subpath
+// refers to: the block for "^id", the section for a heading, or the body
+// without frontmatter when sub is empty.
+func embedPart(contents, sub string) (string, bool) {
+	lines := strings.Split(contents, "\n")
+
+	var part []string
+	switch {
+	case strings.HasPrefix(sub, "^"):
+		start, end, ok := markdown.FindBlock(lines, sub)
+		if !ok {
+			return "", false
+		}
+		for _, line := range lines[start:end] {
+			part = append(part, markdown.TrimBlockID(line))
+		}
+	case sub != "":
+		start, end, ok := markdown.FindHeadingPath(lines, sub)
+		if !ok {
+			return "", false
+		}
+		part = lines[start:end]
+	default:
+		part = lines[markdown.BodyStart(lines):]
+	}
+	return trimBlankLines(part), true
+}
+
+// embedKey identifies an embedded note part for cycle detection by the note's
+// vault-relative path, so that notes with the same name in different folders
+// are told apart.
+func embedKey(notePath, sub string) string {
+	name := strings.ToLower(obsidian.RemoveMdSuffix(notePath))
+	if sub == "" {
+		return name
+	}
+	return name + "#" + sub
+}
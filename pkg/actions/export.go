@@ -0,0 +1,606 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+// exportAttachmentDir is the folder of the export that attachments are
+// copied into, keeping their vault paths.
+const exportAttachmentDir = "_attachments"
+
+var (
+	imageExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true, ".svg": true, ".webp": true, ".avif": true}
+	audioExtensions = map[string]bool{".mp3": true, ".wav": true, ".m4a": true, ".ogg": true, ".3gp": true, ".flac": true}
+	videoExtensions = map[string]bool{".mp4": true, ".webm": true, ".ogv": true, ".mov": true, ".mkv": true}
+)
+
+type ExportHTMLParams struct {
+	Target string // note or folder to export; the whole vault if empty
+	Out    string // directory the pages are written to
+	Output io.Writer
+}
+
+// ExportHTML renders a note, or every note in a folder, as standalone HTML
+// pages in params.Out. Wikilinks, embeds and Markdown links between exported
+// notes become relative links, and embedded notes are inlined. Linked and
+// embedded attachments are copied next to the pages. Each page lists the
+// exported notes that link to it, and folder exports get an index page
+// unless the folder has an "index" note. Folder exports leave out the core
+// Templates plugin's folder. Notes excluded by userIgnoreFilters
+// or with "publish: false" are neither exported nor embedded, and links to
+// them are left unresolved.
+func ExportHTML(vault obsidian.VaultManager, params ExportHTMLParams) error {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(params.Out) == "" {
+		return errors.New("an output directory is required")
+	}
+	out, err := filepath.Abs(params.Out)
+	if err != nil {
+		return err
+	}
+
+	e := &siteExporter{
+		vaultPath:   vaultPath,
+		out:         out,
+		notes:       map[string]*exportNote{},
+		pages:       map[string]*exportPage{},
+		attachments: map[string]bool{},
+	}
+	excludedNotes, err := e.scan()
+	if err != nil {
+		return err
+	}
+
+	target := strings.Trim(filepath.ToSlash(strings.TrimSpace(params.Target)), "/")
+	var root string
+	var sources []string
+	skipped := 0
+	if info, err := os.Stat(filepath.Join(vaultPath, filepath.FromSlash(target))); target == "" || (err == nil && info.IsDir()) {
+		if _, err := obsidian.ValidatePath(vaultPath, target); err != nil {
+			return err
+		}
+		if obsidian.IsExcluded(target, obsidian.ExcludedPaths(vaultPath)) {
+			return fmt.Errorf("folder '%s' is excluded by userIgnoreFilters", target)
+		}
+		root = target
+		e.folder = true
+		// Templates are not pages, unless their folder is what is exported.
+		templates := strings.Trim(filepath.ToSlash(obsidian.ReadTemplatesConfig(vaultPath).Folder), "/")
+		if root == templates || inFolder(root, templates) {
+			templates = ""
+		}
+		for _, file := range e.files {
+			if templates != "" && inFolder(file, templates) {
+				continue
+			}
+			if strings.HasSuffix(file, ".md") && inFolder(file, root) {
+				sources = append(sources, file)
+			}
+		}
+		for _, file := range excludedNotes {
+			if inFolder(file, root) {
+				skipped++
+			}
+		}
+		if root == "" {
+			root = "."
+		}
+	} else {
+		notePath, ok := e.resolve(obsidian.RemoveMdSuffix(target))
+		if !ok || !strings.HasSuffix(notePath, ".md") {
+			return fmt.Errorf("note or folder '%s' not found", params.Target)
+		}
+		note, err := e.note(notePath)
+		if err != nil {
+			return err
+		}
+		if note.private {
+			return fmt.Errorf("note '%s' has publish: false", notePath)
+		}
+		root = path.Dir(notePath)
+		sources = []string{notePath}
+	}
+
+	for _, source := range sources {
+		note, err := e.note(source)
+		if err != nil {
+			return err
+		}
+		if note.private {
+			skipped++
+			continue
+		}
+		rel := strings.TrimPrefix(source, root+"/")
+		if root == "." {
+			rel = source
+		}
+		e.pages[source] = &exportPage{
+			source:    source,
+			file:      strings.TrimSuffix(rel, ".md") + ".html",
+			title:     path.Base(obsidian.RemoveMdSuffix(source)),
+			backlinks: map[string]bool{},
+		}
+	}
+	if len(e.pages) == 0 {
+		return fmt.Errorf("no notes to export in '%s'", params.Target)
+	}
+
+	// Render every page first, so that all links are known for backlinks.
+	for _, source := range e.sortedPages() {
+		page := e.pages[source]
+		r := &pageRenderer{e: e, page: page, source: source, stack: []string{embedKey(source, "")}}
+		page.ids = markdown.HeadingIDs(e.notes[source].contents)
+		page.body = markdown.ToHTML(e.notes[source].contents, r.options())
+	}
+
+	_, hasIndex := e.pages[path.Join(root, "index.md")]
+	if root == "." {
+		_, hasIndex = e.pages["index.md"]
+	}
+	for _, source := range e.sortedPages() {
+		if err := e.writePage(e.pages[source]); err != nil {
+			return err
+		}
+	}
+	if e.folder && !hasIndex {
+		title := path.Base(root)
+		if root == "." {
+			title = vaultName
+		}
+		if err := e.writeIndex(title); err != nil {
+			return err
+		}
+	}
+	for attachment := range e.attachments {
+		if err := e.copyAttachment(attachment); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(params.Output, "Exported %d %s to %s", len(e.pages), plural(len(e.pages), "note", "notes"), params.Out)
+	if len(e.attachments) > 0 {
+		fmt.Fprintf(params.Output, " (%d %s)", len(e.attachments), plural(len(e.attachments), "attachment", "attachments"))
+	}
+	fmt.Fprintln(params.Output)
+	if skipped > 0 {
+		fmt.Fprintf(params.Output, "Skipped %d %s excluded by userIgnoreFilters or publish: false\n", skipped, plural(skipped, "note", "notes"))
+	}
+	return nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func inFolder(file, folder string) bool {
+	return folder == "" || strings.HasPrefix(file, folder+"/")
+}
+
+// siteExporter holds the state of an export.
+type siteExporter struct {
+	vaultPath   string
+	out         string
+	folder      bool                   // exporting a folder rather than a note
+	files       []string               // vault files that are not excluded, sorted
+	notes       map[string]*exportNote // notes read so far, by vault path
+	pages       map[string]*exportPage // exported notes, by vault path
+	attachments map[string]bool        // attachments to copy, by vault path
+}
+
+type exportNote struct {
+	contents string
+	private  bool // publish: false
+}
+
+type exportPage struct {
+	source    string // vault path of the note
+	file      string // path of the page in the export
+	title     string
+	body      string
+	backlinks map[string]bool // vault paths of the pages linking here
+	ids       map[string]int  // heading IDs on the page, kept unique in embeds
+}
+
+// scan lists the files of the vault that are not excluded, skipping hidden
+// folders and the export itself. It returns the excluded notes.
+func (e *siteExporter) scan() ([]string, error) {
+	filters := obsidian.ExcludedPaths(e.vaultPath)
+	var excluded []string
+	err := filepath.WalkDir(e.vaultPath, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(e.vaultPath, file)
+		if err != nil || relPath == "." {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if strings.HasPrefix(d.Name(), ".") || file == e.out {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if obsidian.IsExcluded(relPath, filters) {
+			if strings.HasSuffix(relPath, ".md") {
+				excluded = append(excluded, relPath)
+			}
+			return nil
+		}
+		e.files = append(e.files, relPath)
+		return nil
+	})
+	sort.Strings(e.files)
+	return excluded, err
+}
+
+// resolve finds the vault file a link target refers to as Obsidian does: the
+// exact path first, then the file with the shortest path ending in the
+// target. Targets without an extension refer to notes.
+func (e *siteExporter) resolve(target string) (string, bool) {
+	target = strings.ToLower(strings.Trim(target, "/"))
+	if target == "" {
+		return "", false
+	}
+	wants := []string{target + ".md", target}
+	for _, want := range wants {
+		for _, file := range e.files {
+			if strings.ToLower(file) == want {
+				return file, true
+			}
+		}
+	}
+	best := ""
+	for _, want := range wants {
+		for _, file := range e.files {
+			if strings.HasSuffix(strings.ToLower(file), "/"+want) && (best == "" || len(file) < len(best)) {
+				best = file
+			}
+		}
+		if best != "" {
+			return best, true
+		}
+	}
+	return "", false
+}
+
+// note reads a vault note, once.
+func (e *siteExporter) note(relPath string) (*exportNote, error) {
+	if note, ok := e.notes[relPath]; ok {
+		return note, nil
+	}
+	data, err := os.ReadFile(filepath.Join(e.vaultPath, filepath.FromSlash(relPath)))
+	if err != nil {
+		return nil, err
+	}
+	note := &exportNote{contents: string(data)}
+	if fm, _, err := frontmatter.Parse(note.contents); err == nil {
+		switch publish := fm["publish"].(type) {
+		case bool:
+			note.private = !publish
+		case string:
+			note.private = strings.EqualFold(strings.TrimSpace(publish), "false")
+		}
+	}
+	e.notes[relPath] = note
+	return note, nil
+}
+
+func (e *siteExporter) sortedPages() []string {
+	sources := make([]string, 0, len(e.pages))
+	for source := range e.pages {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// attachmentFile returns the path in the export of a copied attachment.
+func attachmentFile(relPath string) string {
+	return path.Join(exportAttachmentDir, relPath)
+}
+
+func (e *siteExporter) copyAttachment(relPath string) error {
+	data, err := os.ReadFile(filepath.Join(e.vaultPath, filepath.FromSlash(relPath)))
+	if err != nil {
+		return err
+	}
+	return e.write(attachmentFile(relPath), data)
+}
+
+func (e *siteExporter) write(file string, data []byte) error {
+	target := filepath.Join(e.out, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
+
+func (e *siteExporter) writePage(page *exportPage) error {
+	var sb strings.Builder
+	if e.folder {
+		sb.WriteString(fmt.Sprintf("<nav><a href=\"%s\">Index</a></nav>\n", html.EscapeString(relativeURL(page.file, "index.html"))))
+	}
+	sb.WriteString("<main>\n")
+	if !strings.HasPrefix(page.body, "<h1") {
+		sb.WriteString("<h1>" + html.EscapeString(page.title) + "</h1>\n")
+	}
+	sb.WriteString(page.body + "\n</main>\n")
+
+	var backlinks []*exportPage
+	for source := range page.backlinks {
+		if source != page.source {
+			backlinks = append(backlinks, e.pages[source])
+		}
+	}
+	if len(backlinks) > 0 {
+		sort.Slice(backlinks, func(i, j int) bool {
+			return strings.ToLower(backlinks[i].title) < strings.ToLower(backlinks[j].title)
+		})
+		sb.WriteString("<section class=\"backlinks\">\n<h2>Linked from</h2>\n<ul>\n")
+		for _, backlink := range backlinks {
+			sb.WriteString(fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(relativeURL(page.file, backlink.file)), html.EscapeString(backlink.title)))
+		}
+		sb.WriteString("</ul>\n</section>\n")
+	}
+	return e.write(page.file, []byte(htmlPage(page.title, sb.String())))
+}
+
+// writeIndex writes an index page listing the exported notes by folder.
+func (e *siteExporter) writeIndex(title string) error {
+	folders := map[string][]*exportPage{}
+	for _, page := range e.pages {
+		folder := path.Dir(page.file)
+		folders[folder] = append(folders[folder], page)
+	}
+	var names []string
+	for folder := range folders {
+		names = append(names, folder)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("<main>\n<h1>" + html.EscapeString(title) + "</h1>\n")
+	for _, folder := range names {
+		pages := folders[folder]
+		sort.Slice(pages, func(i, j int) bool {
+			return strings.ToLower(pages[i].title) < strings.ToLower(pages[j].title)
+		})
+		if folder != "." {
+			sb.WriteString("<h2>" + html.EscapeString(folder) + "</h2>\n")
+		}
+		sb.WriteString("<ul>\n")
+		for _, page := range pages {
+			sb.WriteString(fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(relativeURL("index.html", page.file)), html.EscapeString(page.title)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+	sb.WriteString("</main>\n")
+	return e.write("index.html", []byte(htmlPage(title, sb.String())))
+}
+
+const exportStyle = `body { max-width: 46rem; margin: 2rem auto; padding: 0 1rem; font: 16px/1.6 system-ui, sans-serif; color: #222; }
+a { color: #705dcf; }
+pre { background: #f5f5f5; padding: .75rem; overflow-x: auto; }
+code { background: #f5f5f5; padding: 0 .2em; }
+pre code { padding: 0; }
+blockquote { margin-left: 0; padding-left: 1rem; border-left: 3px solid #ddd; color: #555; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ddd; padding: .25rem .5rem; }
+img { max-width: 100%; }
+mark { background: #fff3a3; }
+.tag { color: #705dcf; }
+.is-unresolved { color: #888; }
+.task-list-item { list-style: none; }
+.callout { border-left: 4px solid #705dcf; background: #f4f2fc; padding: .5rem 1rem; margin: 1rem 0; }
+.callout-title { font-weight: bold; }
+.embed { border-left: 3px solid #705dcf; padding-left: 1rem; margin: 1rem 0; }
+.backlinks { border-top: 1px solid #ddd; margin-top: 2rem; }
+nav { margin-bottom: 1rem; }`
+
+func htmlPage(title, body string) string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n" +
+		"<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n" +
+		"<title>" + html.EscapeString(title) + "</title>\n<style>\n" + exportStyle + "\n</style>\n</head>\n<body>\n" +
+		body + "</body>\n</html>\n"
+}
+
+// relativeURL returns the URL of the export file to, relative to the page
+// from.
+func relativeURL(from, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(to))
+	if err != nil {
+		rel = to
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// subpathAnchor returns the URL fragment for a link's subpath ("Heading",
+// "Parent#Child" or "^block").
+func subpathAnchor(sub string) string {
+	sub = strings.TrimSpace(sub)
+	if sub == "" {
+		return ""
+	}
+	if strings.HasPrefix(sub, "^") {
+		return "#" + sub
+	}
+	headings := strings.Split(sub, "#")
+	return "#" + markdown.HeadingID(headings[len(headings)-1])
+}
+
+// pageRenderer renders a page, or a note embedded in it. Links are relative
+// to the page either way.
+type pageRenderer struct {
+	e      *siteExporter
+	page   *exportPage
+	source string   // vault path of the note being rendered
+	stack  []string // embedKeys of the notes being embedded, for cycles
+}
+
+func (r *pageRenderer) options() markdown.RenderOptions {
+	// Raw HTML in notes is not trusted, so scripts cannot end up in the site.
+	opts := markdown.RenderOptions{WikiLink: r.wikiLink, Embed: r.embed, Resource: r.resource, EscapeHTML: true}
+	if len(r.stack) > 1 {
+		// Headings of embedded notes must not reuse the page's IDs.
+		opts.HeadingIDs = r.page.ids
+	}
+	return opts
+}
+
+// link returns the URL of a vault file from the page: the page of an
+// exported note or a copied attachment. It reports false for notes that are
+// not exported.
+func (r *pageRenderer) link(relPath, sub string) (string, bool) {
+	if !strings.HasSuffix(relPath, ".md") {
+		r.e.attachments[relPath] = true
+		return relativeURL(r.page.file, attachmentFile(relPath)), true
+	}
+	target, ok := r.e.pages[relPath]
+	if !ok {
+		return "", false
+	}
+	target.backlinks[r.page.source] = true
+	if target == r.page && sub != "" {
+		return subpathAnchor(sub), true
+	}
+	return relativeURL(r.page.file, target.file) + subpathAnchor(sub), true
+}
+
+func (r *pageRenderer) wikiLink(target string) (string, bool) {
+	name, sub, _ := strings.Cut(target, "#")
+	relPath := r.source
+	if name = strings.TrimSpace(name); name != "" {
+		var ok bool
+		if relPath, ok = r.e.resolve(name); !ok {
+			return "", false
+		}
+	}
+	return r.link(relPath, sub)
+}
+
+// embed renders attachments as images, audio, video or links, and notes,
+// sections and blocks inline.
+func (r *pageRenderer) embed(target, alias string) (string, bool) {
+	name, sub, _ := strings.Cut(target, "#")
+	name = strings.TrimSpace(name)
+	relPath := r.source
+	if name != "" {
+		var ok bool
+		if relPath, ok = r.e.resolve(name); !ok {
+			return "", false
+		}
+	}
+
+	if !strings.HasSuffix(relPath, ".md") {
+		src, _ := r.link(relPath, "")
+		ext := strings.ToLower(path.Ext(relPath))
+		switch {
+		case imageExtensions[ext]:
+			size := ""
+			if isImageSize(alias) {
+				width, height, _ := strings.Cut(alias, "x")
+				size = fmt.Sprintf(" width=\"%s\"", width)
+				if height != "" {
+					size += fmt.Sprintf(" height=\"%s\"", height)
+				}
+				alias = ""
+			}
+			if alias == "" {
+				alias = path.Base(relPath)
+			}
+			return fmt.Sprintf("<img src=\"%s\" alt=\"%s\"%s>", html.EscapeString(src), html.EscapeString(alias), size), true
+		case audioExtensions[ext]:
+			return fmt.Sprintf("<audio controls src=\"%s\"></audio>", html.EscapeString(src)), true
+		case videoExtensions[ext]:
+			return fmt.Sprintf("<video controls src=\"%s\"></video>", html.EscapeString(src)), true
+		}
+		if alias == "" {
+			alias = path.Base(relPath)
+		}
+		return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(src), html.EscapeString(alias)), true
+	}
+
+	note, err := r.e.note(relPath)
+	if err != nil || note.private || len(r.stack) > maxEmbedDepth {
+		return "", false
+	}
+	key := embedKey(relPath, sub)
+	for _, open := range r.stack {
+		if open == key {
+			return "", false
+		}
+	}
+	part, ok := embedPart(note.contents, sub)
+	if !ok {
+		return "", false
+	}
+	if page, ok := r.e.pages[relPath]; ok {
+		page.backlinks[r.page.source] = true
+	}
+
+	nested := &pageRenderer{e: r.e, page: r.page, source: relPath, stack: append(r.stack[:len(r.stack):len(r.stack)], key)}
+	return "<div class=\"embed\">\n" + markdown.ToHTML(part, nested.options()) + "\n</div>", true
+}
+
+// resource resolves the destination of a Markdown link or image to a vault
+// file, relative to the note first as Obsidian does. Other destinations are
+// kept.
+func (r *pageRenderer) resource(dest string) string {
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return dest
+	}
+
+	relPath := ""
+	candidate := path.Join(path.Dir(r.source), u.Path)
+	for _, file := range r.e.files {
+		if file == candidate {
+			relPath = file
+			break
+		}
+	}
+	if relPath == "" {
+		var ok bool
+		if relPath, ok = r.e.resolve(obsidian.RemoveMdSuffix(u.Path)); !ok {
+			return dest
+		}
+	}
+	if href, ok := r.link(relPath, u.Fragment); ok {
+		return href
+	}
+	return dest
+}
@@ -0,0 +1,184 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHTML(t *testing.T) {
+	newVault := func(t *testing.T) string {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/app.json", `{"userIgnoreFilters": ["Drafts/"]}`)
+		writeVaultFile(t, vaultPath, "Public/Home.md", "---\ntags: [home]\n---\n# Welcome\n\nSee [[Guide]], [[Guide#Install|install]], [[Secret]] and [[Draft]].\n\n![[pic.png|300]]\n\n![[Guide#Install]]\n")
+		writeVaultFile(t, vaultPath, "Public/Docs/Guide.md", "## Install\n\nRun it. ^step\n\nBack to [[Home]] or [the start](../Home.md).\n")
+		writeVaultFile(t, vaultPath, "Public/Secret.md", "---\npublish: false\n---\nsecret\n")
+		writeVaultFile(t, vaultPath, "Drafts/Draft.md", "draft\n")
+		writeVaultFile(t, vaultPath, "assets/pic.png", "PNG")
+		return vaultPath
+	}
+	readPage := func(t *testing.T, out, file string) string {
+		data, err := os.ReadFile(filepath.Join(out, file))
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("Exports a folder with links, embeds, attachments and backlinks", func(t *testing.T) {
+		vaultPath, out := newVault(t), filepath.Join(t.TempDir(), "site")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var output strings.Builder
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Public", Out: out, Output: &output})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Exported 2 notes to "+out+" (1 attachment)\nSkipped 1 note excluded by userIgnoreFilters or publish: false\n", output.String())
+
+		home := readPage(t, out, "Home.html")
+		assert.Contains(t, home, "<title>Home</title>")
+		assert.Contains(t, home, "<nav><a href=\"index.html\">Index</a></nav>")
+		assert.Contains(t, home, "<a class=\"internal-link\" href=\"Docs/Guide.html\">Guide</a>")
+		assert.Contains(t, home, "<a class=\"internal-link\" href=\"Docs/Guide.html#install\">install</a>")
+		assert.Contains(t, home, "<span class=\"internal-link is-unresolved\">Secret</span>")
+		assert.Contains(t, home, "<span class=\"internal-link is-unresolved\">Draft</span>")
+		assert.Contains(t, home, "<img src=\"_attachments/assets/pic.png\" alt=\"pic.png\" width=\"300\">")
+		assert.Contains(t, home, "<div class=\"embed\">\n<h2 id=\"install\">Install</h2>\n<p id=\"^step\">Run it.</p>\n"+
+			"<p>Back to <a class=\"internal-link\" href=\"Home.html\">Home</a> or <a href=\"Home.html\">the start</a>.</p>\n</div>")
+		assert.NotContains(t, home, "<h1>Home</h1>")
+
+		guide := readPage(t, out, "Docs/Guide.html")
+		assert.Contains(t, guide, "<nav><a href=\"../index.html\">Index</a></nav>")
+		assert.Contains(t, guide, "<h1>Guide</h1>")
+		assert.Contains(t, guide, "<a href=\"../Home.html\">the start</a>")
+		assert.Contains(t, guide, "<h2>Linked from</h2>\n<ul>\n<li><a href=\"../Home.html\">Home</a></li>\n</ul>")
+
+		index := readPage(t, out, "index.html")
+		assert.Contains(t, index, "<h1>Public</h1>\n<ul>\n<li><a href=\"Home.html\">Home</a></li>\n</ul>\n<h2>Docs</h2>\n<ul>\n<li><a href=\"Docs/Guide.html\">Guide</a></li>\n</ul>")
+
+		image, _ := os.ReadFile(filepath.Join(out, "_attachments", "assets", "pic.png"))
+		assert.Equal(t, "PNG", string(image))
+		_, err = os.Stat(filepath.Join(out, "Secret.html"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Exports a single note", func(t *testing.T) {
+		vaultPath, out := newVault(t), t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var output strings.Builder
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Guide", Out: out, Output: &output})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Exported 1 note to "+out+"\n", output.String())
+		guide := readPage(t, out, "Guide.html")
+		assert.Contains(t, guide, "<span class=\"internal-link is-unresolved\">Home</span>")
+		assert.NotContains(t, guide, "<nav>")
+		_, err = os.Stat(filepath.Join(out, "index.html"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Uses the folder's index note as the index page", func(t *testing.T) {
+		vaultPath, out := newVault(t), t.TempDir()
+		writeVaultFile(t, vaultPath, "Public/index.md", "Start at [[Home]]\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Public", Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		assert.Contains(t, readPage(t, out, "index.html"), "Start at <a class=\"internal-link\" href=\"Home.html\">Home</a>")
+	})
+
+	t.Run("Does not embed notes in a loop forever", func(t *testing.T) {
+		vaultPath, out := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, "A.md", "a\n\n![[B]]\n")
+		writeVaultFile(t, vaultPath, "B.md", "b\n\n![[A]]\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "A", Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		assert.Contains(t, readPage(t, out, "A.html"), "<p>a</p>\n<div class=\"embed\">\n<p>b</p>\n<p><a class=\"internal-link\" href=\"A.html\">A</a></p>\n</div>")
+	})
+
+	t.Run("Gives embedded headings IDs unused on the page", func(t *testing.T) {
+		vaultPath, out := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, "Page.md", "## Install\n\n![[Guide]]\n\n![[Guide]]\n\n## Install\n")
+		writeVaultFile(t, vaultPath, "Guide.md", "## Install\n\nRun it.\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Page", Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		page := readPage(t, out, "Page.html")
+		// The page keeps its own IDs; the embedded headings are numbered on.
+		last := -1
+		for _, id := range []string{"install", "install-2", "install-3", "install-1"} {
+			assert.Equal(t, 1, strings.Count(page, "id=\""+id+"\""), id)
+			index := strings.Index(page, "<h2 id=\""+id+"\">")
+			assert.Greater(t, index, last, id)
+			last = index
+		}
+	})
+
+	t.Run("Leaves out the templates folder", func(t *testing.T) {
+		vaultPath, out := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/templates.json", `{"folder": "Templates"}`)
+		writeVaultFile(t, vaultPath, "Templates/Meeting.md", "# {{title}}\n")
+		writeVaultFile(t, vaultPath, "Notes/Plan.md", "plan\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(out, "Notes", "Plan.html"))
+		assert.NoFileExists(t, filepath.Join(out, "Templates", "Meeting.html"))
+		assert.NotContains(t, readPage(t, out, "index.html"), "Meeting")
+
+		out = t.TempDir()
+		err = actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Templates", Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(out, "Meeting.html"))
+	})
+
+	t.Run("Escapes raw HTML in notes", func(t *testing.T) {
+		vaultPath, out := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, "Page.md", "<script>alert(1)</script>\n\nHi <img src=x onerror=\"alert(1)\">\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Page", Out: out, Output: &strings.Builder{}})
+
+		assert.NoError(t, err)
+		page := readPage(t, out, "Page.html")
+		assert.NotContains(t, page, "<script>alert")
+		assert.NotContains(t, page, "<img src=x")
+		assert.Contains(t, page, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>")
+	})
+
+	t.Run("Rejects private, excluded and missing targets", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		for target, message := range map[string]string{
+			"Secret":  "note 'Public/Secret.md' has publish: false",
+			"Drafts":  "folder 'Drafts' is excluded by userIgnoreFilters",
+			"Draft":   "note or folder 'Draft' not found",
+			"Missing": "note or folder 'Missing' not found",
+		} {
+			err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: target, Out: t.TempDir(), Output: &strings.Builder{}})
+			assert.EqualError(t, err, message, target)
+		}
+	})
+
+	t.Run("Requires an output directory", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		err := actions.ExportHTML(&vault, actions.ExportHTMLParams{Target: "Note", Output: &strings.Builder{}})
+
+		assert.EqualError(t, err, "an output directory is required")
+	})
+}
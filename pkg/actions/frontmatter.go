@@ -3,18 +3,43 @@ package actions
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	frontmatterFormatYAML = "yaml"
+	frontmatterFormatJSON = "json"
 )
 
 type FrontmatterParams struct {
-	NoteName string
-	Print    bool
-	Edit     bool
-	Delete   bool
-	Key      string
-	Value    string
+	NoteName   string
+	Print      bool
+	Get        string // key to print the value of
+	Default    string // printed by Get when the key is missing, if HasDefault
+	HasDefault bool
+	Format     string // output format for Print and Get: yaml or json
+	Edit       bool
+	Delete     bool
+	Key        string
+	Value      string
+	Add        []string // "key=value" items to add to list properties
+	Remove     []string // "key=value" items to remove from list properties
+	Toggle     []string // boolean keys to flip
+	Increment  []string // numeric keys to increment, as "key" or "key=step"
+	Rename     string   // "old=new" key rename
+	ConvertTo  string   // frontmatter format to convert to: yaml, toml or json
+
+	// Bulk mode: when Filter is set, the edits are applied to every matching
+	// note instead of NoteName. Per-note results are written to Output.
+	Filter NoteFilter
+	DryRun bool
+	Output io.Writer
 }
 
 // Frontmatter handles viewing and modifying note frontmatter.
@@ -30,29 +55,105 @@ func Frontmatter(vault obsidian.VaultManager, note obsidian.NoteManager, params
 		return "", err
 	}
 
+	format, err := normalizeFrontmatterFormat(params.Format)
+	if err != nil {
+		return "", err
+	}
+
+	// Coerce edited values to the property types declared in Obsidian.
+	types := frontmatter.PropertyTypes(obsidian.ReadPropertyTypes(vaultPath))
+
+	if params.Filter.IsSet() {
+		return handleBulk(note, vaultPath, types, params)
+	}
+
+	// Read, edit and write the note again if it changes in between.
+	return retryOnChange(func() (string, error) {
+		return frontmatterNote(note, types, vaultPath, format, params)
+	})
+}
+
+// frontmatterNote applies the operation selected by params to a single note.
+func frontmatterNote(note obsidian.NoteManager, types frontmatter.PropertyTypes, vaultPath, format string, params FrontmatterParams) (string, error) {
+	edits, err := bulkEdits(types, params)
+	if err != nil {
+		return "", err
+	}
+	if len(edits) > 0 && (params.Print || params.Get != "") {
+		return "", errors.New("--print and --get cannot be combined with edit operations")
+	}
+
 	contents, err := note.GetContents(vaultPath, params.NoteName)
 	if err != nil {
 		return "", err
 	}
 
+	// Apply combined operations together, as for note filters
+	if frontmatterOperations(params) > 1 {
+		return handleCombined(note, vaultPath, params.NoteName, contents, edits, params.DryRun)
+	}
+
+	// Handle get operation
+	if params.Get != "" {
+		return handleGet(contents, params, format)
+	}
+
 	// Handle print operation
 	if params.Print {
+		if format == frontmatterFormatJSON {
+			return handlePrintJSON(contents)
+		}
 		return handlePrint(contents)
 	}
 
 	// Handle edit operation
 	if params.Edit {
-		return handleEdit(note, vaultPath, params.NoteName, contents, params.Key, params.Value)
+		return handleEdit(note, types, vaultPath, params.NoteName, contents, params.Key, params.Value, params.DryRun)
 	}
 
 	// Handle delete operation
 	if params.Delete {
-		return handleDelete(note, vaultPath, params.NoteName, contents, params.Key)
+		return handleDelete(note, vaultPath, params.NoteName, contents, params.Key, params.DryRun)
 	}
 
-	return "", errors.New("no operation specified: use --print, --edit, or --delete")
+	// Handle rename operation
+	if params.Rename != "" {
+		return handleRename(note, vaultPath, params.NoteName, contents, params.Rename, params.DryRun)
+	}
+
+	// Handle convert operation
+	if params.ConvertTo != "" {
+		return handleConvert(note, vaultPath, params.NoteName, contents, params.ConvertTo, params.DryRun)
+	}
+
+	// Handle list, toggle and increment operations
+	if len(params.Add) > 0 || len(params.Remove) > 0 || len(params.Toggle) > 0 || len(params.Increment) > 0 {
+		return handleUpdates(note, types, vaultPath, contents, params)
+	}
+
+	return "", errors.New(noFrontmatterOperationError)
 }
 
+// frontmatterOperations counts the edit operations requested by params. The
+// list, toggle and increment flags count as one operation.
+func frontmatterOperations(params FrontmatterParams) int {
+	count := 0
+	for _, set := range []bool{
+		params.Edit,
+		params.Delete,
+		params.Rename != "",
+		params.ConvertTo != "",
+		len(params.Add) > 0 || len(params.Remove) > 0 || len(params.Toggle) > 0 || len(params.Increment) > 0,
+	} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+const noFrontmatterOperationError = "no operation specified: use --print, --get, --edit, --delete, --rename, --add, --remove, --toggle or --increment"
+
 func handlePrint(contents string) (string, error) {
 	if !frontmatter.HasFrontmatter(contents) {
 		return "", nil // Return empty for notes without frontmatter
@@ -71,7 +172,73 @@ func handlePrint(contents string) (string, error) {
 	return formatted, nil
 }
 
-func handleEdit(note obsidian.NoteManager, vaultPath, noteName, contents, key, value string) (string, error) {
+func handlePrintJSON(contents string) (string, error) {
+	doc, err := frontmatter.ParseDocument(contents)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := doc.JSON()
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// handleGet prints the value of a single key. Scalars are printed as is,
+// lists and maps as YAML or JSON. A missing key is an error unless a default
+// is given.
+func handleGet(contents string, params FrontmatterParams, format string) (string, error) {
+	doc, err := frontmatter.ParseDocument(contents)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := doc.Get(doc.ResolvePath(params.Get)...)
+	if !ok {
+		if !params.HasDefault {
+			return "", fmt.Errorf("frontmatter key '%s' not found in %s", params.Get, params.NoteName)
+		}
+		// The default is read like a plain YAML scalar, so --default 0 is a
+		// number and --default true a boolean in JSON output. A default that
+		// would read as null, such as --default '', stays a string.
+		value = &yaml.Node{Kind: yaml.ScalarNode, Value: params.Default}
+		var decoded interface{}
+		if err := value.Decode(&decoded); err != nil || decoded == nil {
+			value.Tag = "!!str"
+		}
+	}
+
+	if format == frontmatterFormatJSON {
+		data, err := frontmatter.ToJSON(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	formatted, err := frontmatter.ToYAML(value)
+	if err != nil {
+		return "", err
+	}
+	return formatted + "\n", nil
+}
+
+func normalizeFrontmatterFormat(format string) (string, error) {
+	trimmed := strings.TrimSpace(strings.ToLower(format))
+	if trimmed == "" {
+		return frontmatterFormatYAML, nil
+	}
+
+	switch trimmed {
+	case frontmatterFormatYAML, frontmatterFormatJSON:
+		return trimmed, nil
+	default:
+		return "", fmt.Errorf("invalid format '%s': expected one of yaml, json", format)
+	}
+}
+
+func handleEdit(note obsidian.NoteManager, types frontmatter.PropertyTypes, vaultPath, noteName, contents, key, value string, dryRun bool) (string, error) {
 	if key == "" {
 		return "", errors.New("--key is required for edit operation")
 	}
@@ -79,33 +246,349 @@ func handleEdit(note obsidian.NoteManager, vaultPath, noteName, contents, key, v
 		return "", errors.New("--value is required for edit operation")
 	}
 
-	updatedContent, err := frontmatter.SetKey(contents, key, value)
+	updatedContent, err := types.SetKey(contents, key, value)
 	if err != nil {
 		return "", err
 	}
 
-	err = note.SetContents(vaultPath, noteName, updatedContent)
+	return writeFrontmatter(note, vaultPath, noteName, contents, updatedContent, dryRun,
+		fmt.Sprintf("Updated frontmatter key '%s' in %s", key, noteName))
+}
+
+func handleDelete(note obsidian.NoteManager, vaultPath, noteName, contents, key string, dryRun bool) (string, error) {
+	if key == "" {
+		return "", errors.New("--key is required for delete operation")
+	}
+
+	updatedContent, err := frontmatter.DeleteKey(contents, key)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("Updated frontmatter key '%s' in %s", key, noteName), nil
+	return writeFrontmatter(note, vaultPath, noteName, contents, updatedContent, dryRun,
+		fmt.Sprintf("Deleted frontmatter key '%s' from %s", key, noteName))
 }
 
-func handleDelete(note obsidian.NoteManager, vaultPath, noteName, contents, key string) (string, error) {
-	if key == "" {
-		return "", errors.New("--key is required for delete operation")
+func handleRename(note obsidian.NoteManager, vaultPath, noteName, contents, rename string, dryRun bool) (string, error) {
+	oldKey, newKey, err := splitKeyValue("--rename", rename)
+	if err != nil {
+		return "", err
 	}
 
-	updatedContent, err := frontmatter.DeleteKey(contents, key)
+	updatedContent, err := frontmatter.RenameKey(contents, oldKey, newKey)
+	if err != nil {
+		return "", err
+	}
+	if updatedContent == contents {
+		return fmt.Sprintf("Frontmatter key '%s' not found in %s", oldKey, noteName), nil
+	}
+
+	return writeFrontmatter(note, vaultPath, noteName, contents, updatedContent, dryRun,
+		fmt.Sprintf("Renamed frontmatter key '%s' to '%s' in %s", oldKey, newKey, noteName))
+}
+
+func handleConvert(note obsidian.NoteManager, vaultPath, noteName, contents, format string, dryRun bool) (string, error) {
+	if !frontmatter.HasFrontmatter(contents) {
+		return "", errors.New(frontmatter.NoFrontmatterError)
+	}
+
+	updatedContent, err := frontmatter.Convert(contents, format)
+	if err != nil {
+		return "", err
+	}
+	if updatedContent == contents {
+		return fmt.Sprintf("Frontmatter of %s is already %s", noteName, format), nil
+	}
+
+	return writeFrontmatter(note, vaultPath, noteName, contents, updatedContent, dryRun,
+		fmt.Sprintf("Converted frontmatter of %s to %s", noteName, format))
+}
+
+func handleUpdates(note obsidian.NoteManager, types frontmatter.PropertyTypes, vaultPath, contents string, params FrontmatterParams) (string, error) {
+	updated := contents
+	var keys []string
+
+	for _, item := range params.Add {
+		key, value, err := splitKeyValue("--add", item)
+		if err != nil {
+			return "", err
+		}
+		if updated, err = types.AddToList(updated, key, value); err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, item := range params.Remove {
+		key, value, err := splitKeyValue("--remove", item)
+		if err != nil {
+			return "", err
+		}
+		if updated, err = types.RemoveFromList(updated, key, value); err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range params.Toggle {
+		var err error
+		if updated, err = types.ToggleKey(updated, key); err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, item := range params.Increment {
+		key, step, found := strings.Cut(item, "=")
+		if !found {
+			step = "1"
+		}
+		var err error
+		if updated, err = types.IncrementKey(updated, key, step); err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+
+	if updated == contents {
+		return fmt.Sprintf("Frontmatter of %s is already up to date", params.NoteName), nil
+	}
+
+	return writeFrontmatter(note, vaultPath, params.NoteName, contents, updated, params.DryRun,
+		fmt.Sprintf("Updated frontmatter keys '%s' in %s", strings.Join(keys, "', '"), params.NoteName))
+}
+
+// handleCombined applies several edit operations to a single note in the
+// order of bulkEdits and writes the note once.
+func handleCombined(note obsidian.NoteManager, vaultPath, noteName, contents string, edits []frontmatterEdit, dryRun bool) (string, error) {
+	updated := contents
+	for _, edit := range edits {
+		var err error
+		if updated, err = edit(updated); err != nil {
+			return "", err
+		}
+	}
+	if updated == contents {
+		return fmt.Sprintf("Frontmatter of %s is already up to date", noteName), nil
+	}
+
+	return writeFrontmatter(note, vaultPath, noteName, contents, updated, dryRun,
+		fmt.Sprintf("Updated frontmatter of %s", noteName))
+}
+
+// writeFrontmatter writes the edited contents of a single note and returns
+// message, or with dryRun returns a unified diff of the edit and leaves the
+// note as it is.
+func writeFrontmatter(note obsidian.NoteManager, vaultPath, noteName, contents, updated string, dryRun bool, message string) (string, error) {
+	if dryRun {
+		return unifiedDiff(obsidian.AddMdSuffix(noteName), contents, updated)
+	}
+	if err := note.SetContents(vaultPath, noteName, updated); err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// splitKeyValue splits a "key=value" flag argument.
+func splitKeyValue(flag, item string) (string, string, error) {
+	key, value, found := strings.Cut(item, "=")
+	if !found || key == "" {
+		return "", "", fmt.Errorf("%s expects key=value, got %q", flag, item)
+	}
+	return key, value, nil
+}
+
+type frontmatterEdit func(string) (string, error)
+
+// bulkEdits turns the edit flags into a list of content transformations, in
+// the order edit, delete, rename, convert, add, remove, toggle, increment.
+func bulkEdits(types frontmatter.PropertyTypes, params FrontmatterParams) ([]frontmatterEdit, error) {
+	var edits []frontmatterEdit
+
+	if params.Edit {
+		if params.Key == "" {
+			return nil, errors.New("--key is required for edit operation")
+		}
+		if params.Value == "" {
+			return nil, errors.New("--value is required for edit operation")
+		}
+		edits = append(edits, func(c string) (string, error) { return types.SetKey(c, params.Key, params.Value) })
+	}
+
+	if params.Delete {
+		if params.Key == "" {
+			return nil, errors.New("--key is required for delete operation")
+		}
+		edits = append(edits, func(c string) (string, error) {
+			if !frontmatter.HasFrontmatter(c) {
+				return c, nil
+			}
+			updated, err := frontmatter.DeleteKey(c, params.Key)
+			if err != nil && err.Error() == frontmatter.NoFrontmatterError {
+				return c, nil
+			}
+			return updated, err
+		})
+	}
+
+	if params.Rename != "" {
+		oldKey, newKey, err := splitKeyValue("--rename", params.Rename)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, func(c string) (string, error) { return frontmatter.RenameKey(c, oldKey, newKey) })
+	}
+
+	if params.ConvertTo != "" {
+		if err := frontmatter.ValidateFormat(params.ConvertTo); err != nil {
+			return nil, err
+		}
+		edits = append(edits, func(c string) (string, error) { return frontmatter.Convert(c, params.ConvertTo) })
+	}
+
+	for _, item := range params.Add {
+		key, value, err := splitKeyValue("--add", item)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, func(c string) (string, error) { return types.AddToList(c, key, value) })
+	}
+
+	for _, item := range params.Remove {
+		key, value, err := splitKeyValue("--remove", item)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, func(c string) (string, error) { return types.RemoveFromList(c, key, value) })
+	}
+
+	for _, key := range params.Toggle {
+		edits = append(edits, func(c string) (string, error) { return types.ToggleKey(c, key) })
+	}
+
+	for _, item := range params.Increment {
+		key, step, found := strings.Cut(item, "=")
+		if !found {
+			step = "1"
+		}
+		edits = append(edits, func(c string) (string, error) { return types.IncrementKey(c, key, step) })
+	}
+
+	return edits, nil
+}
+
+// handleBulk applies the edits to every note matching params.Filter. Errors
+// in one note are reported and the remaining notes are still processed.
+func handleBulk(note obsidian.NoteManager, vaultPath string, types frontmatter.PropertyTypes, params FrontmatterParams) (string, error) {
+	if params.Print || params.Get != "" {
+		return "", errors.New("--print and --get cannot be combined with note filters")
+	}
+
+	edits, err := bulkEdits(types, params)
 	if err != nil {
 		return "", err
 	}
+	if len(edits) == 0 {
+		return "", errors.New(noFrontmatterOperationError)
+	}
 
-	err = note.SetContents(vaultPath, noteName, updatedContent)
+	return applyEdits(note, vaultPath, params.Filter, edits, params.DryRun, params.Output)
+}
+
+// applyEdits runs edits on every note matching filter, writing "Updated" lines
+// or, with dryRun, unified diffs to out. Notes skipped by the filter are
+// reported to filter.Warnings, or to out if it is unset. It returns a summary
+// line.
+func applyEdits(note obsidian.NoteManager, vaultPath string, filter NoteFilter, edits []frontmatterEdit, dryRun bool, out io.Writer) (string, error) {
+	if filter.Warnings == nil {
+		filter.Warnings = out
+	}
+	notes, err := SelectNotes(note, vaultPath, filter)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("Deleted frontmatter key '%s' from %s", key, noteName), nil
+	if out == nil {
+		out = io.Discard
+	}
+
+	changed, failed := 0, 0
+	for _, notePath := range notes {
+		// Edit the note again from scratch if it changes before it is written.
+		result, err := retryOnChange(func() (string, error) {
+			return editNote(note, vaultPath, notePath, edits, dryRun)
+		})
+		if err != nil {
+			fmt.Fprintf(out, "Error: %s: %v\n", notePath, err)
+			failed++
+			continue
+		}
+		if result == "" {
+			continue
+		}
+		fmt.Fprint(out, result)
+		changed++
+	}
+
+	summary := fmt.Sprintf("%d notes matched, %d changed, %d unchanged", len(notes), changed, len(notes)-changed-failed)
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	if dryRun {
+		summary += " (dry run, no files written)"
+	}
+	summary += "\n"
+
+	if failed > 0 {
+		return summary, fmt.Errorf("failed to update %d notes", failed)
+	}
+	return summary, nil
+}
+
+// editNote applies edits to one note and writes it, or with dryRun diffs it.
+// It returns the line to report for the note, or "" if it is unchanged.
+func editNote(note obsidian.NoteManager, vaultPath, notePath string, edits []frontmatterEdit, dryRun bool) (string, error) {
+	contents, err := note.GetContents(vaultPath, notePath)
+	if err != nil {
+		return "", err
+	}
+
+	updated := contents
+	for _, edit := range edits {
+		if updated, err = edit(updated); err != nil {
+			return "", err
+		}
+	}
+	if updated == contents {
+		return "", nil
+	}
+
+	if dryRun {
+		return unifiedDiff(notePath, contents, updated)
+	}
+	if err := note.SetContents(vaultPath, notePath, updated); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Updated %s\n", notePath), nil
+}
+
+func unifiedDiff(notePath, before, after string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(before),
+		B:        diffLines(after),
+		FromFile: "a/" + notePath,
+		ToFile:   "b/" + notePath,
+		Context:  3,
+	})
+}
+
+// diffLines splits content into lines that each end in a newline, without the
+// phantom empty line difflib.SplitLines adds after a trailing newline.
+func diffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
 }
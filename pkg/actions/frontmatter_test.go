@@ -2,10 +2,14 @@ package actions_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Yakitrak/notesmd-cli/mocks"
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -235,3 +239,496 @@ func TestFrontmatter_NoOperation(t *testing.T) {
 		assert.Contains(t, err.Error(), "no operation specified")
 	})
 }
+
+func TestFrontmatter_Updates(t *testing.T) {
+	t.Run("Applies list, toggle and increment operations in one write", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{
+			Contents: "---\ntags: [draft, work]\ndone: false\nreviews: 1\n---\nBody",
+		}
+
+		output, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName:  "test-note",
+			Add:       []string{"tags=meeting"},
+			Remove:    []string{"tags=draft"},
+			Toggle:    []string{"done"},
+			Increment: []string{"reviews", "stats.views=5"},
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Updated frontmatter keys 'tags', 'tags', 'done', 'reviews', 'stats.views'")
+		assert.Equal(t, "---\ntags: [work, meeting]\ndone: true\nreviews: 2\nstats:\n  views: 5\n---\nBody", note.SetContentsValue)
+	})
+
+	t.Run("Unchanged note is not written", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{
+			Contents:         "---\ntags: [work]\n---\nBody",
+			SetContentsError: errors.New("should not write"),
+		}
+
+		output, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Add:      []string{"tags=work"},
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "already up to date")
+	})
+
+	t.Run("Add requires key=value", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\ntags: [work]\n---\nBody"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Add:      []string{"tags"},
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--add expects key=value")
+	})
+
+	t.Run("Edit with dotted key sets nested value", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: Plan\n---\nBody"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Edit:     true,
+			Key:      "project.owner",
+			Value:    "ana",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntitle: Plan\nproject:\n  owner: ana\n---\nBody", note.SetContentsValue)
+	})
+}
+
+func TestFrontmatter_Rename(t *testing.T) {
+	t.Run("Rename key in a single note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{
+			Contents: "---\nstate: draft\n---\nBody",
+		}
+
+		result, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Rename:   "state=status",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Renamed frontmatter key 'state' to 'status' in test-note", result)
+		assert.Equal(t, "---\nstatus: draft\n---\nBody", note.SetContentsValue)
+	})
+
+	t.Run("Rename requires old=new", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\nstate: draft\n---\n"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Rename:   "state",
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFrontmatter_Bulk(t *testing.T) {
+	newNote := func() *mocks.MockNoteManager {
+		return &mocks.MockNoteManager{
+			NotesList: []string{"Meetings/a.md", "Meetings/b.md", "Projects/c.md"},
+			NoteContents: map[string]string{
+				"Meetings/a.md": "---\nattendees: [ana]\n---\nBody",
+				"Meetings/b.md": "No frontmatter",
+				"Projects/c.md": "---\nattendees: [bo]\n---\n",
+			},
+		}
+	}
+
+	t.Run("Applies edits to every matching note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		summary, err := actions.Frontmatter(&vault, note, actions.FrontmatterParams{
+			Rename: "attendees=people",
+			Filter: actions.NoteFilter{Folder: "Meetings"},
+			Output: &out,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2 notes matched, 1 changed, 1 unchanged\n", summary)
+		assert.Equal(t, "Updated Meetings/a.md\n", out.String())
+		assert.Equal(t, "---\npeople: [ana]\n---\nBody", note.NoteContents["Meetings/a.md"])
+		assert.Equal(t, "---\nattendees: [bo]\n---\n", note.NoteContents["Projects/c.md"])
+	})
+
+	t.Run("Dry run prints diffs without writing", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		summary, err := actions.Frontmatter(&vault, note, actions.FrontmatterParams{
+			Edit:   true,
+			Key:    "status",
+			Value:  "done",
+			Filter: actions.NoteFilter{Glob: "Projects/*.md"},
+			DryRun: true,
+			Output: &out,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1 notes matched, 1 changed, 0 unchanged (dry run, no files written)\n", summary)
+		assert.Equal(t, "--- a/Projects/c.md\n+++ b/Projects/c.md\n@@ -1,3 +1,4 @@\n ---\n attendees: [bo]\n+status: done\n ---\n", out.String())
+		assert.Equal(t, "---\nattendees: [bo]\n---\n", note.NoteContents["Projects/c.md"])
+		assert.Empty(t, note.SetContentsValue)
+	})
+
+	t.Run("Delete skips notes without frontmatter", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+
+		summary, err := actions.Frontmatter(&vault, note, actions.FrontmatterParams{
+			Delete: true,
+			Key:    "attendees",
+			Filter: actions.NoteFilter{Glob: "**/*.md"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3 notes matched, 2 changed, 1 unchanged\n", summary)
+		assert.Equal(t, "Body", note.NoteContents["Meetings/a.md"])
+	})
+
+	t.Run("Errors are reported per note and processing continues", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		note.NoteContents["Meetings/a.md"] = "---\nattendees: ana\npeople: bo\n---\n"
+		var out strings.Builder
+
+		summary, err := actions.Frontmatter(&vault, note, actions.FrontmatterParams{
+			Rename: "attendees=people",
+			Filter: actions.NoteFilter{Where: []string{"attendees"}},
+			Output: &out,
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, "2 notes matched, 1 changed, 0 unchanged, 1 failed\n", summary)
+		assert.Contains(t, out.String(), "Error: Meetings/a.md:")
+		assert.Contains(t, out.String(), "Updated Projects/c.md")
+	})
+
+	t.Run("Print cannot be combined with filters", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+
+		_, err := actions.Frontmatter(&vault, newNote(), actions.FrontmatterParams{
+			Print:  true,
+			Filter: actions.NoteFilter{Folder: "Meetings"},
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFrontmatter_DryRun(t *testing.T) {
+	original := "---\ntitle: Plan\nstate: draft\ntags: [a]\n---\nBody\n"
+
+	tests := []struct {
+		testName string
+		params   actions.FrontmatterParams
+		diff     string
+	}{
+		{testName: "Edit", params: actions.FrontmatterParams{Edit: true, Key: "n", Value: "99"}, diff: "+n: 99\n"},
+		{testName: "Delete", params: actions.FrontmatterParams{Delete: true, Key: "title"}, diff: "-title: Plan\n"},
+		{testName: "Rename", params: actions.FrontmatterParams{Rename: "state=status"}, diff: "+status: draft\n"},
+		{testName: "Updates", params: actions.FrontmatterParams{Add: []string{"tags=b"}}, diff: "+tags: [a, b]\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName+" prints a diff and leaves the note unchanged", func(t *testing.T) {
+			vaultPath := t.TempDir()
+			writeVaultFile(t, vaultPath, "plan.md", original)
+			vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+			test.params.NoteName = "plan"
+			test.params.DryRun = true
+
+			result, err := actions.Frontmatter(&vault, &obsidian.Note{}, test.params)
+
+			assert.NoError(t, err)
+			assert.Contains(t, result, "--- a/plan.md\n+++ b/plan.md\n")
+			assert.Contains(t, result, test.diff)
+			data, err := os.ReadFile(filepath.Join(vaultPath, "plan.md"))
+			assert.NoError(t, err)
+			assert.Equal(t, original, string(data))
+		})
+	}
+}
+
+func TestFrontmatter_Combined(t *testing.T) {
+	t.Run("Applies every operation to a single note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: Plan\ntags: [a]\n---\nBody"}
+
+		result, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "plan",
+			Edit:     true,
+			Key:      "state",
+			Value:    "done",
+			Add:      []string{"tags=b"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Updated frontmatter of plan", result)
+		assert.Equal(t, "---\ntitle: Plan\ntags: [a, b]\nstate: done\n---\nBody", note.SetContentsValue)
+	})
+
+	t.Run("Print cannot be combined with edits", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: Plan\n---\nBody"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "plan",
+			Print:    true,
+			Toggle:   []string{"done"},
+		})
+
+		assert.EqualError(t, err, "--print and --get cannot be combined with edit operations")
+		assert.Empty(t, note.SetContentsValue)
+	})
+}
+
+func TestFrontmatter_Get(t *testing.T) {
+	contents := "---\nstatus: done\ndue: 2026-10-01\nproject:\n  owner: ana\ntags:\n  - a\n  - b\n---\nBody\n---\nnot frontmatter: true\n"
+
+	tests := []struct {
+		testName string
+		params   actions.FrontmatterParams
+		want     string
+	}{
+		{testName: "Scalar value", params: actions.FrontmatterParams{Get: "status"}, want: "done\n"},
+		{testName: "Nested value", params: actions.FrontmatterParams{Get: "project.owner"}, want: "ana\n"},
+		{testName: "List as YAML", params: actions.FrontmatterParams{Get: "tags"}, want: "- a\n- b\n"},
+		{testName: "List as JSON", params: actions.FrontmatterParams{Get: "tags", Format: "json"}, want: "[\n  \"a\",\n  \"b\"\n]\n"},
+		{testName: "Date as JSON", params: actions.FrontmatterParams{Get: "due", Format: "json"}, want: "\"2026-10-01\"\n"},
+		{testName: "Missing key with default", params: actions.FrontmatterParams{Get: "priority", Default: "low", HasDefault: true}, want: "low\n"},
+		{testName: "Missing key with empty default", params: actions.FrontmatterParams{Get: "priority", HasDefault: true}, want: "\n"},
+		{testName: "Missing key with string default as JSON", params: actions.FrontmatterParams{Get: "priority", Default: "low", HasDefault: true, Format: "json"}, want: "\"low\"\n"},
+		{testName: "Missing key with number default as JSON", params: actions.FrontmatterParams{Get: "priority", Default: "3", HasDefault: true, Format: "json"}, want: "3\n"},
+		{testName: "Missing key with empty default as JSON", params: actions.FrontmatterParams{Get: "priority", HasDefault: true, Format: "json"}, want: "\"\"\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			vault := mocks.MockVaultOperator{Name: "myVault"}
+			note := mocks.MockNoteManager{Contents: contents}
+			test.params.NoteName = "test-note"
+
+			result, err := actions.Frontmatter(&vault, &note, test.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, result)
+		})
+	}
+
+	t.Run("Missing key is an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: contents}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{NoteName: "test-note", Get: "priority"})
+
+		assert.EqualError(t, err, "frontmatter key 'priority' not found in test-note")
+	})
+
+	t.Run("Invalid format is an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: contents}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{NoteName: "test-note", Print: true, Format: "xml"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFrontmatter_PrintJSON(t *testing.T) {
+	vault := mocks.MockVaultOperator{Name: "myVault"}
+	note := mocks.MockNoteManager{Contents: "---\ntitle: Test\ncount: 2\n---\nBody"}
+
+	result, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+		NoteName: "test-note",
+		Print:    true,
+		Format:   "json",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"title\": \"Test\",\n  \"count\": 2\n}\n", result)
+}
+
+func TestFrontmatter_PropertyTypes(t *testing.T) {
+	vaultPath := t.TempDir()
+	writeVaultFile(t, vaultPath, ".obsidian/types.json", `{"types": {"priority": "number", "tags": "tags"}}`)
+
+	t.Run("Values are coerced to the declared type", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: Test\n---\n"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Edit:     true,
+			Key:      "tags",
+			Value:    "meeting",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntitle: Test\ntags:\n  - meeting\n---\n", note.SetContentsValue)
+	})
+
+	t.Run("Values that cannot be coerced are rejected", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: Test\n---\n"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "test-note",
+			Edit:     true,
+			Key:      "priority",
+			Value:    "high",
+		})
+
+		assert.EqualError(t, err, `cannot set priority to "high": property is declared as number in .obsidian/types.json`)
+		assert.Empty(t, note.SetContentsValue)
+	})
+}
+
+func TestFrontmatter_Convert(t *testing.T) {
+	t.Run("Converts TOML frontmatter to YAML", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "+++\ntitle = \"Hello\"\n+++\nBody"}
+
+		result, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName:  "post",
+			ConvertTo: "yaml",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Converted frontmatter of post to yaml", result)
+		assert.Equal(t, "---\ntitle: Hello\n---\nBody", note.SetContentsValue)
+	})
+
+	t.Run("Dry run prints a diff and leaves the note unchanged", func(t *testing.T) {
+		original := "+++\ntitle = \"Hello\"\n+++\nBody\n"
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, "post.md", original)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		result, err := actions.Frontmatter(&vault, &obsidian.Note{}, actions.FrontmatterParams{
+			NoteName:  "post",
+			ConvertTo: "yaml",
+			DryRun:    true,
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "--- a/post.md\n+++ b/post.md\n")
+		assert.Contains(t, result, "+title: Hello\n")
+		data, err := os.ReadFile(filepath.Join(vaultPath, "post.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, original, string(data))
+	})
+
+	t.Run("Note without frontmatter is an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "Body"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName:  "post",
+			ConvertTo: "yaml",
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Edits keep the TOML format", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "+++\ntitle = \"Hello\"\n+++\nBody"}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{
+			NoteName: "post",
+			Edit:     true,
+			Key:      "draft",
+			Value:    "true",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+++\ntitle = \"Hello\"\ndraft = true\n+++\nBody", note.SetContentsValue)
+	})
+
+	t.Run("Property filters read TOML frontmatter", func(t *testing.T) {
+		note := mocks.MockNoteManager{
+			NotesList: []string{"a.md", "b.md"},
+			NoteContents: map[string]string{
+				"a.md": "+++\ndraft = true\n+++\n",
+				"b.md": ";;;\n{\"draft\": false}\n;;;\n",
+			},
+		}
+
+		notes, err := actions.SelectNotes(&note, "/vault", actions.NoteFilter{Where: []string{"draft=true"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, notes)
+	})
+}
+
+// changingNoteManager simulates another program editing the note between a
+// read and the following write, a given number of times. The note is replaced
+// with edited, or a frontmatter-only note if it is empty.
+type changingNoteManager struct {
+	mocks.MockNoteManager
+	changes int
+	reads   int
+	edited  string
+}
+
+func (m *changingNoteManager) GetContents(vaultPath, noteName string) (string, error) {
+	m.reads++
+	return m.MockNoteManager.GetContents(vaultPath, noteName)
+}
+
+func (m *changingNoteManager) SetContents(vaultPath, noteName, content string) error {
+	if m.changes > 0 {
+		m.changes--
+		m.NoteContents[noteName] = m.edited
+		if m.edited == "" {
+			m.NoteContents[noteName] = "---\ntitle: edited elsewhere\n---\n"
+		}
+		return errors.New(obsidian.NoteChangedError)
+	}
+	return m.MockNoteManager.SetContents(vaultPath, noteName, content)
+}
+
+func TestFrontmatter_LostUpdates(t *testing.T) {
+	t.Run("Edits the note again after it changed", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := changingNoteManager{
+			MockNoteManager: mocks.MockNoteManager{NoteContents: map[string]string{"note": "---\ntitle: old\n---\n"}},
+			changes:         1,
+		}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{NoteName: "note", Edit: true, Key: "status", Value: "done"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, note.reads)
+		assert.Equal(t, "---\ntitle: edited elsewhere\nstatus: done\n---\n", note.NoteContents["note"])
+	})
+
+	t.Run("Gives up when the note keeps changing", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := changingNoteManager{
+			MockNoteManager: mocks.MockNoteManager{NoteContents: map[string]string{"note": "---\ntitle: old\n---\n"}},
+			changes:         obsidian.WriteAttempts,
+		}
+
+		_, err := actions.Frontmatter(&vault, &note, actions.FrontmatterParams{NoteName: "note", Edit: true, Key: "status", Value: "done"})
+
+		assert.EqualError(t, err, obsidian.NoteChangedError)
+		assert.Equal(t, obsidian.WriteAttempts, note.reads)
+	})
+}
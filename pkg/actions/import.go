@@ -0,0 +1,346 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"gopkg.in/yaml.v3"
+)
+
+type ImportHTMLParams struct {
+	Files     []string // HTML files to import
+	Folder    string   // vault folder for the notes; the default note folder if empty
+	Overwrite bool     // replace notes that already exist
+	Output    io.Writer
+}
+
+// ImportHTML converts local HTML files to notes. Each note is named after the
+// page title and gets source, title and imported properties. Local images,
+// including data: URIs, are copied into the attachment folder and embedded;
+// remote images stay links, so nothing is downloaded. Links between the
+// imported files become wikilinks. Files that fail are reported and the
+// others are still imported.
+func ImportHTML(vault obsidian.VaultManager, params ImportHTMLParams) error {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	policy, err := obsidian.LoadNamePolicy(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	folder := strings.Trim(filepath.ToSlash(params.Folder), "/")
+	if folder == "" {
+		if folder = path.Dir(obsidian.ApplyDefaultFolder("note", vaultPath)); folder == "." {
+			folder = ""
+		}
+	}
+
+	// Name every note first so that links between the files can be resolved.
+	// Pages with the same title are numbered ("Title 1") as attachments are.
+	type page struct {
+		file, source, name string
+	}
+	var pages []*page
+	names := map[string]string{}
+	taken := map[string]bool{}
+	failed := 0
+	for _, file := range params.Files {
+		source, err := filepath.Abs(file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(source)
+		if err != nil {
+			fmt.Fprintf(params.Output, "%s: %v\n", file, err)
+			failed++
+			continue
+		}
+		doc := markdown.FromHTML(string(data), markdown.HTMLOptions{})
+		title := doc.Title
+		if title == "" {
+			title = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+		}
+		stem := path.Join(folder, policy.Sanitize(title))
+		name := stem
+		for i := 1; taken[strings.ToLower(name)]; i++ {
+			name = fmt.Sprintf("%s %d", stem, i)
+		}
+		taken[strings.ToLower(name)] = true
+		pages = append(pages, &page{file: file, source: source, name: name})
+		names[source] = name
+	}
+
+	imported := time.Now().Format("2006-01-02")
+	for _, p := range pages {
+		notePath, images, err := importPage(vaultPath, policy, p.source, p.name, names, imported, params.Overwrite, params.Output)
+		if err != nil {
+			fmt.Fprintf(params.Output, "%s: %v\n", p.file, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(params.Output, "Imported %s to %s", p.file, notePath)
+		if images == 1 {
+			fmt.Fprint(params.Output, " (1 image)")
+		} else if images > 1 {
+			fmt.Fprintf(params.Output, " (%d images)", images)
+		}
+		fmt.Fprintln(params.Output)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files could not be imported", failed, len(params.Files))
+	}
+	return nil
+}
+
+// importPage converts the HTML file at source and writes it as the note
+// noteName. It returns the note's vault-relative path and the number of
+// images copied.
+func importPage(vaultPath string, policy obsidian.NamePolicy, source, noteName string, names map[string]string, imported string, overwrite bool, warnings io.Writer) (string, int, error) {
+	relPath := obsidian.AddMdSuffix(noteName)
+	notePath, err := obsidian.ValidatePath(vaultPath, relPath)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := os.Stat(notePath); err == nil && !overwrite {
+		return "", 0, fmt.Errorf("%s already exists (use --overwrite to replace it)", relPath)
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", 0, err
+	}
+
+	attachments := attachmentCopier{
+		vaultPath: vaultPath,
+		folder:    obsidian.AttachmentFolder(vaultPath, path.Dir(relPath)),
+		policy:    policy,
+		noteName:  path.Base(noteName),
+		copied:    map[string]string{},
+	}
+	baseDir := filepath.Dir(source)
+	doc := markdown.FromHTML(string(data), markdown.HTMLOptions{
+		Image: func(src, alt string) string {
+			embed, err := attachments.embed(baseDir, src, alt)
+			if err != nil {
+				fmt.Fprintf(warnings, "warning: %s: image %s: %v\n", filepath.Base(source), src, err)
+			}
+			return embed
+		},
+		Link: func(href, text string) string {
+			if file, ok := localFile(baseDir, href); ok {
+				if name, ok := names[file]; ok {
+					if text == path.Base(name) {
+						return "[[" + name + "]]"
+					}
+					return "[[" + name + "|" + text + "]]"
+				}
+			}
+			return ""
+		},
+	})
+
+	if doc.Source == "" {
+		doc.Source = source
+	}
+	title := doc.Title
+	if title == "" {
+		title = path.Base(noteName)
+	}
+	properties, err := frontmatter.ParseDocument("")
+	if err != nil {
+		return "", 0, err
+	}
+	for _, property := range []struct {
+		key, value, tag string
+	}{
+		{"source", doc.Source, "!!str"},
+		{"title", title, "!!str"},
+		{"imported", imported, "!!timestamp"},
+	} {
+		if err := properties.Set([]string{property.key}, &yaml.Node{Kind: yaml.ScalarNode, Tag: property.tag, Value: property.value}); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
+		return "", 0, err
+	}
+	content := properties.String() + doc.Markdown
+	if err := obsidian.WriteFileAtomic(notePath, []byte(content), 0644); err != nil {
+		return "", 0, err
+	}
+	return relPath, len(attachments.copied), nil
+}
+
+// localFile resolves a relative or file: URL against dir. It reports false for
+// other URLs.
+func localFile(dir, ref string) (string, bool) {
+	u, err := url.Parse(ref)
+	if err != nil || u.Host != "" || (u.Scheme != "" && u.Scheme != "file") || u.Path == "" {
+		return "", false
+	}
+	if u.Scheme == "file" || filepath.IsAbs(filepath.FromSlash(u.Path)) {
+		return filepath.Clean(filepath.FromSlash(u.Path)), true
+	}
+	return filepath.Join(dir, filepath.FromSlash(u.Path)), true
+}
+
+// attachmentCopier copies the images of an imported page into the vault.
+type attachmentCopier struct {
+	vaultPath string
+	folder    string // vault-relative attachment folder
+	policy    obsidian.NamePolicy
+	noteName  string            // names images from data: URIs
+	copied    map[string]string // image reference to embed
+}
+
+// embed copies the image src and returns an embed for it. Remote images are
+// not copied and return "".
+func (a *attachmentCopier) embed(dir, src, alt string) (string, error) {
+	if embed, ok := a.copied[src]; ok {
+		return embed, nil
+	}
+
+	var data []byte
+	var name string
+	if strings.HasPrefix(src, "data:") {
+		var ext string
+		var err error
+		data, ext, err = decodeDataURI(src)
+		if err != nil {
+			return "", err
+		}
+		name = fmt.Sprintf("%s %d%s", a.noteName, len(a.copied)+1, ext)
+	} else {
+		file, ok := localFile(dir, src)
+		if !ok {
+			return "", nil
+		}
+		var err error
+		if data, err = os.ReadFile(file); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", errors.New("not found")
+			}
+			return "", err
+		}
+		name = filepath.Base(file)
+	}
+
+	ext := path.Ext(name)
+	name = a.policy.Sanitize(strings.TrimSuffix(name, ext)) + ext
+	relPath, err := a.write(name, data)
+	if err != nil {
+		return "", err
+	}
+
+	embed := "![[" + relPath
+	if alt = strings.NewReplacer("|", " ", "[", "", "]", "").Replace(alt); alt != "" && !isImageSize(alt) {
+		embed += "|" + alt
+	}
+	embed += "]]"
+	a.copied[src] = embed
+	return embed, nil
+}
+
+// write saves data as name in the attachment folder, reusing an identical
+// file and numbering the name ("pic 1.png") if another file has it. It
+// returns the vault-relative path.
+func (a *attachmentCopier) write(name string, data []byte) (string, error) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 0; ; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s %d%s", stem, i, ext)
+		}
+		relPath := path.Join(a.folder, candidate)
+		target, err := obsidian.ValidatePath(a.vaultPath, relPath)
+		if err != nil {
+			return "", err
+		}
+		existing, err := os.ReadFile(target)
+		if err == nil {
+			if bytes.Equal(existing, data) {
+				return relPath, nil
+			}
+			continue
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", err
+		}
+		return relPath, obsidian.WriteFileAtomic(target, data, 0644)
+	}
+}
+
+// decodeDataURI decodes a data: URI and returns its contents with a file
+// extension for its media type.
+func decodeDataURI(uri string) ([]byte, string, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, "", errors.New("invalid data URI")
+	}
+	mediaType, _, _ := strings.Cut(header, ";")
+	ext := ".bin"
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		ext = exts[0]
+	}
+	switch mediaType {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/svg+xml":
+		ext = ".svg"
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(payload), ""))
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid data URI: %w", err)
+		}
+		return data, ext, nil
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid data URI: %w", err)
+	}
+	return []byte(data), ext, nil
+}
+
+// isImageSize reports whether text would be read as an embed size ("200" or
+// "200x100") instead of alt text.
+func isImageSize(text string) bool {
+	width, height, _ := strings.Cut(text, "x")
+	if _, err := strconv.Atoi(width); err != nil {
+		return false
+	}
+	if height == "" {
+		return !strings.Contains(text, "x")
+	}
+	_, err := strconv.Atoi(height)
+	return err == nil
+}
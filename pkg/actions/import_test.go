@@ -0,0 +1,142 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportHTML(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+
+	t.Run("Imports a page with frontmatter and copied images", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, srcDir, "post.html", `<!-- saved from url=(0024)https://example.com/post -->
+<html><head><title>Post: part 1</title></head><body>
+<h1>Post</h1><p>See <img src="images/pic%20one.png" alt="A pic"> and <img src="https://example.com/remote.png" alt="remote"></p>
+</body></html>`)
+		writeVaultFile(t, srcDir, "images/pic one.png", "PNG")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:  []string{filepath.Join(srcDir, "post.html")},
+			Folder: "Clippings/",
+			Output: &out,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Imported "+filepath.Join(srcDir, "post.html")+" to Clippings/Post part 1.md (1 image)\n", out.String())
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "Clippings", "Post part 1.md"))
+		assert.Equal(t, "---\nsource: https://example.com/post\ntitle: 'Post: part 1'\nimported: "+today+"\n---\n"+
+			"# Post\n\nSee ![[pic one.png|A pic]] and ![remote](https://example.com/remote.png)\n", string(content))
+		image, _ := os.ReadFile(filepath.Join(vaultPath, "pic one.png"))
+		assert.Equal(t, "PNG", string(image))
+	})
+
+	t.Run("Uses the attachment folder and keeps existing attachments", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/app.json", `{"attachmentFolderPath": "./assets"}`)
+		writeVaultFile(t, vaultPath, "Clippings/assets/pic.png", "other image")
+		writeVaultFile(t, srcDir, "page.html", `<p><img src="pic.png"><img src="data:image/png;base64,UE5H"></p>`)
+		writeVaultFile(t, srcDir, "pic.png", "PNG")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:  []string{filepath.Join(srcDir, "page.html")},
+			Folder: "Clippings",
+			Output: &strings.Builder{},
+		})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "Clippings", "page.md"))
+		assert.Contains(t, string(content), "![[Clippings/assets/pic 1.png]]![[Clippings/assets/page 2.png]]\n")
+		assert.Contains(t, string(content), "source: "+filepath.Join(srcDir, "page.html")+"\ntitle: page\n")
+		assert.FileExists(t, filepath.Join(vaultPath, "Clippings", "assets", "page 2.png"))
+		existing, _ := os.ReadFile(filepath.Join(vaultPath, "Clippings", "assets", "pic.png"))
+		assert.Equal(t, "other image", string(existing))
+	})
+
+	t.Run("Links between imported pages become wikilinks", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, srcDir, "a.html", `<title>Alpha</title><p>See <a href="b.html#part">the other page</a> and <a href="c.html">c</a>.</p>`)
+		writeVaultFile(t, srcDir, "b.html", `<title>Beta</title><p>Back to <a href="a.html">Alpha</a></p>`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:  []string{filepath.Join(srcDir, "a.html"), filepath.Join(srcDir, "b.html")},
+			Output: &strings.Builder{},
+		})
+
+		assert.NoError(t, err)
+		a, _ := os.ReadFile(filepath.Join(vaultPath, "Alpha.md"))
+		assert.Contains(t, string(a), "See [[Beta|the other page]] and [c](c.html).\n")
+		b, _ := os.ReadFile(filepath.Join(vaultPath, "Beta.md"))
+		assert.Contains(t, string(b), "Back to [[Alpha]]\n")
+	})
+
+	t.Run("Numbers pages with the same title", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, srcDir, "one.html", `<title>Notes</title><p>first, see <a href="two.html">two</a></p>`)
+		writeVaultFile(t, srcDir, "two.html", `<title>notes</title><p>second</p>`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:  []string{filepath.Join(srcDir, "one.html"), filepath.Join(srcDir, "two.html")},
+			Output: &out,
+		})
+
+		assert.NoError(t, err)
+		first, _ := os.ReadFile(filepath.Join(vaultPath, "Notes.md"))
+		assert.Contains(t, string(first), "first, see [[notes 1|two]]\n")
+		second, _ := os.ReadFile(filepath.Join(vaultPath, "notes 1.md"))
+		assert.Contains(t, string(second), "second\n")
+	})
+
+	t.Run("Reports failures and imports the other files", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, "Existing.md", "keep")
+		writeVaultFile(t, srcDir, "existing.html", `<title>Existing</title><p>new</p>`)
+		writeVaultFile(t, srcDir, "new.html", `<title>New</title><p><img src="missing.png"></p>`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:  []string{filepath.Join(srcDir, "existing.html"), filepath.Join(srcDir, "missing.html"), filepath.Join(srcDir, "new.html")},
+			Output: &out,
+		})
+
+		assert.EqualError(t, err, "2 of 3 files could not be imported")
+		assert.Contains(t, out.String(), "existing.html: Existing.md already exists (use --overwrite to replace it)\n")
+		assert.Contains(t, out.String(), "missing.html: open ")
+		assert.Contains(t, out.String(), "warning: new.html: image missing.png: not found\n")
+		existing, _ := os.ReadFile(filepath.Join(vaultPath, "Existing.md"))
+		assert.Equal(t, "keep", string(existing))
+		created, _ := os.ReadFile(filepath.Join(vaultPath, "New.md"))
+		assert.Contains(t, string(created), "![](missing.png)\n")
+	})
+
+	t.Run("Overwrites existing notes when asked", func(t *testing.T) {
+		vaultPath, srcDir := t.TempDir(), t.TempDir()
+		writeVaultFile(t, vaultPath, "Existing.md", "keep")
+		writeVaultFile(t, srcDir, "existing.html", `<title>Existing</title><p>new</p>`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.ImportHTML(&vault, actions.ImportHTMLParams{
+			Files:     []string{filepath.Join(srcDir, "existing.html")},
+			Overwrite: true,
+			Output:    &strings.Builder{},
+		})
+
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "Existing.md"))
+		assert.Contains(t, string(content), "\nnew\n")
+	})
+}
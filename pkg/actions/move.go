@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"io"
+
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 )
 
@@ -9,6 +11,10 @@ type MoveParams struct {
 	NewNoteName     string
 	ShouldOpen      bool
 	UseEditor       bool
+	// Sanitize replaces characters that are unsafe in the new name instead
+	// of failing (see obsidian.NamePolicy).
+	Sanitize bool
+	Output   io.Writer // receives the "Moved note" message; nil prints nothing
 }
 
 func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params MoveParams) error {
@@ -21,6 +27,11 @@ func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidi
 		return err
 	}
 
+	params.NewNoteName, err = checkNoteName(vaultPath, params.NewNoteName, params.Sanitize)
+	if err != nil {
+		return err
+	}
+
 	// Validate paths stay within vault directory
 	currentPath, err := obsidian.ValidatePath(vaultPath, params.CurrentNoteName)
 	if err != nil {
@@ -31,7 +42,7 @@ func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidi
 		return err
 	}
 
-	err = note.Move(currentPath, newPath)
+	err = note.Move(currentPath, newPath, params.Output)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,169 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+const (
+	lintNamesFormatText = "text"
+	lintNamesFormatJSON = "json"
+)
+
+type LintNamesParams struct {
+	Folder string // only check notes in this folder
+	Fix    bool   // rename notes to their sanitized names and update links
+	DryRun bool   // with Fix, report the renames without making them
+	Format string // text or json
+	Output io.Writer
+}
+
+// NameIssue is a note whose file name is unsafe.
+type NameIssue struct {
+	Path     string   `json:"path"`
+	Problems []string `json:"problems"`
+	Rename   string   `json:"rename"` // the sanitized path
+	Renamed  bool     `json:"renamed"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// checkNoteName checks the file name of noteName against the vault's name
+// policy (see obsidian.NamePolicy). With sanitize, the unsafe characters are
+// replaced instead and the fixed name returned.
+func checkNoteName(vaultPath, noteName string, sanitize bool) (string, error) {
+	policy, err := obsidian.LoadNamePolicy(vaultPath)
+	if err != nil {
+		return "", err
+	}
+
+	dir, file := path.Split(noteName)
+	base := obsidian.RemoveMdSuffix(file)
+	problems := policy.Problems(base)
+	if len(problems) == 0 {
+		return noteName, nil
+	}
+	if sanitize {
+		return dir + policy.Sanitize(base) + file[len(base):], nil
+	}
+	return "", fmt.Errorf("note name %q %s (use --sanitize to replace unsafe characters)", base, strings.Join(problems, "; "))
+}
+
+// LintNames reports notes whose file names break links or are not allowed on
+// Windows, and with params.Fix renames them to sanitized names, updating links
+// to them. It fails if any unsafe name is left.
+func LintNames(vault obsidian.VaultManager, note obsidian.NoteManager, params LintNamesParams) error {
+	format := params.Format
+	if format == "" {
+		format = lintNamesFormatText
+	}
+	if format != lintNamesFormatText && format != lintNamesFormatJSON {
+		return fmt.Errorf("invalid format '%s': expected text or json", format)
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	policy, err := obsidian.LoadNamePolicy(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	notes, err := SelectNotes(note, vaultPath, NoteFilter{Folder: params.Folder})
+	if err != nil {
+		return err
+	}
+
+	issues := []NameIssue{}
+	targets := map[string]bool{}
+	for _, notePath := range notes {
+		dir, file := path.Split(filepath.ToSlash(notePath))
+		base := obsidian.RemoveMdSuffix(file)
+		problems := policy.Problems(base)
+		if len(problems) == 0 {
+			continue
+		}
+
+		issue := NameIssue{Path: filepath.ToSlash(notePath), Problems: problems, Rename: dir + policy.Sanitize(base) + file[len(base):]}
+		if params.Fix {
+			if err := renameNote(note, vaultPath, issue.Path, issue.Rename, targets, params.DryRun); err != nil {
+				issue.Error = err.Error()
+			} else {
+				issue.Renamed = !params.DryRun
+			}
+		}
+		issues = append(issues, issue)
+	}
+
+	out := params.Output
+	if format == lintNamesFormatJSON {
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		for _, issue := range issues {
+			fmt.Fprintf(out, "%s: %s\n", issue.Path, strings.Join(issue.Problems, "; "))
+			switch {
+			case issue.Error != "":
+				fmt.Fprintf(out, "  not renamed: %s\n", issue.Error)
+			case issue.Renamed:
+				fmt.Fprintf(out, "  renamed to %s\n", issue.Rename)
+			default:
+				fmt.Fprintf(out, "  rename to %s\n", issue.Rename)
+			}
+		}
+		fmt.Fprintf(out, "Checked %d notes, %d with unsafe names\n", len(notes), len(issues))
+	}
+
+	left := 0
+	for _, issue := range issues {
+		if !issue.Renamed {
+			left++
+		}
+	}
+	if left > 0 && !params.DryRun {
+		return fmt.Errorf("%d notes have unsafe names", left)
+	}
+	return nil
+}
+
+// renameNote moves the note at oldPath to newPath, both vault-relative, and
+// updates links to it. targets collects the new paths already claimed. The
+// move is not printed; the result is reported through LintNames' output.
+func renameNote(note obsidian.NoteManager, vaultPath, oldPath, newPath string, targets map[string]bool, dryRun bool) error {
+	newFile, err := obsidian.ValidatePath(vaultPath, newPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(newFile); err == nil || targets[newPath] {
+		return fmt.Errorf("%s already exists", newPath)
+	}
+	targets[newPath] = true
+	if dryRun {
+		return nil
+	}
+
+	oldFile, err := obsidian.ValidatePath(vaultPath, oldPath)
+	if err != nil {
+		return err
+	}
+	if err := note.Move(oldFile, newFile, nil); err != nil {
+		return err
+	}
+	return note.UpdateLinks(vaultPath, obsidian.RemoveMdSuffix(oldPath), obsidian.RemoveMdSuffix(newPath))
+}
@@ -0,0 +1,176 @@
+package actions_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintNames(t *testing.T) {
+	newVault := func(t *testing.T) string {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, "Meeting: ACME.md", "# ACME\n")
+		writeVaultFile(t, vaultPath, "Projects/C#.md", "")
+		writeVaultFile(t, vaultPath, "Projects/Plan.md", "see [[Meeting: ACME]] and [[Projects/C#|C sharp]]\n")
+		return vaultPath
+	}
+
+	t.Run("Reports unsafe names and fails", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Output: &out})
+
+		assert.EqualError(t, err, "2 notes have unsafe names")
+		assert.Equal(t, `Meeting: ACME.md: contains ":", which Windows does not allow
+  rename to Meeting ACME.md
+Projects/C#.md: contains "#", which breaks links
+  rename to Projects/C.md
+Checked 3 notes, 2 with unsafe names
+`, out.String())
+		assert.FileExists(t, filepath.Join(vaultPath, "Meeting: ACME.md"))
+	})
+
+	t.Run("Limits the check to a folder", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Folder: "Projects", Format: "json", Output: &out})
+
+		assert.EqualError(t, err, "1 notes have unsafe names")
+		assert.JSONEq(t, `[{"path": "Projects/C#.md", "problems": ["contains \"#\", which breaks links"], "rename": "Projects/C.md", "renamed": false}]`, out.String())
+	})
+
+	t.Run("Fixes names and updates links", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Fix: true, Output: &out})
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "  renamed to Meeting ACME.md\n")
+		assert.FileExists(t, filepath.Join(vaultPath, "Meeting ACME.md"))
+		assert.NoFileExists(t, filepath.Join(vaultPath, "Projects", "C#.md"))
+		plan, _ := os.ReadFile(filepath.Join(vaultPath, "Projects", "Plan.md"))
+		assert.Equal(t, "see [[Meeting ACME]] and [[Projects/C|C sharp]]\n", string(plan))
+	})
+
+	t.Run("Fixes names with parseable JSON output", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Fix: true, Format: "json", Output: &out})
+
+		assert.NoError(t, err)
+		var issues []actions.NameIssue
+		assert.NoError(t, json.Unmarshal([]byte(out.String()), &issues), out.String())
+		assert.Len(t, issues, 2)
+		for _, issue := range issues {
+			assert.True(t, issue.Renamed, issue.Path)
+			assert.FileExists(t, filepath.Join(vaultPath, filepath.FromSlash(issue.Rename)))
+		}
+	})
+
+	t.Run("Dry run reports renames without making them", func(t *testing.T) {
+		vaultPath := newVault(t)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Fix: true, DryRun: true, Output: &out})
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "  rename to Projects/C.md\n")
+		assert.FileExists(t, filepath.Join(vaultPath, "Projects", "C#.md"))
+	})
+
+	t.Run("Does not rename onto an existing note", func(t *testing.T) {
+		vaultPath := newVault(t)
+		writeVaultFile(t, vaultPath, "Projects/C.md", "")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Fix: true, Output: &out})
+
+		assert.EqualError(t, err, "1 notes have unsafe names")
+		assert.Contains(t, out.String(), "  not renamed: Projects/C.md already exists\n")
+		assert.FileExists(t, filepath.Join(vaultPath, "Projects", "C#.md"))
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+		err := actions.LintNames(&vault, &obsidian.Note{}, actions.LintNamesParams{Format: "xml", Output: &strings.Builder{}})
+		assert.EqualError(t, err, "invalid format 'xml': expected text or json")
+	})
+}
+
+func TestNoteNames(t *testing.T) {
+	t.Run("Create rejects unsafe names", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "Ideas/a|b"})
+
+		assert.EqualError(t, err, `note name "a|b" contains "|", which breaks links (use --sanitize to replace unsafe characters)`)
+		assert.NoFileExists(t, filepath.Join(vaultPath, "Ideas", "a|b.md"))
+	})
+
+	t.Run("Create sanitizes names", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "Ideas/What? #1", Sanitize: true})
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(vaultPath, "Ideas", "What 1.md"))
+	})
+
+	t.Run("Create uses the vault's name policy", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".notesmd/names.yaml", "replace:\n  \":\": \" -\"\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{NoteName: "Meeting: ACME", Sanitize: true})
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(vaultPath, "Meeting - ACME.md"))
+	})
+
+	t.Run("Move rejects and sanitizes unsafe names", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		err := actions.MoveNote(&vault, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
+			CurrentNoteName: "draft",
+			NewNoteName:     "CON",
+		})
+		assert.EqualError(t, err, `note name "CON" is a reserved name on Windows (use --sanitize to replace unsafe characters)`)
+
+		err = actions.MoveNote(&vault, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
+			CurrentNoteName: "draft",
+			NewNoteName:     "CON",
+			Sanitize:        true,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Daily rejects formats that make unsafe names", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".obsidian/daily-notes.json", `{"format": "YYYY-MM-DD HH:mm"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{Date: "2026-10-19"})
+
+		assert.ErrorContains(t, err, `contains ":", which Windows does not allow: change the note format`)
+	})
+}
@@ -0,0 +1,170 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"gopkg.in/yaml.v3"
+)
+
+// NoteFilter selects notes in a vault. All set criteria must match.
+type NoteFilter struct {
+	Glob   string   // path glob relative to the vault, "**" matches any folders
+	Folder string   // folder the note must be in, at any depth
+	Tag    string   // frontmatter or inline tag; "project" also matches "project/alpha"
+	Where  []string // property filters: "key=value" or "key" (key exists)
+	Paths  []string // explicit note paths, e.g. read from stdin
+
+	// Warnings receives a line for each note skipped because its frontmatter
+	// cannot be parsed while matching Tag or Where.
+	Warnings io.Writer
+}
+
+// IsSet reports whether any criterion is set.
+func (f NoteFilter) IsSet() bool {
+	return f.Glob != "" || f.Folder != "" || f.Tag != "" || len(f.Where) > 0 || len(f.Paths) > 0
+}
+
+// SelectNotes returns the vault-relative paths of the notes matching filter.
+// With explicit Paths only those notes are considered; otherwise every note in
+// the vault is. Notes with invalid frontmatter never match Tag or Where.
+func SelectNotes(note obsidian.NoteManager, vaultPath string, filter NoteFilter) ([]string, error) {
+	var candidates []string
+	if len(filter.Paths) > 0 {
+		for _, given := range filter.Paths {
+			given = strings.TrimSpace(given)
+			if given == "" {
+				continue
+			}
+			notePath := given
+			if filepath.IsAbs(notePath) {
+				rel, err := filepath.Rel(vaultPath, notePath)
+				if err != nil {
+					return nil, fmt.Errorf("%s is not inside the vault", given)
+				}
+				notePath = rel
+			}
+			// Match --folder and --glob against "Notes/a.md", not "./Notes/a.md".
+			notePath = path.Clean(filepath.ToSlash(notePath))
+			if notePath == ".." || strings.HasPrefix(notePath, "../") {
+				return nil, fmt.Errorf("%s is not inside the vault", given)
+			}
+			candidates = append(candidates, obsidian.AddMdSuffix(notePath))
+		}
+	} else {
+		notes, err := note.GetNotesList(vaultPath)
+		if err != nil {
+			return nil, err
+		}
+		candidates = notes
+	}
+
+	warnings := filter.Warnings
+	if warnings == nil {
+		warnings = io.Discard
+	}
+
+	folder := strings.Trim(filepath.ToSlash(filter.Folder), "/")
+	var selected []string
+	for _, candidate := range candidates {
+		relPath := filepath.ToSlash(candidate)
+		if filter.Glob != "" && !obsidian.MatchGlob(filter.Glob, relPath) {
+			continue
+		}
+		if folder != "" && !strings.HasPrefix(relPath, folder+"/") {
+			continue
+		}
+		if filter.Tag != "" || len(filter.Where) > 0 {
+			contents, err := note.GetContents(vaultPath, candidate)
+			if err != nil {
+				return nil, err
+			}
+			matched, err := matchContents(contents, filter)
+			if err != nil {
+				fmt.Fprintf(warnings, "warning: skipping %s: %v\n", candidate, err)
+				continue
+			}
+			if !matched {
+				continue
+			}
+		}
+		selected = append(selected, candidate)
+	}
+	return selected, nil
+}
+
+func matchContents(contents string, filter NoteFilter) (bool, error) {
+	doc, err := frontmatter.ParseDocument(contents)
+	if err != nil {
+		return false, err
+	}
+
+	if filter.Tag != "" && !hasTag(doc, contents, filter.Tag) {
+		return false, nil
+	}
+
+	for _, where := range filter.Where {
+		key, want, hasValue := strings.Cut(where, "=")
+		value, ok := doc.Get(doc.ResolvePath(key)...)
+		if !ok {
+			return false, nil
+		}
+		if hasValue && !nodeMatches(value, want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// hasTag checks the "tags"/"tag" properties and inline #tags of a note.
+func hasTag(doc *frontmatter.Document, contents, want string) bool {
+	want = strings.TrimPrefix(want, "#")
+	var tags []string
+	for _, key := range []string{"tags", "tag"} {
+		node, ok := doc.Get(key)
+		if !ok {
+			continue
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				tags = append(tags, item.Value)
+			}
+		case yaml.ScalarNode:
+			tags = append(tags, strings.FieldsFunc(node.Value, func(r rune) bool {
+				return r == ',' || r == ' '
+			})...)
+		}
+	}
+	tags = append(tags, markdown.InlineTags(doc.Body())...)
+
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if strings.EqualFold(tag, want) || strings.HasPrefix(strings.ToLower(tag), strings.ToLower(want)+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeMatches compares a property value with a filter value. Lists match if
+// any item does.
+func nodeMatches(node *yaml.Node, want string) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value == want
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode && item.Value == want {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package actions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectNotes(t *testing.T) {
+	note := mocks.MockNoteManager{
+		NotesList: []string{"Meetings/2026/sync.md", "Meetings/retro.md", "Projects/alpha.md", "inbox.md"},
+		NoteContents: map[string]string{
+			"Meetings/2026/sync.md": "---\ntags: [meeting]\nstatus: open\n---\n",
+			"Meetings/retro.md":     "---\ntags: meeting\nstatus: done\n---\n",
+			"Projects/alpha.md":     "---\nstatus: open\nowners: [ana, bo]\n---\nWork on #project/alpha",
+			"inbox.md":              "Nothing here",
+		},
+	}
+
+	tests := []struct {
+		testName string
+		filter   actions.NoteFilter
+		want     []string
+	}{
+		{testName: "Glob", filter: actions.NoteFilter{Glob: "Meetings/**/*.md"}, want: []string{"Meetings/2026/sync.md", "Meetings/retro.md"}},
+		{testName: "Folder", filter: actions.NoteFilter{Folder: "Projects/"}, want: []string{"Projects/alpha.md"}},
+		{testName: "Frontmatter tag", filter: actions.NoteFilter{Tag: "#meeting"}, want: []string{"Meetings/2026/sync.md", "Meetings/retro.md"}},
+		{testName: "Inline nested tag", filter: actions.NoteFilter{Tag: "project"}, want: []string{"Projects/alpha.md"}},
+		{testName: "Property value", filter: actions.NoteFilter{Where: []string{"status=open"}}, want: []string{"Meetings/2026/sync.md", "Projects/alpha.md"}},
+		{testName: "List property value", filter: actions.NoteFilter{Where: []string{"owners=bo"}}, want: []string{"Projects/alpha.md"}},
+		{testName: "Property exists", filter: actions.NoteFilter{Where: []string{"owners"}}, want: []string{"Projects/alpha.md"}},
+		{testName: "Combined criteria", filter: actions.NoteFilter{Folder: "Meetings", Where: []string{"status=open"}}, want: []string{"Meetings/2026/sync.md"}},
+		{testName: "Explicit paths", filter: actions.NoteFilter{Paths: []string{"/vault/inbox.md", "Meetings/retro", ""}}, want: []string{"inbox.md", "Meetings/retro.md"}},
+		{testName: "Explicit paths are cleaned before filtering", filter: actions.NoteFilter{Folder: "Meetings", Glob: "Meetings/*.md", Paths: []string{"./Meetings/retro.md", "Meetings//./retro.md", "./inbox.md"}}, want: []string{"Meetings/retro.md", "Meetings/retro.md"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			notes, err := actions.SelectNotes(&note, "/vault", test.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, notes)
+		})
+	}
+
+	t.Run("Absolute path outside the vault", func(t *testing.T) {
+		_, err := actions.SelectNotes(&note, "/vault", actions.NoteFilter{Paths: []string{"/elsewhere/note.md"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Relative path outside the vault", func(t *testing.T) {
+		_, err := actions.SelectNotes(&note, "/vault", actions.NoteFilter{Paths: []string{"./Meetings/../../note.md"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Notes with invalid frontmatter are skipped with a warning", func(t *testing.T) {
+		broken := mocks.MockNoteManager{
+			NotesList: []string{"broken.md", "ok.md"},
+			NoteContents: map[string]string{
+				"broken.md": "---\nstatus: [open\n---\n",
+				"ok.md":     "---\nstatus: open\n---\n",
+			},
+		}
+		var warnings strings.Builder
+
+		notes, err := actions.SelectNotes(&broken, "/vault", actions.NoteFilter{Where: []string{"status=open"}, Warnings: &warnings})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ok.md"}, notes)
+		assert.Contains(t, warnings.String(), "warning: skipping broken.md:")
+	})
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
 	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 )
 
 type PrintParams struct {
 	NoteName        string
 	IncludeMentions bool
+	Section         string // print only this heading's section ("Decisions" or "Project#Decisions")
+	Block           string // print only the block tagged with this ID ("abc123" or "^abc123")
+	Outline         bool   // print only the headings
+	ExpandEmbeds    bool   // inline embedded notes, sections and blocks
 }
 
 func PrintNote(vault obsidian.VaultManager, note obsidian.NoteManager, params PrintParams) (string, error) {
@@ -28,6 +33,15 @@ func PrintNote(vault obsidian.VaultManager, note obsidian.NoteManager, params Pr
 		return "", err
 	}
 
+	contents, err = notePart(contents, params)
+	if err != nil {
+		return "", fmt.Errorf("%w in %s", err, params.NoteName)
+	}
+
+	if params.ExpandEmbeds && !params.Outline {
+		contents = embedExpander{note: note, vaultPath: vaultPath}.expand(params.NoteName, contents)
+	}
+
 	if params.IncludeMentions {
 		backlinks, err := note.FindBacklinks(vaultPath, params.NoteName)
 		if err != nil {
@@ -42,6 +56,48 @@ func PrintNote(vault obsidian.VaultManager, note obsidian.NoteManager, params Pr
 	return contents, nil
 }
 
+// notePart returns the section, block or outline of contents selected by
+// params, or all of contents.
+func notePart(contents string, params PrintParams) (string, error) {
+	lines := strings.Split(contents, "\n")
+	switch {
+	case params.Section != "":
+		start, end, ok := markdown.FindHeadingPath(lines, params.Section)
+		if !ok {
+			return "", fmt.Errorf("section '%s' not found", params.Section)
+		}
+		return trimBlankLines(lines[start:end]), nil
+	case params.Block != "":
+		start, end, ok := markdown.FindBlock(lines, params.Block)
+		if !ok {
+			return "", fmt.Errorf("block '%s' not found", params.Block)
+		}
+		block := append([]string(nil), lines[start:end]...)
+		for i, line := range block {
+			block[i] = markdown.TrimBlockID(line)
+		}
+		return trimBlankLines(block), nil
+	case params.Outline:
+		var outline []string
+		for _, h := range markdown.Headings(lines) {
+			outline = append(outline, strings.Repeat("#", h.Level)+" "+h.Text)
+		}
+		return strings.Join(outline, "\n"), nil
+	}
+	return contents, nil
+}
+
+// trimBlankLines joins lines without the blank lines at either end.
+func trimBlankLines(lines []string) string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 func formatMentions(backlinks []obsidian.NoteMatch) string {
 	var sb strings.Builder
 	sb.WriteString("\n\n## Linked Mentions\n")
@@ -2,9 +2,12 @@ package actions_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/Yakitrak/notesmd-cli/mocks"
 	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -117,3 +120,128 @@ func TestPrintNote(t *testing.T) {
 		assert.Equal(t, "failed to find backlinks", err.Error())
 	})
 }
+
+func TestPrintNote_Parts(t *testing.T) {
+	contents := "---\ntags: [a]\n---\n# Meeting\nIntro\n\n## Decisions\n- Ship it ^ship\n  - on Friday\n\n### Follow-up\nCall Ann\n\n## Notes\nA paragraph\nwith an ID ^para\n"
+	printPart := func(params actions.PrintParams) (string, error) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: contents}
+		params.NoteName = "meeting"
+		return actions.PrintNote(&vault, &note, params)
+	}
+
+	t.Run("Section with its subsections", func(t *testing.T) {
+		content, err := printPart(actions.PrintParams{Section: "Decisions"})
+		assert.NoError(t, err)
+		assert.Equal(t, "## Decisions\n- Ship it ^ship\n  - on Friday\n\n### Follow-up\nCall Ann", content)
+	})
+
+	t.Run("Nested section path", func(t *testing.T) {
+		content, err := printPart(actions.PrintParams{Section: "Decisions#Follow-up"})
+		assert.NoError(t, err)
+		assert.Equal(t, "### Follow-up\nCall Ann", content)
+	})
+
+	t.Run("Missing section", func(t *testing.T) {
+		_, err := printPart(actions.PrintParams{Section: "Budget"})
+		assert.EqualError(t, err, "section 'Budget' not found in meeting")
+	})
+
+	t.Run("List item block without its ID", func(t *testing.T) {
+		content, err := printPart(actions.PrintParams{Block: "^ship"})
+		assert.NoError(t, err)
+		assert.Equal(t, "- Ship it\n  - on Friday", content)
+	})
+
+	t.Run("Paragraph block", func(t *testing.T) {
+		content, err := printPart(actions.PrintParams{Block: "para"})
+		assert.NoError(t, err)
+		assert.Equal(t, "A paragraph\nwith an ID", content)
+	})
+
+	t.Run("Missing block", func(t *testing.T) {
+		_, err := printPart(actions.PrintParams{Block: "nope"})
+		assert.EqualError(t, err, "block 'nope' not found in meeting")
+	})
+
+	t.Run("Outline", func(t *testing.T) {
+		content, err := printPart(actions.PrintParams{Outline: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "# Meeting\n## Decisions\n### Follow-up\n## Notes", content)
+	})
+}
+
+func TestPrintNote_ExpandEmbeds(t *testing.T) {
+	vaultPath := t.TempDir()
+	writeVaultFile(t, vaultPath, "Brief.md", "---\ntags: [project]\n---\n# Brief\n![[Goals]]\n\n> ![[Notes/Meeting#Decisions]]\n\nTask: ![[Notes/Meeting#^owner]]\n\n![[diagram.png]] ![[Missing]] ![[Goals#Nope]]\n```\n![[Goals]]\n```\n")
+	writeVaultFile(t, vaultPath, "Goals.md", "---\nstatus: draft\n---\n- Ship v2\n- ![[Brief]]\n")
+	writeVaultFile(t, vaultPath, "Notes/Meeting.md", "# Meeting\n## Decisions\nUse Go\n![[#Owners]]\n## Owners\n- Ann owns it ^owner\n")
+	vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+
+	content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Brief", ExpandEmbeds: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "---\ntags: [project]\n---\n# Brief\n- Ship v2\n- ![[Brief]]\n\n"+
+		"> ## Decisions\n> Use Go\n> ## Owners\n> - Ann owns it ^owner\n\n"+
+		"Task: - Ann owns it\n\n![[diagram.png]] ![[Missing]] ![[Goals#Nope]]\n```\n![[Goals]]\n```\n", content)
+
+	t.Run("Embeds that include themselves are not expanded again", func(t *testing.T) {
+		writeVaultFile(t, vaultPath, "Loop.md", "Loop start\n![[#Part]]\n# Part\npart ![[#Part]]\n")
+
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Loop", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Loop start\n# Part\npart ![[#Part]]\n# Part\npart # Part\npart ![[#Part]]\n", content)
+	})
+
+	t.Run("Stops at the depth limit", func(t *testing.T) {
+		for i := 0; i < 15; i++ {
+			writeVaultFile(t, vaultPath, fmt.Sprintf("Chain %d.md", i), fmt.Sprintf("%d ![[Chain %d]]", i, i+1))
+		}
+
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Chain 0", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0 1 2 3 4 5 6 7 8 9 10 ![[Chain 11]]", content)
+	})
+
+	t.Run("Notes with the same name in different folders are not a cycle", func(t *testing.T) {
+		writeVaultFile(t, vaultPath, "Projects/Index.md", "Projects\n![[Areas/Index]]\n")
+		writeVaultFile(t, vaultPath, "Areas/Index.md", "Areas\n![[Projects/Index]]\n")
+
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Projects/Index", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Projects\nAreas\n![[Projects/Index]]\n", content)
+	})
+
+	t.Run("Embeds ending in an empty embed add no blank line", func(t *testing.T) {
+		writeVaultFile(t, vaultPath, "Empty.md", "---\nstatus: draft\n---\n")
+		writeVaultFile(t, vaultPath, "Outer.md", "Outer\n![[Empty]]\n")
+		writeVaultFile(t, vaultPath, "Host.md", "Host\n![[Outer]]\n")
+
+		plain, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Host"})
+		assert.NoError(t, err)
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Host", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Host\nOuter\n", content)
+		assert.Equal(t, strings.Count(plain, "\n"), strings.Count(content, "\n"))
+	})
+
+	t.Run("Indents embeds in list items to the item's content", func(t *testing.T) {
+		writeVaultFile(t, vaultPath, "Lists.md", "- ![[Notes/Meeting#Owners]]\n> 1. ![[Notes/Meeting#Owners]]\n")
+
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Lists", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "- ## Owners\n  - Ann owns it ^owner\n> 1. ## Owners\n>    - Ann owns it ^owner\n", content)
+	})
+
+	t.Run("Expands embeds in a printed section", func(t *testing.T) {
+		content, err := actions.PrintNote(&vault, &obsidian.Note{}, actions.PrintParams{NoteName: "Notes/Meeting", Section: "Decisions", ExpandEmbeds: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "## Decisions\nUse Go\n## Owners\n- Ann owns it ^owner", content)
+	})
+}
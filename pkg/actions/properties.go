@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+type PropertiesParams struct {
+	DryRun   bool
+	Output   io.Writer // receives per-note results
+	Warnings io.Writer // receives notes skipped for invalid frontmatter
+}
+
+// PropertyUsage summarises how a property is used across the vault.
+type PropertyUsage struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+	Count int      `json:"count"`
+}
+
+// RenameProperty renames a frontmatter key in every note that uses it.
+func RenameProperty(vault obsidian.VaultManager, note obsidian.NoteManager, oldKey, newKey string, params PropertiesParams) (string, error) {
+	if oldKey == "" || newKey == "" {
+		return "", fmt.Errorf("property names cannot be empty")
+	}
+	return editProperty(vault, note, oldKey, params, func(_ frontmatter.PropertyTypes) frontmatterEdit {
+		return func(c string) (string, error) { return frontmatter.RenameKey(c, oldKey, newKey) }
+	})
+}
+
+// MergeProperties merges the values of one key into another in every note
+// that uses it, then removes the first key.
+func MergeProperties(vault obsidian.VaultManager, note obsidian.NoteManager, from, into string, params PropertiesParams) (string, error) {
+	if from == "" || into == "" {
+		return "", fmt.Errorf("property names cannot be empty")
+	}
+	if from == into {
+		return "", fmt.Errorf("cannot merge property '%s' into itself", from)
+	}
+	return editProperty(vault, note, from, params, func(_ frontmatter.PropertyTypes) frontmatterEdit {
+		return func(c string) (string, error) { return frontmatter.MergeKeys(c, from, into) }
+	})
+}
+
+// SetPropertyValues replaces values of a key across the vault using a
+// "old=new,old2=new2" mapping. List properties have their items mapped.
+func SetPropertyValues(vault obsidian.VaultManager, note obsidian.NoteManager, key, mapping string, params PropertiesParams) (string, error) {
+	values, err := ParseValueMap(mapping)
+	if err != nil {
+		return "", err
+	}
+	return editProperty(vault, note, key, params, func(types frontmatter.PropertyTypes) frontmatterEdit {
+		return func(c string) (string, error) { return types.MapValues(c, key, values) }
+	})
+}
+
+// ParseValueMap parses "todo=open,doing=active" into a mapping.
+func ParseValueMap(mapping string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range strings.Split(mapping, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		from, to, found := strings.Cut(pair, "=")
+		from = strings.TrimSpace(from)
+		if !found || from == "" {
+			return nil, fmt.Errorf("--map expects old=new pairs separated by commas, got %q", pair)
+		}
+		values[from] = strings.TrimSpace(to)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("--map is required")
+	}
+	return values, nil
+}
+
+func editProperty(vault obsidian.VaultManager, note obsidian.NoteManager, key string, params PropertiesParams, edit func(frontmatter.PropertyTypes) frontmatterEdit) (string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	types := frontmatter.PropertyTypes(obsidian.ReadPropertyTypes(vaultPath))
+	filter := NoteFilter{Where: []string{key}, Warnings: params.Warnings}
+	return applyEdits(note, vaultPath, filter, []frontmatterEdit{edit(types)}, params.DryRun, params.Output)
+}
+
+// ListProperties returns every top-level frontmatter key in the vault with
+// the types it is used with and the number of notes using it, sorted by name.
+// Notes with invalid frontmatter are skipped.
+func ListProperties(vault obsidian.VaultManager, note obsidian.NoteManager) ([]PropertyUsage, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := note.GetNotesList(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[string]*PropertyUsage{}
+	for _, notePath := range notes {
+		contents, err := note.GetContents(vaultPath, notePath)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := frontmatter.ParseDocument(contents)
+		if err != nil {
+			continue
+		}
+		for _, key := range doc.Keys() {
+			property, ok := usage[key]
+			if !ok {
+				property = &PropertyUsage{Name: key, Types: []string{}}
+				usage[key] = property
+			}
+			property.Count++
+
+			value, _ := doc.Get(key)
+			valueType := frontmatter.ValueType(value)
+			if !containsString(property.Types, valueType) {
+				property.Types = append(property.Types, valueType)
+			}
+		}
+	}
+
+	properties := make([]PropertyUsage, 0, len(usage))
+	for _, property := range usage {
+		sort.Strings(property.Types)
+		properties = append(properties, *property)
+	}
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].Name < properties[j].Name
+	})
+	return properties, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package actions_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProperties(t *testing.T) {
+	newNote := func() *mocks.MockNoteManager {
+		return &mocks.MockNoteManager{
+			NotesList: []string{"a.md", "b.md", "c.md"},
+			NoteContents: map[string]string{
+				"a.md": "---\nstatus: todo\ntag: work\n---\nBody",
+				"b.md": "---\nstatus: [doing, done]\ntags: [home]\ntag: work\n---\n",
+				"c.md": "No frontmatter",
+			},
+		}
+	}
+
+	t.Run("Rename a property across the vault", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		summary, err := actions.RenameProperty(&vault, note, "status", "state", actions.PropertiesParams{Output: &out})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2 notes matched, 2 changed, 0 unchanged\n", summary)
+		assert.Equal(t, "Updated a.md\nUpdated b.md\n", out.String())
+		assert.Equal(t, "---\nstate: todo\ntag: work\n---\nBody", note.NoteContents["a.md"])
+		assert.Equal(t, "---\nstate: [doing, done]\ntags: [home]\ntag: work\n---\n", note.NoteContents["b.md"])
+	})
+
+	t.Run("Rename skips notes with invalid frontmatter", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		note.NotesList = append(note.NotesList, "broken.md")
+		note.NoteContents["broken.md"] = "---\nstatus: [open\n---\n"
+		var out, warnings strings.Builder
+
+		summary, err := actions.RenameProperty(&vault, note, "status", "state", actions.PropertiesParams{Output: &out, Warnings: &warnings})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2 notes matched, 2 changed, 0 unchanged\n", summary)
+		assert.Contains(t, warnings.String(), "warning: skipping broken.md:")
+		assert.Equal(t, "---\nstatus: [open\n---\n", note.NoteContents["broken.md"])
+	})
+
+	t.Run("Rename fails per note when the new key exists", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		summary, err := actions.RenameProperty(&vault, note, "tag", "tags", actions.PropertiesParams{Output: &out})
+
+		assert.Error(t, err)
+		assert.Equal(t, "2 notes matched, 1 changed, 0 unchanged, 1 failed\n", summary)
+		assert.Contains(t, out.String(), "Error: b.md:")
+	})
+
+	t.Run("Merge one property into another", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		_, err := actions.MergeProperties(&vault, note, "tag", "tags", actions.PropertiesParams{Output: &out})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nstatus: todo\ntags: work\n---\nBody", note.NoteContents["a.md"])
+		assert.Equal(t, "---\nstatus: [doing, done]\ntags: [home, work]\n---\n", note.NoteContents["b.md"])
+	})
+
+	t.Run("Merging a property into itself is an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+
+		_, err := actions.MergeProperties(&vault, newNote(), "tag", "tag", actions.PropertiesParams{Output: &strings.Builder{}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Set values with a mapping", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		_, err := actions.SetPropertyValues(&vault, note, "status", "todo=open, doing=active", actions.PropertiesParams{Output: &out})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nstatus: open\ntag: work\n---\nBody", note.NoteContents["a.md"])
+		assert.Equal(t, "---\nstatus: [active, done]\ntags: [home]\ntag: work\n---\n", note.NoteContents["b.md"])
+	})
+
+	t.Run("Dry run prints diffs without writing", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := newNote()
+		var out strings.Builder
+
+		summary, err := actions.SetPropertyValues(&vault, note, "status", "todo=open", actions.PropertiesParams{DryRun: true, Output: &out})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2 notes matched, 1 changed, 1 unchanged (dry run, no files written)\n", summary)
+		assert.Contains(t, out.String(), "-status: todo\n+status: open\n")
+		assert.Equal(t, "---\nstatus: todo\ntag: work\n---\nBody", note.NoteContents["a.md"])
+	})
+
+	t.Run("Invalid mapping", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+
+		_, err := actions.SetPropertyValues(&vault, newNote(), "status", "todo", actions.PropertiesParams{Output: &strings.Builder{}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("List properties with types and counts", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+
+		properties, err := actions.ListProperties(&vault, newNote())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []actions.PropertyUsage{
+			{Name: "status", Types: []string{"list", "text"}, Count: 2},
+			{Name: "tag", Types: []string{"text"}, Count: 2},
+			{Name: "tags", Types: []string{"list"}, Count: 1},
+		}, properties)
+	})
+
+	t.Run("Vault error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("no default vault")}
+
+		_, err := actions.ListProperties(&vault, newNote())
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+)
+
+// What happens to rolled over tasks in the previous note.
+const (
+	RolloverKeep   = "keep"   // leave them unchanged
+	RolloverMark   = "mark"   // mark them as moved: "- [>] task"
+	RolloverDelete = "delete" // remove them
+)
+
+// RolloverParams configures rolling unfinished tasks over into a new note.
+// Empty fields fall back to the Rollover Daily Todos plugin's settings.
+type RolloverParams struct {
+	Under     string // heading to add tasks under; the end of the note if empty
+	Originals string // RolloverKeep, RolloverMark or RolloverDelete
+}
+
+// rolloverTasks copies the open tasks of the most recent note of period before
+// date into content, the body of the new note. It returns the new content and
+// a function that updates the previous note as params.Originals says, to be
+// called once the new note has been written.
+func rolloverTasks(vaultPath, period string, settings obsidian.PeriodConfig, date time.Time, content string, params RolloverParams) (string, func() error, error) {
+	noop := func() error { return nil }
+
+	config := obsidian.ReadRolloverTodosConfig(vaultPath)
+	under := params.Under
+	if under == "" {
+		under = config.TemplateHeading
+	}
+	originals := params.Originals
+	if originals == "" {
+		originals = RolloverKeep
+		if config.DeleteOnComplete {
+			originals = RolloverDelete
+		}
+	}
+	if originals != RolloverKeep && originals != RolloverMark && originals != RolloverDelete {
+		return "", nil, fmt.Errorf("invalid rollover mode '%s': expected keep, mark or delete", originals)
+	}
+
+	previous, err := nearestPeriodicNote(vaultPath, period, settings, date, DailyPrev)
+	if err != nil {
+		// No earlier note: nothing to roll over.
+		return content, noop, nil
+	}
+	previousName := obsidian.FormatMoment(previous, settings.Format)
+	if settings.Folder != "" {
+		previousName = settings.Folder + "/" + previousName
+	}
+	previousPath := filepath.Join(vaultPath, filepath.FromSlash(obsidian.AddMdSuffix(previousName)))
+
+	data, version, err := obsidian.ReadFileVersion(previousPath)
+	if err != nil {
+		return "", nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+	blocks := tasks.OpenBlocks(lines, config.RolloverChildren, config.RemoveEmptyTodos)
+	if len(blocks) == 0 {
+		return content, noop, nil
+	}
+
+	var rolled []string
+	for _, block := range blocks {
+		for _, line := range lines[block.Start:block.End] {
+			rolled = append(rolled, strings.TrimSuffix(line, "\r"))
+		}
+	}
+	text := strings.Join(rolled, "\n")
+
+	if under != "" {
+		content = markdown.InsertUnderHeading(content, under, text)
+	} else {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += text + "\n"
+	}
+
+	if originals == RolloverKeep {
+		return content, noop, nil
+	}
+	return content, func() error {
+		// The new note already holds the tasks, so a previous note edited
+		// in the meantime is left alone rather than updated from a stale copy.
+		updated := []byte(strings.Join(updateRolledOver(lines, blocks, originals), "\n"))
+		if err := obsidian.WriteFileIfUnchanged(previousPath, updated, 0644, version); err != nil {
+			return fmt.Errorf("could not update %s: %w", previousName, err)
+		}
+		return nil
+	}, nil
+}
+
+// updateRolledOver marks or deletes the rolled over blocks in lines.
+func updateRolledOver(lines []string, blocks []tasks.Block, originals string) []string {
+	updated := make([]string, 0, len(lines))
+	next := 0
+	for _, block := range blocks {
+		updated = append(updated, lines[next:block.Start]...)
+		if originals == RolloverMark {
+			line, hasCR := strings.CutSuffix(lines[block.Start], "\r")
+			task, _ := tasks.Parse(line)
+			task.Status = tasks.MovedStatus
+			if hasCR {
+				updated = append(updated, task.String()+"\r")
+			} else {
+				updated = append(updated, task.String())
+			}
+			updated = append(updated, lines[block.Start+1:block.End]...)
+		}
+		next = block.End
+	}
+	return append(updated, lines[next:]...)
+}
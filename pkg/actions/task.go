@@ -0,0 +1,193 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+)
+
+type TaskParams struct {
+	NoteName string
+	Line     int
+}
+
+type AddTaskParams struct {
+	NoteName string
+	Text     string
+	Due      string
+	Under    string
+}
+
+// ParseTaskRef splits a "<note>:<line>" reference into the note name and a
+// one-based line number.
+func ParseTaskRef(ref string) (string, int, error) {
+	idx := strings.LastIndex(ref, ":")
+	if idx <= 0 {
+		return "", 0, fmt.Errorf("invalid task reference %q: expected <note>:<line>", ref)
+	}
+	line, err := strconv.Atoi(ref[idx+1:])
+	if err != nil || line < 1 {
+		return "", 0, fmt.Errorf("invalid line number in task reference %q", ref)
+	}
+	return ref[:idx], line, nil
+}
+
+// CompleteTask ticks the task on the given line and stamps it with today's
+// completion date. Recurring tasks get their next instance inserted above.
+func CompleteTask(vault obsidian.VaultManager, note obsidian.NoteManager, params TaskParams) (string, error) {
+	return updateTask(vault, note, params, func(task tasks.Task) ([]string, string, error) {
+		if task.IsDone() {
+			return nil, "", errors.New(tasks.AlreadyDoneError)
+		}
+		return completeTask(task)
+	})
+}
+
+// ToggleTask completes an open task or reopens a completed one.
+func ToggleTask(vault obsidian.VaultManager, note obsidian.NoteManager, params TaskParams) (string, error) {
+	return updateTask(vault, note, params, func(task tasks.Task) ([]string, string, error) {
+		if task.IsDone() {
+			return []string{task.Uncomplete().String()}, "Reopened", nil
+		}
+		return completeTask(task)
+	})
+}
+
+func completeTask(task tasks.Task) ([]string, string, error) {
+	done, next, err := task.Complete(time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	if next == nil {
+		return []string{done.String()}, "Completed", nil
+	}
+	return []string{next.String(), done.String()}, "Completed", nil
+}
+
+func updateTask(vault obsidian.VaultManager, note obsidian.NoteManager, params TaskParams, update func(tasks.Task) ([]string, string, error)) (string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	// The task is addressed by line number, so a retry after the note changed
+	// is only safe while that line still holds the task first read.
+	original := ""
+	return retryOnChange(func() (string, error) {
+		return updateTaskLine(note, vaultPath, params, &original, update)
+	})
+}
+
+func updateTaskLine(note obsidian.NoteManager, vaultPath string, params TaskParams, original *string, update func(tasks.Task) ([]string, string, error)) (string, error) {
+	contents, err := note.GetContents(vaultPath, params.NoteName)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(contents, "\n")
+	if *original != "" && (params.Line > len(lines) || lines[params.Line-1] != *original) {
+		return "", fmt.Errorf("line %d of %s changed while the task was being updated", params.Line, params.NoteName)
+	}
+	if params.Line < 1 || params.Line > len(lines) {
+		return "", fmt.Errorf("line %d is out of range for %s", params.Line, params.NoteName)
+	}
+	*original = lines[params.Line-1]
+
+	// Keep CRLF line endings intact on the rewritten lines.
+	line, hasCR := strings.CutSuffix(lines[params.Line-1], "\r")
+	task, ok := tasks.Parse(line)
+	if !ok {
+		return "", fmt.Errorf("%s: %s:%d", tasks.NotATaskError, params.NoteName, params.Line)
+	}
+
+	replacement, verb, err := update(task)
+	if err != nil {
+		return "", err
+	}
+	if hasCR {
+		for i := range replacement {
+			replacement[i] += "\r"
+		}
+	}
+
+	updated := make([]string, 0, len(lines)+len(replacement)-1)
+	updated = append(updated, lines[:params.Line-1]...)
+	updated = append(updated, replacement...)
+	updated = append(updated, lines[params.Line:]...)
+
+	if err := note.SetContents(vaultPath, params.NoteName, strings.Join(updated, "\n")); err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("%s task on line %d in %s", verb, params.Line, params.NoteName)
+	if len(replacement) > 1 {
+		message += fmt.Sprintf("\nAdded next occurrence on line %d", params.Line)
+	}
+	return message, nil
+}
+
+// AddTask adds a new open task to a note, optionally with a due date and
+// under a specific heading. Without a heading the task is appended to the end.
+func AddTask(vault obsidian.VaultManager, note obsidian.NoteManager, params AddTaskParams) (string, error) {
+	if strings.TrimSpace(params.Text) == "" {
+		return "", errors.New("task text cannot be empty")
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	return retryOnChange(func() (string, error) {
+		return addTaskLine(note, vaultPath, params)
+	})
+}
+
+func addTaskLine(note obsidian.NoteManager, vaultPath string, params AddTaskParams) (string, error) {
+	contents, err := note.GetContents(vaultPath, params.NoteName)
+	if err != nil {
+		return "", err
+	}
+
+	task := tasks.Task{Prefix: "- [", Status: ' ', Body: " " + strings.TrimSpace(params.Text)}
+	if params.Due != "" {
+		due, err := obsidian.ParseDate(params.Due, time.Now())
+		if err != nil {
+			return "", err
+		}
+		task.SetDate(tasks.DueSignifier, due)
+	}
+
+	var updated string
+	if params.Under != "" {
+		updated = markdown.InsertUnderHeading(contents, params.Under, task.String())
+	} else {
+		updated = contents
+		if updated != "" && !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		updated += task.String() + "\n"
+	}
+
+	if err := note.SetContents(vaultPath, params.NoteName, updated); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Added task to %s: %s", params.NoteName, task.String()), nil
+}
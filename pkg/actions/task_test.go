@@ -0,0 +1,191 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskRef(t *testing.T) {
+	t.Run("Splits note and line", func(t *testing.T) {
+		note, line, err := actions.ParseTaskRef("Projects/Home:12")
+		assert.NoError(t, err)
+		assert.Equal(t, "Projects/Home", note)
+		assert.Equal(t, 12, line)
+	})
+
+	t.Run("Uses last colon", func(t *testing.T) {
+		note, line, err := actions.ParseTaskRef("Meeting 10:30:3")
+		assert.NoError(t, err)
+		assert.Equal(t, "Meeting 10:30", note)
+		assert.Equal(t, 3, line)
+	})
+
+	for _, ref := range []string{"note", ":3", "note:", "note:abc", "note:0"} {
+		t.Run("Rejects "+ref, func(t *testing.T) {
+			_, _, err := actions.ParseTaskRef(ref)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompleteTask(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+
+	t.Run("Completes task on line", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Tasks\n- [ ] Buy milk\n- [ ] Call mum"}
+
+		output, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 2})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Completed task on line 2")
+		assert.Equal(t, "# Tasks\n- [x] Buy milk ✅ "+today+"\n- [ ] Call mum", note.SetContentsValue)
+	})
+
+	t.Run("Inserts next recurrence above completed task", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [ ] Review 🔁 every week 📅 2026-10-19\r\nend"}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "- [ ] Review 🔁 every week 📅 2026-10-26\r\n- [x] Review 🔁 every week 📅 2026-10-19 ✅ "+today+"\r\nend", note.SetContentsValue)
+	})
+
+	t.Run("Already done task returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [x] Buy milk"}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Non-task line returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Tasks\n- [ ] Buy milk"}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Line out of range returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [ ] Buy milk"}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 5})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("SetContents error propagates", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [ ] Buy milk", SetContentsError: errors.New("write failed")}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Retries when the task line is unchanged", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := changingNoteManager{
+			MockNoteManager: mocks.MockNoteManager{NoteContents: map[string]string{"todo": "- [ ] Buy milk\n- [ ] Call mum\n"}},
+			changes:         1,
+			edited:          "- [ ] Buy milk\n- [ ] Call mum\n- [ ] Added elsewhere\n",
+		}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "- [ ] Buy milk\n- [x] Call mum ✅ "+today+"\n- [ ] Added elsewhere\n", note.NoteContents["todo"])
+	})
+
+	t.Run("Fails when a line is inserted above the task before the write", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := changingNoteManager{
+			MockNoteManager: mocks.MockNoteManager{NoteContents: map[string]string{"todo": "- [ ] Buy milk\n- [ ] Call mum\n"}},
+			changes:         1,
+			edited:          "- [ ] Inserted elsewhere\n- [ ] Buy milk\n- [ ] Call mum\n",
+		}
+
+		_, err := actions.CompleteTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 2})
+
+		assert.EqualError(t, err, "line 2 of todo changed while the task was being updated")
+		assert.Equal(t, "- [ ] Inserted elsewhere\n- [ ] Buy milk\n- [ ] Call mum\n", note.NoteContents["todo"])
+	})
+}
+
+func TestToggleTask(t *testing.T) {
+	t.Run("Reopens completed task", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [x] Buy milk ✅ 2026-10-01"}
+
+		output, err := actions.ToggleTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Reopened task")
+		assert.Equal(t, "- [ ] Buy milk", note.SetContentsValue)
+	})
+
+	t.Run("Completes open task", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "- [ ] Buy milk"}
+
+		_, err := actions.ToggleTask(&vault, &note, actions.TaskParams{NoteName: "todo", Line: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "- [x] Buy milk ✅ "+time.Now().Format("2006-01-02"), note.SetContentsValue)
+	})
+}
+
+func TestAddTask(t *testing.T) {
+	t.Run("Appends task to end of note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Home"}
+
+		_, err := actions.AddTask(&vault, &note, actions.AddTaskParams{NoteName: "home", Text: "Call plumber"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# Home\n- [ ] Call plumber\n", note.SetContentsValue)
+	})
+
+	t.Run("Adds task with due date under heading", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "## Todo\n- [ ] a\n\n## Done\n"}
+
+		_, err := actions.AddTask(&vault, &note, actions.AddTaskParams{
+			NoteName: "home",
+			Text:     "Call plumber",
+			Due:      "2026-10-23",
+			Under:    "## Todo",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "## Todo\n- [ ] a\n- [ ] Call plumber 📅 2026-10-23\n\n## Done\n", note.SetContentsValue)
+	})
+
+	t.Run("Invalid due date returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Home"}
+
+		_, err := actions.AddTask(&vault, &note, actions.AddTaskParams{NoteName: "home", Text: "x", Due: "someday"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty text returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+
+		_, err := actions.AddTask(&vault, &note, actions.AddTaskParams{NoteName: "home", Text: " "})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+type TemplateParams struct {
+	Name  string // template name or path, relative to the templates folder
+	Title string // value of {{title}}; defaults to the template's name
+	Date  string // value of {{date}}; an expression understood by obsidian.ParseDate
+}
+
+// RenderTemplate returns a template from the core Templates plugin's folder
+// with its variables expanded.
+func RenderTemplate(vault obsidian.VaultManager, params TemplateParams) (string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	config := obsidian.ReadTemplatesConfig(vaultPath)
+	templatePath, err := findTemplate(vaultPath, config.Folder, params.Name)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(templatePath)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	date := now
+	if params.Date != "" {
+		date, err = obsidian.ParseDate(params.Date, now)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	title := params.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(templatePath), ".md")
+	}
+
+	return config.Render(string(data), title, date, now), nil
+}
+
+// ListTemplates returns the names of the templates in the core Templates
+// plugin's folder, relative to it and without the .md extension, sorted.
+func ListTemplates(vault obsidian.VaultManager) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	config := obsidian.ReadTemplatesConfig(vaultPath)
+	if config.Folder == "" {
+		return nil, errors.New("no template folder is configured in .obsidian/templates.json")
+	}
+	root, err := obsidian.ValidatePath(vaultPath, config.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, strings.TrimSuffix(filepath.ToSlash(rel), ".md"))
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("template folder %s does not exist", config.Folder)
+		}
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// findTemplate resolves a template name inside the templates folder, falling
+// back to a path from the vault root.
+func findTemplate(vaultPath, folder, name string) (string, error) {
+	candidates := []string{obsidian.AddMdSuffix(name)}
+	if folder != "" {
+		candidates = append([]string{folder + "/" + obsidian.AddMdSuffix(name)}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		templatePath, err := obsidian.ValidatePath(vaultPath, candidate)
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(templatePath); err == nil && !info.IsDir() {
+			return templatePath, nil
+		}
+	}
+
+	if folder == "" {
+		return "", fmt.Errorf("template '%s' not found", name)
+	}
+	return "", fmt.Errorf("template '%s' not found in %s", name, folder)
+}
@@ -0,0 +1,97 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Run("Renders a template from the templates folder", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"folder": "Templates", "dateFormat": "DD/MM/YYYY"}`)
+		writeVaultFile(t, tmpDir, "Templates/Meeting.md", "# {{title}}\nDate: {{date}}\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		output, err := actions.RenderTemplate(&vault, actions.TemplateParams{Name: "Meeting", Date: "2026-10-01"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# Meeting\nDate: 01/10/2026\n", output)
+	})
+
+	t.Run("Uses the given title and today by default", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, "Templates/Daily.md", "{{title}} {{date}}")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		output, err := actions.RenderTemplate(&vault, actions.TemplateParams{Name: "Templates/Daily", Title: "Today"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Today "+time.Now().Format("2006-01-02"), output)
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"folder": "Templates"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		_, err := actions.RenderTemplate(&vault, actions.TemplateParams{Name: "Nope"})
+
+		assert.EqualError(t, err, "template 'Nope' not found in Templates")
+	})
+
+	t.Run("Template outside the vault", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		_, err := actions.RenderTemplate(&vault, actions.TemplateParams{Name: "../secret"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("path error")}
+
+		_, err := actions.RenderTemplate(&vault, actions.TemplateParams{Name: "Meeting"})
+
+		assert.Equal(t, vault.PathError, err)
+	})
+}
+
+func TestListTemplates(t *testing.T) {
+	t.Run("Lists templates in the templates folder", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"folder": "Templates"}`)
+		writeVaultFile(t, tmpDir, "Templates/Meeting.md", "")
+		writeVaultFile(t, tmpDir, "Templates/Work/Standup.md", "")
+		writeVaultFile(t, tmpDir, "Templates/Daily.md", "")
+		writeVaultFile(t, tmpDir, "Templates/logo.png", "")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		names, err := actions.ListTemplates(&vault)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Daily", "Meeting", "Work/Standup"}, names)
+	})
+
+	t.Run("No templates folder configured", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		_, err := actions.ListTemplates(&vault)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Templates folder missing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/templates.json", `{"folder": "Templates"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		_, err := actions.ListTemplates(&vault)
+
+		assert.EqualError(t, err, "template folder Templates does not exist")
+	})
+}
@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+// uniqueNoteAttempts bounds the search for a free timestamp.
+const uniqueNoteAttempts = 1000
+
+type UniqueNoteParams struct {
+	Title      string   // added to the timestamp after a space
+	Template   string   // template to use instead of the plugin's
+	Properties []string // "key=value" frontmatter properties
+	Content    string
+	RawContent bool // write Content as is, without NormalizeContent
+	ShouldOpen bool
+	UseEditor  bool
+	Sanitize   bool // replace unsafe characters in the title instead of failing
+}
+
+// UniqueNote creates a note named with a timestamp, like Obsidian's Unique
+// note creator core plugin, using the folder, format and template from
+// .obsidian/zk-prefixer.json. If a note in the folder already uses the
+// timestamp, later timestamps are tried until a free one is found. The note
+// file is created exclusively, so a note created at the same time by another
+// process is never overwritten. It returns the path of the created note.
+func UniqueNote(vault obsidian.VaultManager, uri obsidian.UriManager, params UniqueNoteParams) (string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	title := strings.TrimSpace(params.Title)
+	if strings.ContainsAny(title, `/\`) {
+		return "", fmt.Errorf("title %q cannot contain a path separator", title)
+	}
+
+	config := obsidian.ReadZkPrefixerConfig(vaultPath)
+	folder := config.Folder
+	if folder == "" {
+		// Fall back to the default location for new notes.
+		if folder = path.Dir(obsidian.ApplyDefaultFolder("note", vaultPath)); folder == "." {
+			folder = ""
+		}
+	}
+
+	noteName, notePath, err := reserveUniqueNote(vaultPath, folder, config.Format, title, params.Sanitize, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	template := params.Template
+	if template == "" {
+		template = config.Template
+	}
+
+	err = CreateNote(vault, uri, CreateParams{
+		NoteName: noteName,
+		// Fill in the empty file reserved for the note.
+		ShouldOverwrite: true,
+		Content:         params.Content,
+		RawContent:      params.RawContent,
+		ShouldOpen:      params.ShouldOpen,
+		UseEditor:       params.UseEditor,
+		Template:        template,
+		Properties:      params.Properties,
+	})
+	if err != nil {
+		// Give the name back unless the note was written before the error.
+		if info, statErr := os.Stat(notePath); statErr == nil && info.Size() == 0 {
+			os.Remove(notePath)
+		}
+		return "", err
+	}
+
+	return notePath, nil
+}
+
+// reserveUniqueNote formats now with format, moving on by the format's
+// resolution while a note in folder is named with the result, alone or
+// followed by a space and a title, and creates an empty note file for the
+// first free name. A name taken by another process between the check and the
+// creation is skipped too. It returns the note name and the path of the file.
+func reserveUniqueNote(vaultPath, folder, format, title string, sanitize bool, now time.Time) (string, string, error) {
+	dir := filepath.Join(vaultPath, filepath.FromSlash(folder))
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", err
+	}
+	taken := func(id string) bool {
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), ".md")
+			if name == id || strings.HasPrefix(name, id+" ") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create note directory: %w", err)
+	}
+
+	step := obsidian.MomentResolution(format)
+	for i := 0; i < uniqueNoteAttempts; i++ {
+		id := obsidian.FormatMoment(now.Add(time.Duration(i)*step), format)
+		if taken(id) {
+			continue
+		}
+
+		noteName := id
+		if title != "" {
+			noteName += " " + title
+		}
+		if folder != "" {
+			noteName = folder + "/" + noteName
+		}
+		if noteName, err = checkNoteName(vaultPath, noteName, sanitize); err != nil {
+			return "", "", err
+		}
+		notePath, err := obsidian.ValidatePath(vaultPath, obsidian.AddMdSuffix(noteName))
+		if err != nil {
+			return "", "", err
+		}
+
+		file, err := os.OpenFile(notePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if err := file.Close(); err != nil {
+			return "", "", err
+		}
+		return noteName, notePath, nil
+	}
+	return "", "", fmt.Errorf("could not find a free note name for format %s", format)
+}
@@ -0,0 +1,76 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueNote(t *testing.T) {
+	t.Run("Creates a timestamped note in the configured folder", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/zk-prefixer.json", `{"folder": "Zettel", "format": "YYYYMMDD"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		notePath, err := actions.UniqueNote(&vault, &mocks.MockUriManager{}, actions.UniqueNoteParams{Title: "Spaced repetition", Content: "body"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(tmpDir, "Zettel", time.Now().Format("20060102")+" Spaced repetition.md"), notePath)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "body", string(content))
+	})
+
+	t.Run("Skips timestamps already in use", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/zk-prefixer.json", `{"format": "YYYYMMDD"}`)
+		today := time.Now()
+		writeVaultFile(t, tmpDir, today.Format("20060102")+" Earlier idea.md", "")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		notePath, err := actions.UniqueNote(&vault, &mocks.MockUriManager{}, actions.UniqueNoteParams{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(tmpDir, today.AddDate(0, 0, 1).Format("20060102")+".md"), notePath)
+	})
+
+	t.Run("Uses the default folder and the plugin's template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/app.json", `{"newFileLocation": "folder", "newFileFolderPath": "Inbox"}`)
+		writeVaultFile(t, tmpDir, ".obsidian/zk-prefixer.json", `{"format": "YYYY", "template": "Templates/Zettel"}`)
+		writeVaultFile(t, tmpDir, "Templates/Zettel.md", "# {{title}}\n")
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		notePath, err := actions.UniqueNote(&vault, &mocks.MockUriManager{}, actions.UniqueNoteParams{Title: "Idea", Properties: []string{"status=seed"}})
+
+		assert.NoError(t, err)
+		year := time.Now().Format("2006")
+		assert.Equal(t, filepath.Join(tmpDir, "Inbox", year+" Idea.md"), notePath)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "---\nstatus: seed\n---\n# "+year+" Idea\n", string(content))
+	})
+
+	t.Run("Leaves no empty note behind when creation fails", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeVaultFile(t, tmpDir, ".obsidian/zk-prefixer.json", `{"folder": "Zettel", "format": "YYYYMMDD", "template": "Templates/Missing"}`)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: tmpDir}
+
+		_, err := actions.UniqueNote(&vault, &mocks.MockUriManager{}, actions.UniqueNoteParams{Title: "Idea"})
+
+		assert.Error(t, err)
+		entries, _ := os.ReadDir(filepath.Join(tmpDir, "Zettel"))
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Title cannot contain a path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		_, err := actions.UniqueNote(&vault, &mocks.MockUriManager{}, actions.UniqueNoteParams{Title: "../escape"})
+
+		assert.Error(t, err)
+	})
+}
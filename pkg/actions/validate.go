@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+const (
+	validateFormatText = "text"
+	validateFormatJSON = "json"
+)
+
+type ValidateParams struct {
+	Filter NoteFilter // limits the notes checked; all notes when unset
+	Format string     // text or json
+	Output io.Writer
+}
+
+// Diagnostic is a schema problem in a single note.
+type Diagnostic struct {
+	File string `json:"file"`
+	frontmatter.Problem
+}
+
+// Validate checks the frontmatter of every note covered by a schema in
+// .notesmd/schema.yaml and writes one diagnostic per problem to
+// params.Output. It returns an error if any note has problems.
+func Validate(vault obsidian.VaultManager, note obsidian.NoteManager, params ValidateParams) error {
+	format := strings.TrimSpace(strings.ToLower(params.Format))
+	if format == "" {
+		format = validateFormatText
+	}
+	if format != validateFormatText && format != validateFormatJSON {
+		return fmt.Errorf("invalid format '%s': expected one of text, json", params.Format)
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	config, err := frontmatter.LoadSchemaConfig(vaultPath)
+	if err != nil {
+		return err
+	}
+	if len(config.Schemas) == 0 {
+		return fmt.Errorf("no schemas defined in %s", frontmatter.SchemaConfigPath)
+	}
+
+	notes, err := SelectNotes(note, vaultPath, params.Filter)
+	if err != nil {
+		return err
+	}
+
+	out := params.Output
+	if out == nil {
+		out = io.Discard
+	}
+
+	diagnostics := []Diagnostic{}
+	checked, failed := 0, 0
+	for _, notePath := range notes {
+		schema := config.ForNote(notePath)
+		if schema == nil {
+			continue
+		}
+		contents, err := note.GetContents(vaultPath, notePath)
+		if err != nil {
+			return err
+		}
+		checked++
+
+		problems := ValidateContents(schema, contents)
+		if len(problems) > 0 {
+			failed++
+		}
+		for _, problem := range problems {
+			diagnostics = append(diagnostics, Diagnostic{File: notePath, Problem: problem})
+		}
+	}
+
+	if format == validateFormatJSON {
+		data, err := json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		for _, d := range diagnostics {
+			fmt.Fprintf(out, "%s: %s\n", d.File, d.Message)
+		}
+		fmt.Fprintf(out, "Checked %d notes, %d with problems\n", checked, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d notes failed validation", failed)
+	}
+	return nil
+}
+
+// ValidateContents checks a note's frontmatter against schema. Frontmatter
+// that cannot be parsed is reported as a single problem.
+func ValidateContents(schema *frontmatter.Schema, contents string) []frontmatter.Problem {
+	fm, _, err := frontmatter.Parse(contents)
+	if err != nil {
+		return []frontmatter.Problem{{Kind: frontmatter.ProblemInvalid, Message: err.Error()}}
+	}
+	return schema.Validate(fm)
+}
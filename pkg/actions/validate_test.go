@@ -0,0 +1,85 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/mocks"
+	"github.com/Yakitrak/notesmd-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeVaultFile(t *testing.T, vaultPath, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(vaultPath, filepath.FromSlash(relPath))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+const meetingSchema = "schemas:\n  - folder: Meetings\n    properties:\n      date: {type: date, required: true}\n      status: {type: text, enum: [planned, done]}\n"
+
+func TestValidate(t *testing.T) {
+	newNote := func() *mocks.MockNoteManager {
+		return &mocks.MockNoteManager{
+			NotesList: []string{"Meetings/a.md", "Meetings/b.md", "inbox.md"},
+			NoteContents: map[string]string{
+				"Meetings/a.md": "---\ndate: 2026-10-01\nstatus: done\n---\n",
+				"Meetings/b.md": "---\nstatus: maybe\n---\n",
+				"inbox.md":      "no frontmatter",
+			},
+		}
+	}
+
+	t.Run("Reports problems as text and fails", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".notesmd/schema.yaml", meetingSchema)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.Validate(&vault, newNote(), actions.ValidateParams{Output: &out})
+
+		assert.EqualError(t, err, "1 notes failed validation")
+		assert.Equal(t, "Meetings/b.md: missing required property 'date'\n"+
+			"Meetings/b.md: property 'status' has value 'maybe', expected one of: planned, done\n"+
+			"Checked 2 notes, 1 with problems\n", out.String())
+	})
+
+	t.Run("Reports problems as JSON", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".notesmd/schema.yaml", meetingSchema)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		var out strings.Builder
+
+		err := actions.Validate(&vault, newNote(), actions.ValidateParams{Format: "json", Output: &out})
+
+		assert.Error(t, err)
+		assert.JSONEq(t, `[
+			{"file": "Meetings/b.md", "property": "date", "problem": "missing", "message": "missing required property 'date'"},
+			{"file": "Meetings/b.md", "property": "status", "problem": "enum", "message": "property 'status' has value 'maybe', expected one of: planned, done"}
+		]`, out.String())
+	})
+
+	t.Run("Valid vault passes", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeVaultFile(t, vaultPath, ".notesmd/schema.yaml", meetingSchema)
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: vaultPath}
+		note := newNote()
+		note.NotesList = []string{"Meetings/a.md", "inbox.md"}
+		var out strings.Builder
+
+		err := actions.Validate(&vault, note, actions.ValidateParams{Output: &out})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Checked 1 notes, 0 with problems\n", out.String())
+	})
+
+	t.Run("Missing schema config is an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathValue: t.TempDir()}
+
+		err := actions.Validate(&vault, newNote(), actions.ValidateParams{})
+
+		assert.EqualError(t, err, "no schemas defined in .notesmd/schema.yaml")
+	})
+}
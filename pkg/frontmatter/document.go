@@ -0,0 +1,636 @@
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a note split into its frontmatter and body. Edits are made on a
+// yaml.Node tree and spliced back into the original text line by line, so key
+// order, comments, quoting and flow/block style of every untouched key stay
+// byte-for-byte intact, as does the body.
+//
+// TOML ("+++") and JSON (";;;") frontmatter is edited as YAML internally and
+// written back in its original format once changed.
+type Document struct {
+	hasFrontmatter bool
+	format         string // FormatYAML, FormatTOML or FormatJSON
+	original       string // TOML or JSON frontmatter text, until edited
+	dirty          bool
+	newline        string
+	open           string   // opening delimiter line, including its line ending
+	lines          []string // frontmatter lines, without line endings
+	close          string   // closing delimiter line, including its line ending
+	body           string
+	root           *yaml.Node // top-level mapping, nil when the frontmatter is empty
+}
+
+// span is a half-open range of frontmatter line indexes.
+type span struct {
+	start, end int
+}
+
+// ParseDocument splits content into frontmatter and body. Content without a
+// leading "---" block yields a document with empty frontmatter.
+func ParseDocument(content string) (*Document, error) {
+	doc := &Document{format: FormatYAML, newline: "\n", body: content}
+	if !HasFrontmatter(content) {
+		return doc, nil
+	}
+
+	rawLines := strings.SplitAfter(content, "\n")
+	doc.format = delimiterFormat(rawLines[0])
+	closing := -1
+	for i := 1; i < len(rawLines); i++ {
+		if strings.TrimSpace(rawLines[i]) == formatDelimiter(doc.format) {
+			closing = i
+			break
+		}
+	}
+	if closing == -1 {
+		return nil, errors.New(InvalidFrontmatterError)
+	}
+
+	if strings.HasSuffix(rawLines[0], "\r\n") {
+		doc.newline = "\r\n"
+	}
+	doc.hasFrontmatter = true
+	doc.open = rawLines[0]
+	doc.close = rawLines[closing]
+	doc.body = strings.Join(rawLines[closing+1:], "")
+
+	if doc.format != FormatYAML {
+		doc.original = strings.Join(rawLines[1:closing], "")
+		root, err := decodeFrontmatter(doc.format, doc.original)
+		if err != nil {
+			return nil, err
+		}
+		if len(root.Content) > 0 {
+			if doc.lines, err = renderEntries(root); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, line := range rawLines[1:closing] {
+			doc.lines = append(doc.lines, strings.TrimRight(line, "\r\n"))
+		}
+	}
+
+	if err := doc.reparse(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// HasFrontmatter reports whether the document had or now has a frontmatter block.
+func (d *Document) HasFrontmatter() bool {
+	return d.hasFrontmatter
+}
+
+// Format returns the frontmatter format: FormatYAML, FormatTOML or FormatJSON.
+func (d *Document) Format() string {
+	return d.format
+}
+
+// Convert switches the frontmatter to another format. The whole block is
+// rewritten, so comments in TOML or YAML frontmatter are lost.
+func (d *Document) Convert(format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	if !d.hasFrontmatter || format == d.format {
+		return nil
+	}
+
+	if d.root != nil {
+		lines, err := renderEntries(d.root)
+		if err != nil {
+			return err
+		}
+		d.lines = lines
+		if err := d.reparse(); err != nil {
+			return err
+		}
+	}
+	if format != FormatYAML && d.root != nil {
+		// Fail now rather than when the note is written.
+		if _, err := encodeFrontmatter(format, d.root); err != nil {
+			return err
+		}
+	}
+
+	d.format = format
+	d.open, d.close = "", ""
+	d.dirty = true
+	return nil
+}
+
+// Body returns the content after the frontmatter block.
+func (d *Document) Body() string {
+	return d.body
+}
+
+// Len returns the number of top-level keys.
+func (d *Document) Len() int {
+	if d.root == nil {
+		return 0
+	}
+	return len(d.root.Content) / 2
+}
+
+// Keys returns the top-level keys in document order.
+func (d *Document) Keys() []string {
+	var keys []string
+	if d.root == nil {
+		return keys
+	}
+	for i := 0; i < len(d.root.Content); i += 2 {
+		keys = append(keys, d.root.Content[i].Value)
+	}
+	return keys
+}
+
+// Get returns the value node at path, where each element is a key of a
+// nested map.
+func (d *Document) Get(path ...string) (*yaml.Node, bool) {
+	node := d.root
+	for _, key := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		idx := findKey(node, key)
+		if idx == -1 {
+			return nil, false
+		}
+		node = node.Content[idx+1]
+	}
+	return node, node != nil
+}
+
+// ResolvePath turns a dotted key such as "project.owner" into a path of nested
+// keys. A top-level key that literally contains dots is matched first.
+func (d *Document) ResolvePath(key string) []string {
+	if _, ok := d.Get(key); ok || !strings.Contains(key, ".") {
+		return []string{key}
+	}
+	return strings.Split(key, ".")
+}
+
+// Set replaces the value at path, or adds it (with any missing parent maps)
+// after the last entry of its parent map.
+func (d *Document) Set(path []string, value *yaml.Node) error {
+	if len(path) == 0 || path[0] == "" {
+		return errors.New("frontmatter key cannot be empty")
+	}
+	d.hasFrontmatter = true
+
+	if d.root == nil {
+		entry, err := renderEntry(keyNode(path[0], nil), nest(path[1:], value), 0)
+		if err != nil {
+			return err
+		}
+		return d.splice(span{len(d.lines), len(d.lines)}, entry)
+	}
+
+	mapping := d.root
+	region := span{0, len(d.lines)}
+	for depth, key := range path {
+		idx := findKey(mapping, key)
+		spans := entrySpans(d.lines, mapping, region.end)
+
+		if idx == -1 {
+			at := spans[len(spans)-1].end
+			entry, err := renderEntry(keyNode(key, nil), nest(path[depth+1:], value), mapping.Content[0].Column-1)
+			if err != nil {
+				return err
+			}
+			return d.splice(span{at, at}, entry)
+		}
+
+		entrySpan := spans[idx/2]
+		oldKey, oldValue := mapping.Content[idx], mapping.Content[idx+1]
+
+		if depth == len(path)-1 {
+			return d.replaceEntry(entrySpan, oldKey, keepStyle(oldValue, value))
+		}
+
+		switch {
+		case oldValue.Kind == yaml.ScalarNode && oldValue.Tag == "!!null":
+			return d.replaceEntry(entrySpan, oldKey, nest(path[depth+1:], value))
+		case oldValue.Kind != yaml.MappingNode:
+			return fmt.Errorf("cannot set %s: %s is not a map", strings.Join(path, "."), strings.Join(path[:depth+1], "."))
+		case oldValue.Style&yaml.FlowStyle != 0:
+			// Flow maps ("{a: 1}") cannot be edited line by line; rewrite the entry.
+			updated := cloneNode(oldValue)
+			if err := setInTree(updated, path[depth+1:], value); err != nil {
+				return err
+			}
+			return d.replaceEntry(entrySpan, oldKey, updated)
+		}
+
+		mapping = oldValue
+		region = entrySpan
+	}
+	return nil
+}
+
+// Delete removes the entry at path, including any comment directly above it.
+// It reports whether the key existed.
+func (d *Document) Delete(path []string) (bool, error) {
+	mapping := d.root
+	region := span{0, len(d.lines)}
+	for depth, key := range path {
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			return false, nil
+		}
+		idx := findKey(mapping, key)
+		if idx == -1 {
+			return false, nil
+		}
+
+		entrySpan := entrySpans(d.lines, mapping, region.end)[idx/2]
+		oldKey, oldValue := mapping.Content[idx], mapping.Content[idx+1]
+
+		if depth == len(path)-1 {
+			entrySpan.start -= headCommentLines(d.lines, entrySpan.start, oldKey)
+			return true, d.splice(entrySpan, nil)
+		}
+
+		if oldValue.Kind == yaml.MappingNode && oldValue.Style&yaml.FlowStyle != 0 {
+			updated := cloneNode(oldValue)
+			if !deleteInTree(updated, path[depth+1:]) {
+				return false, nil
+			}
+			return true, d.replaceEntry(entrySpan, oldKey, updated)
+		}
+
+		mapping = oldValue
+		region = entrySpan
+	}
+	return false, nil
+}
+
+// Rename changes the last key of path to newKey, leaving its value, position
+// and comments untouched. It reports whether the key existed and fails if
+// newKey is already taken in the same map.
+func (d *Document) Rename(path []string, newKey string) (bool, error) {
+	if newKey == "" {
+		return false, errors.New("frontmatter key cannot be empty")
+	}
+
+	mapping := d.root
+	region := span{0, len(d.lines)}
+	for depth, key := range path {
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			return false, nil
+		}
+		idx := findKey(mapping, key)
+		if idx == -1 {
+			return false, nil
+		}
+
+		entrySpan := entrySpans(d.lines, mapping, region.end)[idx/2]
+		oldKey, oldValue := mapping.Content[idx], mapping.Content[idx+1]
+
+		if depth == len(path)-1 {
+			if key == newKey {
+				return true, nil
+			}
+			if findKey(mapping, newKey) != -1 {
+				return false, fmt.Errorf("cannot rename %s: key %s already exists", strings.Join(path, "."), newKey)
+			}
+			line, ok := renameKeyInLine(d.lines[oldKey.Line-1], oldKey, newKey)
+			if !ok {
+				return false, d.replaceEntry(entrySpan, keyNode(newKey, oldKey), oldValue)
+			}
+			return true, d.splice(span{oldKey.Line - 1, oldKey.Line}, []string{line})
+		}
+
+		if oldValue.Kind == yaml.MappingNode && oldValue.Style&yaml.FlowStyle != 0 {
+			tree := cloneNode(oldValue)
+			updated := tree
+			for _, key := range path[depth+1 : len(path)-1] {
+				i := findKey(updated, key)
+				if i == -1 || updated.Content[i+1].Kind != yaml.MappingNode {
+					return false, nil
+				}
+				updated = updated.Content[i+1]
+			}
+			last := path[len(path)-1]
+			i := findKey(updated, last)
+			if i == -1 {
+				return false, nil
+			}
+			if findKey(updated, newKey) != -1 {
+				return false, fmt.Errorf("cannot rename %s: key %s already exists", strings.Join(path, "."), newKey)
+			}
+			updated.Content[i] = keyNode(newKey, updated.Content[i])
+			return true, d.replaceEntry(entrySpan, oldKey, tree)
+		}
+
+		mapping = oldValue
+		region = entrySpan
+	}
+	return false, nil
+}
+
+// String reassembles the note.
+func (d *Document) String() string {
+	if !d.hasFrontmatter {
+		return d.body
+	}
+
+	var sb strings.Builder
+	open := d.open
+	if open == "" {
+		open = formatDelimiter(d.format) + d.newline
+	}
+	sb.WriteString(open)
+	if d.format == FormatYAML {
+		for _, line := range d.lines {
+			sb.WriteString(line)
+			sb.WriteString(d.newline)
+		}
+	} else if !d.dirty {
+		sb.WriteString(d.original)
+	} else {
+		// Encoding was checked when the edit was made.
+		text, _ := encodeFrontmatter(d.format, d.root)
+		sb.WriteString(strings.ReplaceAll(text, "\n", d.newline))
+	}
+	closeLine := d.close
+	if closeLine == "" {
+		closeLine = formatDelimiter(d.format) + d.newline
+	}
+	sb.WriteString(closeLine)
+	sb.WriteString(d.body)
+	return sb.String()
+}
+
+func (d *Document) replaceEntry(s span, oldKey, value *yaml.Node) error {
+	entry, err := renderEntry(keyNode(oldKey.Value, oldKey), value, oldKey.Column-1)
+	if err != nil {
+		return err
+	}
+	return d.splice(s, entry)
+}
+
+// splice replaces lines[s.start:s.end] with replacement and refreshes the tree.
+func (d *Document) splice(s span, replacement []string) error {
+	lines := make([]string, 0, len(d.lines)-(s.end-s.start)+len(replacement))
+	lines = append(lines, d.lines[:s.start]...)
+	lines = append(lines, replacement...)
+	lines = append(lines, d.lines[s.end:]...)
+	d.lines = lines
+	d.dirty = true
+	if err := d.reparse(); err != nil {
+		return err
+	}
+	if d.format != FormatYAML && d.root != nil {
+		if _, err := encodeFrontmatter(d.format, d.root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Document) reparse() error {
+	d.root = nil
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(d.lines, "\n")), &doc); err != nil {
+		return errors.New(InvalidFrontmatterError)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind == yaml.ScalarNode && root.Tag == "!!null" {
+		return nil
+	}
+	if root.Kind != yaml.MappingNode {
+		return errors.New(InvalidFrontmatterError)
+	}
+	if len(root.Content) > 0 {
+		d.root = root
+	}
+	return nil
+}
+
+// entrySpans returns the line range of each key/value pair of a block mapping.
+// A pair runs from its key line up to the next key, excluding blank and
+// comment lines in between (those belong to the next key). The last pair ends
+// at limit.
+func entrySpans(lines []string, mapping *yaml.Node, limit int) []span {
+	var spans []span
+	for i := 0; i < len(mapping.Content); i += 2 {
+		start := mapping.Content[i].Line - 1
+		end := limit
+		if i+2 < len(mapping.Content) {
+			end = mapping.Content[i+2].Line - 1
+		}
+		for end > start+1 && isBlankOrComment(lines[end-1]) {
+			end--
+		}
+		spans = append(spans, span{start, end})
+	}
+	return spans
+}
+
+// headCommentLines counts the comment lines directly above start that yaml.v3
+// attached to key as its head comment.
+func headCommentLines(lines []string, start int, key *yaml.Node) int {
+	if key.HeadComment == "" {
+		return 0
+	}
+	want := strings.Count(key.HeadComment, "\n") + 1
+	n := 0
+	for n < want && start-n-1 >= 0 && strings.HasPrefix(strings.TrimSpace(lines[start-n-1]), "#") {
+		n++
+	}
+	return n
+}
+
+func isBlankOrComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// renderEntries encodes all entries of a mapping as block YAML lines.
+func renderEntries(mapping *yaml.Node) ([]string, error) {
+	var lines []string
+	for i := 0; i < len(mapping.Content); i += 2 {
+		entry, err := renderEntry(keyNode(mapping.Content[i].Value, mapping.Content[i]), mapping.Content[i+1], 0)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, entry...)
+	}
+	return lines, nil
+}
+
+// renderEntry encodes a single "key: value" pair indented by indent spaces.
+func renderEntry(key, value *yaml.Node, indent int) ([]string, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(mapping); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i := range lines {
+		lines[i] = prefix + lines[i]
+	}
+	return lines, nil
+}
+
+// renameKeyInLine swaps the key token at the start of a block mapping entry
+// for newKey, keeping the rest of the line as is. It reports false when the
+// key cannot be located on its line, e.g. for multi-line keys.
+func renameKeyInLine(line string, key *yaml.Node, newKey string) (string, bool) {
+	start := columnOffset(line, key.Column)
+	if start < 0 || start >= len(line) {
+		return "", false
+	}
+
+	end := -1
+	switch line[start] {
+	case '"', '\'':
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' && line[start] == '"' {
+				i++
+				continue
+			}
+			if line[i] == line[start] {
+				end = i + 1
+				break
+			}
+		}
+	default:
+		end = start + len(key.Value)
+	}
+	if end == -1 || end > len(line) || !strings.HasPrefix(strings.TrimLeft(line[end:], " "), ":") {
+		return "", false
+	}
+
+	data, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: newKey, Style: key.Style})
+	if err != nil {
+		return "", false
+	}
+	return line[:start] + strings.TrimSuffix(string(data), "\n") + line[end:], true
+}
+
+// columnOffset converts a 1-based yaml.Node column, which counts runes, into
+// a byte offset in line. It returns -1 if the column is past the line.
+func columnOffset(line string, column int) int {
+	for offset := range line {
+		if column--; column == 0 {
+			return offset
+		}
+	}
+	return -1
+}
+
+// keyNode builds a key for rendering, keeping the quoting and trailing
+// comment of the key it replaces.
+func keyNode(key string, old *yaml.Node) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	if old != nil {
+		node.Style = old.Style
+		node.LineComment = old.LineComment
+	}
+	return node
+}
+
+// keepStyle carries the quoting, flow style and trailing comment of the value
+// being replaced over to its replacement, so that "status: 'draft'" becomes
+// "status: 'done'" rather than changing quote style.
+func keepStyle(old, value *yaml.Node) *yaml.Node {
+	updated := *value
+	if old.Kind == value.Kind && value.Style == 0 {
+		switch {
+		case value.Kind == yaml.ScalarNode && value.Tag == "!!str" && old.Tag == "!!str":
+			updated.Style = old.Style &^ (yaml.LiteralStyle | yaml.FoldedStyle)
+		case value.Kind != yaml.ScalarNode:
+			updated.Style = old.Style & yaml.FlowStyle
+		}
+	}
+	if updated.LineComment == "" {
+		updated.LineComment = old.LineComment
+	}
+	return &updated
+}
+
+// nest wraps value in block maps for each key in path.
+func nest(path []string, value *yaml.Node) *yaml.Node {
+	for i := len(path) - 1; i >= 0; i-- {
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{keyNode(path[i], nil), value}}
+	}
+	return value
+}
+
+func findKey(mapping *yaml.Node, key string) int {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func setInTree(mapping *yaml.Node, path []string, value *yaml.Node) error {
+	for depth, key := range path {
+		idx := findKey(mapping, key)
+		if idx == -1 {
+			mapping.Content = append(mapping.Content, keyNode(key, nil), nest(path[depth+1:], value))
+			return nil
+		}
+		if depth == len(path)-1 {
+			mapping.Content[idx+1] = keepStyle(mapping.Content[idx+1], value)
+			return nil
+		}
+		next := mapping.Content[idx+1]
+		if next.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a map", strings.Join(path, "."), key)
+		}
+		mapping = next
+	}
+	return nil
+}
+
+func deleteInTree(mapping *yaml.Node, path []string) bool {
+	for depth, key := range path {
+		idx := findKey(mapping, key)
+		if idx == -1 {
+			return false
+		}
+		if depth == len(path)-1 {
+			mapping.Content = append(mapping.Content[:idx], mapping.Content[idx+2:]...)
+			return true
+		}
+		mapping = mapping.Content[idx+1]
+		if mapping.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return false
+}
+
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+	return &clone
+}
@@ -0,0 +1,211 @@
+package frontmatter_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const styledNote = `---
+# Note metadata
+title: "Weekly sync"   # shown in lists
+tags: [meeting, work]
+aliases:
+    - sync
+status: 'draft'
+
+# Review fields
+reviewed: false
+---
+Body with --- inside
+`
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func TestDocumentSet(t *testing.T) {
+	t.Run("Only the touched key changes", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument(styledNote)
+		assert.NoError(t, err)
+
+		assert.NoError(t, doc.Set([]string{"reviewed"}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}))
+
+		expected := `---
+# Note metadata
+title: "Weekly sync"   # shown in lists
+tags: [meeting, work]
+aliases:
+    - sync
+status: 'draft'
+
+# Review fields
+reviewed: true
+---
+Body with --- inside
+`
+		assert.Equal(t, expected, doc.String())
+	})
+
+	t.Run("Keeps quoting and trailing comment of replaced value", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+
+		assert.NoError(t, doc.Set([]string{"title"}, stringNode("Monthly sync")))
+		assert.NoError(t, doc.Set([]string{"status"}, stringNode("done")))
+
+		assert.Contains(t, doc.String(), "\ntitle: \"Monthly sync\" # shown in lists\ntags: [meeting, work]\n")
+		assert.Contains(t, doc.String(), "\nstatus: 'done'\n\n# Review fields\n")
+	})
+
+	t.Run("Keeps flow style of replaced list", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+		var tags yaml.Node
+		assert.NoError(t, tags.Encode([]string{"meeting", "personal"}))
+
+		assert.NoError(t, doc.Set([]string{"tags"}, &tags))
+
+		assert.Contains(t, doc.String(), "\ntags: [meeting, personal]\naliases:\n    - sync\n")
+	})
+
+	t.Run("Replaces multi-line value", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+
+		assert.NoError(t, doc.Set([]string{"aliases"}, stringNode("catch-up")))
+
+		assert.Contains(t, doc.String(), "\ntags: [meeting, work]\naliases: catch-up\nstatus: 'draft'\n")
+	})
+
+	t.Run("Appends new key after last entry", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument("---\na: 1\nb: 2\n---\nBody")
+
+		assert.NoError(t, doc.Set([]string{"c"}, stringNode("x")))
+
+		assert.Equal(t, "---\na: 1\nb: 2\nc: x\n---\nBody", doc.String())
+	})
+
+	t.Run("Preserves CRLF line endings", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument("---\r\na: 1\r\nb: 2\r\n---\r\nBody\r\n")
+
+		assert.NoError(t, doc.Set([]string{"a"}, stringNode("x")))
+
+		assert.Equal(t, "---\r\na: x\r\nb: 2\r\n---\r\nBody\r\n", doc.String())
+	})
+
+	t.Run("Creates frontmatter when none exists", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument("Body")
+
+		assert.NoError(t, doc.Set([]string{"a"}, stringNode("x")))
+
+		assert.Equal(t, "---\na: x\n---\nBody", doc.String())
+	})
+}
+
+func TestDocumentDelete(t *testing.T) {
+	t.Run("Removes key with its head comment", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+
+		found, err := doc.Delete([]string{"reviewed"})
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Contains(t, doc.String(), "status: 'draft'\n\n---\nBody with --- inside\n")
+	})
+
+	t.Run("Removes multi-line value", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+
+		_, err := doc.Delete([]string{"aliases"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, doc.String(), "tags: [meeting, work]\nstatus: 'draft'\n")
+	})
+
+	t.Run("Missing key is not found", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(styledNote)
+
+		found, err := doc.Delete([]string{"missing"})
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, styledNote, doc.String())
+	})
+}
+
+func TestParseDocument(t *testing.T) {
+	t.Run("Unclosed frontmatter returns error", func(t *testing.T) {
+		_, err := frontmatter.ParseDocument("---\ntitle: x\nBody")
+		assert.Error(t, err)
+	})
+
+	t.Run("Non-map frontmatter returns error", func(t *testing.T) {
+		_, err := frontmatter.ParseDocument("---\n- a\n---\nBody")
+		assert.Error(t, err)
+	})
+
+	t.Run("Keys in document order", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument(styledNote)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title", "tags", "aliases", "status", "reviewed"}, doc.Keys())
+	})
+}
+
+func TestDocumentNestedPaths(t *testing.T) {
+	const nested = "---\ntitle: Plan\nproject:\n  name: Apollo # codename\n  owner: ana\nmeta: {a: 1}\n---\nBody"
+
+	t.Run("Replaces nested key in place", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		assert.NoError(t, doc.Set([]string{"project", "owner"}, stringNode("bo")))
+
+		assert.Equal(t, "---\ntitle: Plan\nproject:\n  name: Apollo # codename\n  owner: bo\nmeta: {a: 1}\n---\nBody", doc.String())
+	})
+
+	t.Run("Adds nested key to existing map", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		assert.NoError(t, doc.Set([]string{"project", "due"}, stringNode("soon")))
+
+		assert.Equal(t, "---\ntitle: Plan\nproject:\n  name: Apollo # codename\n  owner: ana\n  due: soon\nmeta: {a: 1}\n---\nBody", doc.String())
+	})
+
+	t.Run("Creates missing parent maps", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		assert.NoError(t, doc.Set([]string{"review", "by", "name"}, stringNode("cy")))
+
+		assert.Contains(t, doc.String(), "meta: {a: 1}\nreview:\n  by:\n    name: cy\n---\n")
+	})
+
+	t.Run("Rewrites flow map entry", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		assert.NoError(t, doc.Set([]string{"meta", "b"}, stringNode("2")))
+
+		assert.Contains(t, doc.String(), "\nmeta: {a: 1, b: \"2\"}\n---\n")
+	})
+
+	t.Run("Setting below a scalar returns error", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		assert.Error(t, doc.Set([]string{"title", "sub"}, stringNode("x")))
+	})
+
+	t.Run("Deletes nested key", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument(nested)
+
+		found, err := doc.Delete([]string{"project", "name"})
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "---\ntitle: Plan\nproject:\n  owner: ana\nmeta: {a: 1}\n---\nBody", doc.String())
+	})
+
+	t.Run("Resolves dotted keys", func(t *testing.T) {
+		doc, _ := frontmatter.ParseDocument("---\nv1.2: x\n---\n")
+
+		assert.Equal(t, []string{"v1.2"}, doc.ResolvePath("v1.2"))
+		assert.Equal(t, []string{"project", "owner"}, doc.ResolvePath("project.owner"))
+	})
+}
@@ -0,0 +1,148 @@
+package frontmatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToJSON encodes a YAML value node as indented JSON. Unlike going through
+// map[string]interface{}, map keys keep their document order, and dates are
+// written as they appear in the note ("2026-10-01") instead of as timestamps.
+func ToJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, node); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// JSON encodes the whole frontmatter as a JSON object.
+func (d *Document) JSON() ([]byte, error) {
+	if d.root == nil {
+		return []byte("{}"), nil
+	}
+	return ToJSON(d.root)
+}
+
+// ToYAML renders a value for display: scalars as their plain value, lists
+// and maps as block YAML without the comments of the note.
+func ToYAML(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(withoutComments(node)); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// withoutComments returns a copy of node and its children with all comments
+// removed.
+func withoutComments(node *yaml.Node) *yaml.Node {
+	clean := *node
+	clean.HeadComment, clean.LineComment, clean.FootComment = "", "", ""
+	if len(node.Content) > 0 {
+		clean.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			clean.Content[i] = withoutComments(child)
+		}
+	}
+	return &clean
+}
+
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case yaml.ScalarNode:
+		return writeScalarJSON(buf, node)
+	}
+	return fmt.Errorf("unsupported YAML node kind %d", node.Kind)
+}
+
+func writeScalarJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	var value interface{} = node.Value
+	switch node.ShortTag() {
+	case "!!null":
+		value = nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+		value = b
+	case "!!int":
+		// Only plain decimals that fit an int64 become numbers. Hex, octal,
+		// binary and out-of-range values stay strings as written, so the
+		// output is always valid JSON and nothing is silently reinterpreted.
+		if i, err := strconv.ParseInt(strings.TrimPrefix(node.Value, "+"), 10, 64); err == nil {
+			value = i
+		}
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return err
+		}
+		if !math.IsInf(f, 0) && !math.IsNaN(f) {
+			buf.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
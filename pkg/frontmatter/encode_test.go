@@ -0,0 +1,75 @@
+package frontmatter_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentJSON(t *testing.T) {
+	t.Run("Keeps key order and value types", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument("---\ntitle: Test\ndate: 2026-10-01\ncount: 3\nratio: 0.5\ndone: false\nempty:\nzip: \"007\"\nproject:\n  owner: ana\n  tags: [a, b]\n---\nBody\n---\n")
+		assert.NoError(t, err)
+
+		data, err := doc.JSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `{
+  "title": "Test",
+  "date": "2026-10-01",
+  "count": 3,
+  "ratio": 0.5,
+  "done": false,
+  "empty": null,
+  "zip": "007",
+  "project": {
+    "owner": "ana",
+    "tags": [
+      "a",
+      "b"
+    ]
+  }
+}`, string(data))
+	})
+
+	t.Run("Only decimal ints that fit an int64 are numbers", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument("---\na: +42\nb: 0x1F\nc: 0o17\nd: !!int 123456789012345678901234567890\ne: -7\n---\n")
+		assert.NoError(t, err)
+
+		data, err := doc.JSON()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"a": 42, "b": "0x1F", "c": "0o17", "d": "123456789012345678901234567890", "e": -7}`, string(data))
+	})
+
+	t.Run("No frontmatter is an empty object", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument("Just a body")
+		assert.NoError(t, err)
+
+		data, err := doc.JSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", string(data))
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	doc, err := frontmatter.ParseDocument("---\nstatus: \"done\"\ntags: [a, b]\n---\n")
+	assert.NoError(t, err)
+
+	status, _ := doc.Get("status")
+	formatted, err := frontmatter.ToYAML(status)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", formatted)
+
+	tags, _ := doc.Get("tags")
+	formatted, err = frontmatter.ToYAML(tags)
+	assert.NoError(t, err)
+	assert.Equal(t, "[a, b]", formatted)
+
+	doc, err = frontmatter.ParseDocument("---\nproject: # the project\n  # who runs it\n  owner: ana # lead\n  team: core\n---\n")
+	assert.NoError(t, err)
+
+	project, _ := doc.Get("project")
+	formatted, err = frontmatter.ToYAML(project)
+	assert.NoError(t, err)
+	assert.Equal(t, "owner: ana\nteam: core", formatted)
+}
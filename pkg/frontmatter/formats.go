@@ -0,0 +1,321 @@
+package frontmatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter formats and their delimiters.
+const (
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+	FormatJSON = "json"
+
+	TOMLDelimiter = "+++"
+	JSONDelimiter = ";;;"
+)
+
+// delimiterFormat returns the frontmatter format opened by line, or "".
+func delimiterFormat(line string) string {
+	switch strings.TrimSpace(line) {
+	case Delimiter:
+		return FormatYAML
+	case TOMLDelimiter:
+		return FormatTOML
+	case JSONDelimiter:
+		return FormatJSON
+	}
+	return ""
+}
+
+func formatDelimiter(format string) string {
+	switch format {
+	case FormatTOML:
+		return TOMLDelimiter
+	case FormatJSON:
+		return JSONDelimiter
+	}
+	return Delimiter
+}
+
+// ValidateFormat checks a format name given on the command line.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatYAML, FormatTOML, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid frontmatter format '%s': expected one of yaml, toml, json", format)
+}
+
+// decodeFrontmatter parses TOML or JSON frontmatter into a YAML node tree,
+// keeping key order.
+func decodeFrontmatter(format, text string) (*yaml.Node, error) {
+	switch format {
+	case FormatJSON:
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &doc); err != nil || len(doc.Content) == 0 {
+			return nil, errors.New(InvalidFrontmatterError)
+		}
+		root := doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, errors.New(InvalidFrontmatterError)
+		}
+		clearStyle(root)
+		return root, nil
+	case FormatTOML:
+		var data map[string]interface{}
+		meta, err := toml.Decode(text, &data)
+		if err != nil {
+			return nil, errors.New(InvalidFrontmatterError)
+		}
+		// MetaData lists keys in document order; collect the child order of
+		// every table so the tree can be rebuilt in that order.
+		order := map[string][]string{}
+		seen := map[string]bool{}
+		for _, key := range meta.Keys() {
+			if len(key) == 0 {
+				continue
+			}
+			parent := strings.Join(key[:len(key)-1], "\x00")
+			id := parent + "\x01" + key[len(key)-1]
+			if !seen[id] {
+				seen[id] = true
+				order[parent] = append(order[parent], key[len(key)-1])
+			}
+		}
+		return tomlNode(data, nil, order), nil
+	}
+	return nil, fmt.Errorf("unsupported frontmatter format %s", format)
+}
+
+func tomlNode(value interface{}, path []string, order map[string][]string) *yaml.Node {
+	scalar := func(tag, v string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		keys := order[strings.Join(path, "\x00")]
+		var rest []string
+		for key := range v {
+			if !containsKey(keys, key) {
+				rest = append(rest, key)
+			}
+		}
+		sort.Strings(rest)
+		for _, key := range append(append([]string{}, keys...), rest...) {
+			child, ok := v[key]
+			if !ok {
+				continue
+			}
+			node.Content = append(node.Content, keyNode(key, nil), tomlNode(child, append(path, key), order))
+		}
+		return node
+	case []map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			node.Content = append(node.Content, tomlNode(item, path, order))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			node.Content = append(node.Content, tomlNode(item, path, order))
+		}
+		return node
+	case string:
+		return scalar("!!str", v)
+	case bool:
+		return scalar("!!bool", fmt.Sprint(v))
+	case int64:
+		return scalar("!!int", fmt.Sprint(v))
+	case float64:
+		text := fmt.Sprint(v)
+		if !strings.ContainsAny(text, ".eEnN") {
+			text += ".0"
+		}
+		return scalar("!!float", text)
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return scalar("!!timestamp", v.Format("2006-01-02"))
+		}
+		return scalar("!!timestamp", v.Format(time.RFC3339Nano))
+	}
+	return scalar("!!str", fmt.Sprint(value))
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeFrontmatter renders a mapping as TOML or JSON frontmatter text,
+// without delimiters.
+func encodeFrontmatter(format string, root *yaml.Node) (string, error) {
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	switch format {
+	case FormatJSON:
+		data, err := ToJSON(root)
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case FormatTOML:
+		var buf bytes.Buffer
+		if err := writeTOMLTable(&buf, nil, root); err != nil {
+			return "", err
+		}
+		return strings.TrimLeft(buf.String(), "\n"), nil
+	}
+	return "", fmt.Errorf("unsupported frontmatter format %s", format)
+}
+
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// writeTOMLTable writes the plain keys of a table, then its sub-tables and
+// arrays of tables, as TOML requires.
+func writeTOMLTable(buf *bytes.Buffer, path []string, mapping *yaml.Node) error {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i].Value, resolveAlias(mapping.Content[i+1])
+		if isTOMLTable(value) || isTOMLTableArray(value) {
+			continue
+		}
+		text, err := tomlValue(key, value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%s = %s\n", tomlKey(key), text)
+	}
+
+	for i := 0; i < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i].Value, resolveAlias(mapping.Content[i+1])
+		tablePath := append(append([]string{}, path...), tomlKey(key))
+		switch {
+		case isTOMLTable(value):
+			fmt.Fprintf(buf, "\n[%s]\n", strings.Join(tablePath, "."))
+			if err := writeTOMLTable(buf, tablePath, value); err != nil {
+				return err
+			}
+		case isTOMLTableArray(value):
+			for _, item := range value.Content {
+				fmt.Fprintf(buf, "\n[[%s]]\n", strings.Join(tablePath, "."))
+				if err := writeTOMLTable(buf, tablePath, resolveAlias(item)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isTOMLTable(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode
+}
+
+func isTOMLTableArray(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+	for _, item := range node.Content {
+		if resolveAlias(item).Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	return jsonString(key)
+}
+
+// tomlValue renders an inline TOML value.
+func tomlValue(key string, node *yaml.Node) (string, error) {
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			text, err := tomlValue(key, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, text)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case yaml.MappingNode:
+		items := make([]string, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			text, err := tomlValue(node.Content[i].Value, node.Content[i+1])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, tomlKey(node.Content[i].Value)+" = "+text)
+		}
+		return "{" + strings.Join(items, ", ") + "}", nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return "", fmt.Errorf("cannot write %s as TOML: TOML has no null value", key)
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return "", err
+		}
+		return fmt.Sprint(b), nil
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err != nil {
+			return "", err
+		}
+		return fmt.Sprint(i), nil
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return "", err
+		}
+		text := fmt.Sprint(f)
+		if !strings.ContainsAny(text, ".eEnN") {
+			text += ".0"
+		}
+		return text, nil
+	case "!!timestamp":
+		return node.Value, nil
+	}
+	return jsonString(node.Value), nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// jsonString quotes s as a JSON string, which is also a valid TOML basic string.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
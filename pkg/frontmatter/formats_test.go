@@ -0,0 +1,137 @@
+package frontmatter_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/stretchr/testify/assert"
+)
+
+const tomlNote = `+++
+title = "Hello"
+date = 2026-10-01
+draft = true
+weight = 3
+tags = ["a", "b"]
+
+[params]
+author = "ana"
++++
+Body
+`
+
+const jsonNote = `;;;
+{
+  "title": "Hello",
+  "count": 2,
+  "tags": ["a"]
+}
+;;;
+Body
+`
+
+func TestTOMLFrontmatter(t *testing.T) {
+	t.Run("Detects TOML frontmatter", func(t *testing.T) {
+		assert.True(t, frontmatter.HasFrontmatter(tomlNote))
+
+		doc, err := frontmatter.ParseDocument(tomlNote)
+		assert.NoError(t, err)
+		assert.Equal(t, frontmatter.FormatTOML, doc.Format())
+		assert.Equal(t, []string{"title", "date", "draft", "weight", "tags", "params"}, doc.Keys())
+		assert.Equal(t, "Body\n", doc.Body())
+
+		author, ok := doc.Get("params", "author")
+		assert.True(t, ok)
+		assert.Equal(t, "ana", author.Value)
+	})
+
+	t.Run("Unchanged note is returned as is", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument(tomlNote)
+		assert.NoError(t, err)
+		assert.Equal(t, tomlNote, doc.String())
+	})
+
+	t.Run("Edits are written back as TOML", func(t *testing.T) {
+		result, err := frontmatter.SetKey(tomlNote, "draft", "false")
+		assert.NoError(t, err)
+		assert.Equal(t, `+++
+title = "Hello"
+date = 2026-10-01
+draft = false
+weight = 3
+tags = ["a", "b"]
+
+[params]
+author = "ana"
++++
+Body
+`, result)
+	})
+
+	t.Run("Delete key", func(t *testing.T) {
+		result, err := frontmatter.DeleteKey(tomlNote, "params")
+		assert.NoError(t, err)
+		assert.Equal(t, "+++\ntitle = \"Hello\"\ndate = 2026-10-01\ndraft = true\nweight = 3\ntags = [\"a\", \"b\"]\n+++\nBody\n", result)
+	})
+
+	t.Run("Null cannot be written", func(t *testing.T) {
+		_, err := frontmatter.SetKey(tomlNote, "draft", "null")
+		assert.Error(t, err)
+	})
+
+	t.Run("Convert to YAML", func(t *testing.T) {
+		result, err := frontmatter.Convert(tomlNote, frontmatter.FormatYAML)
+		assert.NoError(t, err)
+		assert.Equal(t, `---
+title: Hello
+date: 2026-10-01
+draft: true
+weight: 3
+tags:
+  - a
+  - b
+params:
+  author: ana
+---
+Body
+`, result)
+	})
+
+	t.Run("Invalid TOML", func(t *testing.T) {
+		_, err := frontmatter.ParseDocument("+++\ntitle = \n+++\n")
+		assert.EqualError(t, err, frontmatter.InvalidFrontmatterError)
+	})
+}
+
+func TestJSONFrontmatter(t *testing.T) {
+	t.Run("Detects JSON frontmatter", func(t *testing.T) {
+		doc, err := frontmatter.ParseDocument(jsonNote)
+		assert.NoError(t, err)
+		assert.Equal(t, frontmatter.FormatJSON, doc.Format())
+		assert.Equal(t, []string{"title", "count", "tags"}, doc.Keys())
+		assert.Equal(t, jsonNote, doc.String())
+	})
+
+	t.Run("Edits are written back as JSON", func(t *testing.T) {
+		result, err := frontmatter.AddToList(jsonNote, "tags", "b")
+		assert.NoError(t, err)
+		assert.Equal(t, ";;;\n{\n  \"title\": \"Hello\",\n  \"count\": 2,\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}\n;;;\nBody\n", result)
+	})
+
+	t.Run("Convert YAML to JSON and TOML", func(t *testing.T) {
+		yamlNote := "---\ntitle: Hello # greeting\ncount: 2\n---\nBody"
+
+		result, err := frontmatter.Convert(yamlNote, frontmatter.FormatJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, ";;;\n{\n  \"title\": \"Hello\",\n  \"count\": 2\n}\n;;;\nBody", result)
+
+		result, err = frontmatter.Convert(yamlNote, frontmatter.FormatTOML)
+		assert.NoError(t, err)
+		assert.Equal(t, "+++\ntitle = \"Hello\"\ncount = 2\n+++\nBody", result)
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		_, err := frontmatter.Convert(jsonNote, "xml")
+		assert.Error(t, err)
+	})
+}
@@ -1,8 +1,13 @@
 package frontmatter
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adrg/frontmatter"
 	"gopkg.in/yaml.v3"
@@ -37,109 +42,455 @@ func Format(fm map[string]interface{}) (string, error) {
 	return string(data), nil
 }
 
-// HasFrontmatter checks if content starts with frontmatter delimiters.
+// HasFrontmatter checks if content starts with frontmatter delimiters:
+// "---" for YAML, "+++" for TOML or ";;;" for JSON.
 func HasFrontmatter(content string) bool {
 	lines := strings.Split(content, "\n")
 	if len(lines) == 0 {
 		return false
 	}
-	return strings.TrimSpace(lines[0]) == Delimiter
+	return delimiterFormat(lines[0]) != ""
 }
 
 // SetKey updates or adds a key in the frontmatter, returning the full updated content.
 // If no frontmatter exists, it creates new frontmatter with the key.
+// Dotted keys ("project.owner") address nested maps, creating them as needed.
+// Only the lines of the touched key change; the order, comments and style of
+// other keys and the body are preserved.
 func SetKey(content, key, value string) (string, error) {
-	parsedValue := parseValue(value)
+	return PropertyTypes(nil).SetKey(content, key, value)
+}
 
-	if !HasFrontmatter(content) {
-		// Create new frontmatter
-		fm := map[string]interface{}{key: parsedValue}
-		fmStr, err := yaml.Marshal(fm)
+// SetKey is like the package-level SetKey, but coerces value to the key's
+// declared type.
+func (t PropertyTypes) SetKey(content, key, value string) (string, error) {
+	return update(content, func(doc *Document) error {
+		node, err := t.Value(key, value)
 		if err != nil {
-			return "", err
+			return err
 		}
-		return Delimiter + "\n" + string(fmStr) + Delimiter + "\n" + content, nil
+		return doc.Set(doc.ResolvePath(key), node)
+	})
+}
+
+// DeleteKey removes a key from the frontmatter, returning the full updated content.
+// If no keys are left, the frontmatter block is removed.
+func DeleteKey(content, key string) (string, error) {
+	if !HasFrontmatter(content) {
+		return "", errors.New(NoFrontmatterError)
 	}
 
-	// Parse existing frontmatter
-	fm, body, err := Parse(content)
+	doc, err := ParseDocument(content)
 	if err != nil {
 		return "", err
 	}
 
-	if fm == nil {
-		fm = make(map[string]interface{})
+	if doc.Len() == 0 {
+		return "", errors.New(NoFrontmatterError)
 	}
 
-	// Update the key
-	fm[key] = parsedValue
-
-	// Reconstruct content
-	fmStr, err := yaml.Marshal(fm)
-	if err != nil {
+	if _, err := doc.Delete(doc.ResolvePath(key)); err != nil {
 		return "", err
 	}
 
-	return Delimiter + "\n" + string(fmStr) + Delimiter + "\n" + body, nil
+	// If no keys left, return just the body
+	if doc.Len() == 0 {
+		return strings.TrimPrefix(doc.Body(), "\n"), nil
+	}
+
+	return doc.String(), nil
 }
 
-// DeleteKey removes a key from the frontmatter, returning the full updated content.
-func DeleteKey(content, key string) (string, error) {
-	if !HasFrontmatter(content) {
-		return "", errors.New(NoFrontmatterError)
+// RenameKey renames a frontmatter key, keeping its value, position and
+// comments. Renaming a missing key is a no-op; renaming onto an existing key
+// is an error.
+func RenameKey(content, oldKey, newKey string) (string, error) {
+	return update(content, func(doc *Document) error {
+		_, err := doc.Rename(doc.ResolvePath(oldKey), newKey)
+		return err
+	})
+}
+
+// Convert rewrites the frontmatter in another format (yaml, toml or json).
+// Content without frontmatter is returned unchanged.
+func Convert(content, format string) (string, error) {
+	return update(content, func(doc *Document) error {
+		return doc.Convert(format)
+	})
+}
+
+// MergeKeys moves the value of from into into and deletes from. If both keys
+// exist their values are combined into a list of unique items; into keeps its
+// own value when from adds nothing new. Merging a missing key is a no-op.
+func MergeKeys(content, from, into string) (string, error) {
+	return update(content, func(doc *Document) error {
+		fromPath, intoPath := doc.ResolvePath(from), doc.ResolvePath(into)
+		fromValue, ok := doc.Get(fromPath...)
+		if !ok {
+			return nil
+		}
+
+		intoValue, ok := doc.Get(intoPath...)
+		if !ok {
+			if len(fromPath) == 1 && len(intoPath) == 1 {
+				_, err := doc.Rename(fromPath, into)
+				return err
+			}
+			if err := doc.Set(intoPath, cloneNode(fromValue)); err != nil {
+				return err
+			}
+			_, err := doc.Delete(fromPath)
+			return err
+		}
+
+		fromItems, err := listItems(from, fromValue)
+		if err != nil {
+			return err
+		}
+		intoItems, err := listItems(into, intoValue)
+		if err != nil {
+			return err
+		}
+
+		merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: intoItems}
+		for _, item := range fromItems {
+			if indexOf(merged, item) == -1 {
+				merged.Content = append(merged.Content, cloneNode(item))
+			}
+		}
+		if len(merged.Content) > len(intoItems) || (intoValue.Kind == yaml.ScalarNode && len(merged.Content) > 1) {
+			if err := doc.Set(intoPath, merged); err != nil {
+				return err
+			}
+		}
+		_, err = doc.Delete(doc.ResolvePath(from))
+		return err
+	})
+}
+
+// MapValues replaces values of key (or items, for lists) found in mapping,
+// e.g. {"todo": "open"}. Values not in mapping are left alone.
+func MapValues(content, key string, mapping map[string]string) (string, error) {
+	return PropertyTypes(nil).MapValues(content, key, mapping)
+}
+
+// MapValues is like the package-level MapValues, but coerces the new values
+// to the key's declared type.
+func (t PropertyTypes) MapValues(content, key string, mapping map[string]string) (string, error) {
+	return update(content, func(doc *Document) error {
+		path := doc.ResolvePath(key)
+		existing, ok := doc.Get(path...)
+		if !ok {
+			return nil
+		}
+
+		switch existing.Kind {
+		case yaml.ScalarNode:
+			replacement, found := mapping[existing.Value]
+			if !found || existing.ShortTag() == "!!null" {
+				return nil
+			}
+			node, err := t.Value(key, replacement)
+			if err != nil {
+				return err
+			}
+			return doc.Set(path, node)
+
+		case yaml.SequenceNode:
+			list := cloneNode(existing)
+			list.Content = list.Content[:0]
+			changed := false
+			for _, item := range existing.Content {
+				if replacement, found := mapping[item.Value]; found && item.Kind == yaml.ScalarNode {
+					node, err := t.Item(key, replacement)
+					if err != nil {
+						return err
+					}
+					item, changed = node, true
+				}
+				if indexOf(list, item) == -1 {
+					list.Content = append(list.Content, item)
+				}
+			}
+			if !changed {
+				return nil
+			}
+			return doc.Set(path, list)
+		}
+		return nil
+	})
+}
+
+// listItems returns a value as list items: a list's items, a scalar as a
+// single item, or no items for null.
+func listItems(key string, node *yaml.Node) ([]*yaml.Node, error) {
+	switch {
+	case node.Kind == yaml.SequenceNode:
+		return append([]*yaml.Node{}, node.Content...), nil
+	case node.Kind == yaml.ScalarNode && node.Tag == "!!null":
+		return nil, nil
+	case node.Kind == yaml.ScalarNode:
+		return []*yaml.Node{node}, nil
 	}
+	return nil, fmt.Errorf("cannot merge %s: value is a map", key)
+}
 
-	fm, body, err := Parse(content)
-	if err != nil {
+// AddToList appends value to the list at key unless it is already present.
+// A missing key becomes a one-item list and a scalar becomes the first item.
+func AddToList(content, key, value string) (string, error) {
+	return PropertyTypes(nil).AddToList(content, key, value)
+}
+
+// AddToList is like the package-level AddToList, but rejects keys declared
+// with a non-list type and adds items as text to list types.
+func (t PropertyTypes) AddToList(content, key, value string) (string, error) {
+	return update(content, func(doc *Document) error {
+		item, err := t.Item(key, value)
+		if err != nil {
+			return err
+		}
+
+		path := doc.ResolvePath(key)
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if existing, ok := doc.Get(path...); ok {
+			switch {
+			case existing.Kind == yaml.SequenceNode:
+				list = cloneNode(existing)
+			case existing.Kind == yaml.ScalarNode && existing.Tag == "!!null":
+			case existing.Kind == yaml.ScalarNode:
+				list.Content = append(list.Content, cloneNode(existing))
+			default:
+				return fmt.Errorf("cannot add to %s: value is not a list", key)
+			}
+		}
+
+		if indexOf(list, item) != -1 {
+			return nil
+		}
+		list.Content = append(list.Content, item)
+		return doc.Set(path, list)
+	})
+}
+
+// RemoveFromList removes every occurrence of value from the list at key.
+func RemoveFromList(content, key, value string) (string, error) {
+	return PropertyTypes(nil).RemoveFromList(content, key, value)
+}
+
+// RemoveFromList is like the package-level RemoveFromList, but matches items
+// as text for keys declared with a list type.
+func (t PropertyTypes) RemoveFromList(content, key, value string) (string, error) {
+	return update(content, func(doc *Document) error {
+		item, err := t.Item(key, value)
+		if err != nil {
+			return err
+		}
+
+		path := doc.ResolvePath(key)
+		existing, ok := doc.Get(path...)
+		if !ok {
+			return nil
+		}
+		if existing.Kind != yaml.SequenceNode {
+			return fmt.Errorf("cannot remove from %s: value is not a list", key)
+		}
+
+		list := cloneNode(existing)
+		list.Content = list.Content[:0]
+		for _, child := range existing.Content {
+			if !sameValue(child, item) {
+				list.Content = append(list.Content, child)
+			}
+		}
+		if len(list.Content) == len(existing.Content) {
+			return nil
+		}
+		return doc.Set(path, list)
+	})
+}
+
+// ToggleKey flips a boolean key. A missing key is set to true.
+func ToggleKey(content, key string) (string, error) {
+	return PropertyTypes(nil).ToggleKey(content, key)
+}
+
+// ToggleKey is like the package-level ToggleKey, but rejects keys declared
+// with a type other than checkbox.
+func (t PropertyTypes) ToggleKey(content, key string) (string, error) {
+	if err := t.require(key, TypeCheckbox, "toggle"); err != nil {
 		return "", err
 	}
+	return update(content, func(doc *Document) error {
+		path := doc.ResolvePath(key)
+		value := true
+		if existing, ok := doc.Get(path...); ok {
+			var current bool
+			if existing.Kind != yaml.ScalarNode || existing.Tag != "!!bool" || existing.Decode(&current) != nil {
+				return fmt.Errorf("cannot toggle %s: value is not a boolean", key)
+			}
+			value = !current
+		}
+		return doc.Set(path, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)})
+	})
+}
 
-	if fm == nil {
-		return "", errors.New(NoFrontmatterError)
+// IncrementKey adds by to a numeric key. A missing key is set to by.
+func IncrementKey(content, key, by string) (string, error) {
+	return PropertyTypes(nil).IncrementKey(content, key, by)
+}
+
+// IncrementKey is like the package-level IncrementKey, but rejects keys
+// declared with a type other than number.
+func (t PropertyTypes) IncrementKey(content, key, by string) (string, error) {
+	if err := t.require(key, TypeNumber, "increment"); err != nil {
+		return "", err
 	}
+	return update(content, func(doc *Document) error {
+		step, err := parseValue(by)
+		if err != nil {
+			return err
+		}
+		if step.Tag != "!!int" && step.Tag != "!!float" {
+			return fmt.Errorf("cannot increment %s by %q: not a number", key, by)
+		}
 
-	// Delete the key
-	delete(fm, key)
+		path := doc.ResolvePath(key)
+		existing, ok := doc.Get(path...)
+		if !ok {
+			return doc.Set(path, step)
+		}
+		if existing.Kind != yaml.ScalarNode || (existing.Tag != "!!int" && existing.Tag != "!!float") {
+			return fmt.Errorf("cannot increment %s: value is not a number", key)
+		}
 
-	// If no keys left, return just the body
-	if len(fm) == 0 {
-		return strings.TrimPrefix(body, "\n"), nil
-	}
+		if existing.Tag == "!!int" && step.Tag == "!!int" {
+			var current, delta int64
+			if err := existing.Decode(&current); err != nil {
+				return err
+			}
+			if err := step.Decode(&delta); err != nil {
+				return err
+			}
+			return doc.Set(path, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(current+delta, 10)})
+		}
 
-	// Reconstruct content
-	fmStr, err := yaml.Marshal(fm)
+		var current, delta float64
+		if err := existing.Decode(&current); err != nil {
+			return err
+		}
+		if err := step.Decode(&delta); err != nil {
+			return err
+		}
+		return doc.Set(path, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(current+delta, 'f', -1, 64)})
+	})
+}
+
+// update parses content, applies edit and reassembles the note.
+func update(content string, edit func(*Document) error) (string, error) {
+	doc, err := ParseDocument(content)
 	if err != nil {
 		return "", err
 	}
-
-	return Delimiter + "\n" + string(fmStr) + Delimiter + "\n" + body, nil
+	if err := edit(doc); err != nil {
+		return "", err
+	}
+	return doc.String(), nil
 }
 
-// parseValue attempts to parse the value into appropriate Go types.
-// Supports: booleans, arrays (comma-separated in brackets), strings.
-func parseValue(value string) interface{} {
-	// Try boolean
-	if value == "true" {
-		return true
+var (
+	intPattern      = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	floatPattern    = regexp.MustCompile(`^-?(0|[1-9][0-9]*)\.[0-9]+$`)
+	datePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	datetimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2})?$`)
+)
+
+// parseValue converts a command-line value into a typed YAML node.
+// Supports:
+//   - booleans ("true", "false") and null ("null", "~")
+//   - integers and decimals ("3", "-1.5"); leading zeros stay strings ("007")
+//   - ISO dates and datetimes ("2026-10-01", "2026-10-01T09:30")
+//   - JSON values ('{"a": 1}', '["a", "b"]', '"3"' for a quoted string)
+//   - comma-separated lists in brackets ("[one, two]")
+//   - anything else as a string
+func parseValue(value string) (*yaml.Node, error) {
+	scalar := func(tag string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
 	}
-	if value == "false" {
-		return false
+
+	switch {
+	case value == "true" || value == "false":
+		return scalar("!!bool"), nil
+	case value == "null" || value == "~":
+		return scalar("!!null"), nil
+	case intPattern.MatchString(value):
+		return scalar("!!int"), nil
+	case floatPattern.MatchString(value):
+		return scalar("!!float"), nil
+	case datePattern.MatchString(value), datetimePattern.MatchString(value):
+		if _, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			// YAML only recognises datetimes with seconds as timestamps, but
+			// both forms render unquoted like Obsidian's date properties.
+			if len(value) == len("2006-01-02T15:04") {
+				return scalar("!!str"), nil
+			}
+			return scalar("!!timestamp"), nil
+		}
 	}
 
-	// Try array (comma-separated in brackets)
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") || strings.HasPrefix(value, "\"") {
+		if json.Valid([]byte(value)) {
+			// JSON is a subset of YAML, so the node tree keeps key order.
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+				return nil, err
+			}
+			node := doc.Content[0]
+			clearStyle(node)
+			return node, nil
+		}
+	}
+
+	// Comma-separated list in brackets
 	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
-		inner := value[1 : len(value)-1]
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		inner := strings.TrimSpace(value[1 : len(value)-1])
 		if inner == "" {
-			return []string{}
+			return list, nil
 		}
-		parts := strings.Split(inner, ",")
-		result := make([]string, 0, len(parts))
-		for _, p := range parts {
-			result = append(result, strings.TrimSpace(p))
+		for _, part := range strings.Split(inner, ",") {
+			item, err := parseValue(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			list.Content = append(list.Content, item)
 		}
-		return result
+		return list, nil
 	}
 
-	// Default to string
-	return value
+	return scalar("!!str"), nil
+}
+
+// clearStyle drops JSON quoting and flow style so values render like the rest
+// of the frontmatter.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+func indexOf(list, item *yaml.Node) int {
+	for i, child := range list.Content {
+		if sameValue(child, item) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameValue(a, b *yaml.Node) bool {
+	if a.Kind != yaml.ScalarNode || b.Kind != yaml.ScalarNode {
+		return false
+	}
+	return a.Value == b.Value
 }
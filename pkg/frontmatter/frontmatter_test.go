@@ -130,6 +130,13 @@ func TestSetKey(t *testing.T) {
 		assert.Contains(t, result, "- three")
 	})
 
+	t.Run("Preserves order and comments of other keys", func(t *testing.T) {
+		content := "---\nzeta: 1 # last\nalpha: [a, b]\n---\nBody"
+		result, err := frontmatter.SetKey(content, "status", "done")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nzeta: 1 # last\nalpha: [a, b]\nstatus: done\n---\nBody", result)
+	})
+
 	t.Run("Parse empty array value", func(t *testing.T) {
 		content := "---\n---\nBody"
 		result, err := frontmatter.SetKey(content, "tags", "[]")
@@ -138,6 +145,124 @@ func TestSetKey(t *testing.T) {
 	})
 }
 
+func TestSetKeyTypedValues(t *testing.T) {
+	tests := []struct {
+		testName string
+		value    string
+		want     string
+	}{
+		{testName: "Integer", value: "3", want: "n: 3\n"},
+		{testName: "Negative decimal", value: "-1.5", want: "n: -1.5\n"},
+		{testName: "Leading zero stays string", value: "007", want: "n: \"007\"\n"},
+		{testName: "Null", value: "null", want: "n: null\n"},
+		{testName: "Date", value: "2026-10-01", want: "n: 2026-10-01\n"},
+		{testName: "Datetime", value: "2026-10-01T09:30", want: "n: 2026-10-01T09:30\n"},
+		{testName: "Invalid date stays string", value: "2026-13-45", want: "n: 2026-13-45\n"},
+		{testName: "JSON string", value: `"3"`, want: "n: \"3\"\n"},
+		{testName: "JSON list", value: `["a", 2]`, want: "n:\n  - a\n  - 2\n"},
+		{testName: "JSON object keeps key order", value: `{"z": 1, "a": {"b": true}}`, want: "n:\n  z: 1\n  a:\n    b: true\n"},
+		{testName: "Bracket list items are typed", value: "[1, two]", want: "n:\n  - 1\n  - two\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			result, err := frontmatter.SetKey("---\n---\nBody", "n", test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, "---\n"+test.want+"---\nBody", result)
+		})
+	}
+
+	t.Run("Dotted key sets nested value", func(t *testing.T) {
+		result, err := frontmatter.SetKey("---\nproject:\n  name: Apollo\n---\n", "project.owner", "ana")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nproject:\n  name: Apollo\n  owner: ana\n---\n", result)
+	})
+}
+
+func TestListOperations(t *testing.T) {
+	t.Run("Add appends to block list", func(t *testing.T) {
+		result, err := frontmatter.AddToList("---\ntags:\n  - work\n---\n", "tags", "meeting")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags:\n  - work\n  - meeting\n---\n", result)
+	})
+
+	t.Run("Add keeps flow style", func(t *testing.T) {
+		result, err := frontmatter.AddToList("---\ntags: [work]\n---\n", "tags", "meeting")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags: [work, meeting]\n---\n", result)
+	})
+
+	t.Run("Add skips existing item", func(t *testing.T) {
+		content := "---\ntags: [work]\n---\n"
+		result, err := frontmatter.AddToList(content, "tags", "work")
+		assert.NoError(t, err)
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("Add creates list for missing key", func(t *testing.T) {
+		result, err := frontmatter.AddToList("Body", "tags", "meeting")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags:\n  - meeting\n---\nBody", result)
+	})
+
+	t.Run("Add converts scalar to list", func(t *testing.T) {
+		result, err := frontmatter.AddToList("---\ntags: work\n---\n", "tags", "meeting")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags:\n  - work\n  - meeting\n---\n", result)
+	})
+
+	t.Run("Remove drops item", func(t *testing.T) {
+		result, err := frontmatter.RemoveFromList("---\ntags:\n  - draft\n  - work\n---\n", "tags", "draft")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags:\n  - work\n---\n", result)
+	})
+
+	t.Run("Remove from scalar returns error", func(t *testing.T) {
+		_, err := frontmatter.RemoveFromList("---\ntags: draft\n---\n", "tags", "draft")
+		assert.Error(t, err)
+	})
+
+	t.Run("Toggle flips boolean", func(t *testing.T) {
+		result, err := frontmatter.ToggleKey("---\ndraft: true\n---\n", "draft")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ndraft: false\n---\n", result)
+	})
+
+	t.Run("Toggle sets missing key to true", func(t *testing.T) {
+		result, err := frontmatter.ToggleKey("---\na: 1\n---\n", "draft")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\na: 1\ndraft: true\n---\n", result)
+	})
+
+	t.Run("Toggle non-boolean returns error", func(t *testing.T) {
+		_, err := frontmatter.ToggleKey("---\ndraft: maybe\n---\n", "draft")
+		assert.Error(t, err)
+	})
+
+	t.Run("Increment integer", func(t *testing.T) {
+		result, err := frontmatter.IncrementKey("---\nreviews: 2\n---\n", "reviews", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nreviews: 3\n---\n", result)
+	})
+
+	t.Run("Increment by decimal", func(t *testing.T) {
+		result, err := frontmatter.IncrementKey("---\nscore: 2\n---\n", "score", "0.5")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nscore: 2.5\n---\n", result)
+	})
+
+	t.Run("Increment missing key", func(t *testing.T) {
+		result, err := frontmatter.IncrementKey("---\n---\n", "stats.views", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nstats:\n  views: 1\n---\n", result)
+	})
+
+	t.Run("Increment string returns error", func(t *testing.T) {
+		_, err := frontmatter.IncrementKey("---\nreviews: many\n---\n", "reviews", "1")
+		assert.Error(t, err)
+	})
+}
+
 func TestDeleteKey(t *testing.T) {
 	t.Run("Delete existing key", func(t *testing.T) {
 		content := "---\ntitle: Test\nauthor: John\n---\nBody"
@@ -148,6 +273,13 @@ func TestDeleteKey(t *testing.T) {
 		assert.Contains(t, result, "Body")
 	})
 
+	t.Run("Delete preserves order of other keys", func(t *testing.T) {
+		content := "---\nzeta: 1\nauthor: John\nalpha: 2\n---\nBody"
+		result, err := frontmatter.DeleteKey(content, "author")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nzeta: 1\nalpha: 2\n---\nBody", result)
+	})
+
 	t.Run("Delete last key removes frontmatter", func(t *testing.T) {
 		content := "---\ntitle: Test\n---\nBody"
 		result, err := frontmatter.DeleteKey(content, "title")
@@ -170,3 +302,146 @@ func TestDeleteKey(t *testing.T) {
 		assert.Contains(t, result, "title: Test")
 	})
 }
+
+func TestRenameKey(t *testing.T) {
+	t.Run("Rename keeps value, position and comments", func(t *testing.T) {
+		content := "---\ntitle: Test\nstate: draft # legacy\ntags:\n  - a\n---\nBody"
+		result, err := frontmatter.RenameKey(content, "state", "status")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntitle: Test\nstatus: draft # legacy\ntags:\n  - a\n---\nBody", result)
+	})
+
+	t.Run("Rename quoted key", func(t *testing.T) {
+		content := "---\n\"old key\": 1\n---\n"
+		result, err := frontmatter.RenameKey(content, "old key", "new")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\n\"new\": 1\n---\n", result)
+	})
+
+	t.Run("Rename nested key", func(t *testing.T) {
+		content := "---\nproject:\n  lead: ana\n  due: 2026-10-01\n---\n"
+		result, err := frontmatter.RenameKey(content, "project.lead", "owner")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nproject:\n  owner: ana\n  due: 2026-10-01\n---\n", result)
+	})
+
+	t.Run("Rename key in flow map", func(t *testing.T) {
+		content := "---\nproject: {lead: ana}\n---\n"
+		result, err := frontmatter.RenameKey(content, "project.lead", "owner")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nproject: {owner: ana}\n---\n", result)
+	})
+
+	t.Run("Rename key after non-ASCII text on its line", func(t *testing.T) {
+		content := "---\n{clé: 1, state: 2}\n---\n"
+		result, err := frontmatter.RenameKey(content, "state", "status")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\n{clé: 1, status: 2}\n---\n", result)
+	})
+
+	t.Run("Missing key is a no-op", func(t *testing.T) {
+		content := "---\ntitle: Test\n---\nBody"
+		result, err := frontmatter.RenameKey(content, "state", "status")
+		assert.NoError(t, err)
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("Existing target key is an error", func(t *testing.T) {
+		content := "---\nstate: draft\nstatus: done\n---\n"
+		_, err := frontmatter.RenameKey(content, "state", "status")
+		assert.Error(t, err)
+	})
+}
+
+func TestMergeKeys(t *testing.T) {
+	t.Run("Missing target renames the key in place", func(t *testing.T) {
+		content := "---\ntag: a\ntitle: Test\n---\nBody"
+		result, err := frontmatter.MergeKeys(content, "tag", "tags")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags: a\ntitle: Test\n---\nBody", result)
+	})
+
+	t.Run("Both keys are combined into a list of unique items", func(t *testing.T) {
+		content := "---\ntag: [a, b]\ntags:\n  - b\n  - c\n---\n"
+		result, err := frontmatter.MergeKeys(content, "tag", "tags")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags:\n  - b\n  - c\n  - a\n---\n", result)
+	})
+
+	t.Run("Scalars are combined into a list", func(t *testing.T) {
+		content := "---\nauthor: ana\nauthors: bo\n---\n"
+		result, err := frontmatter.MergeKeys(content, "author", "authors")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nauthors:\n  - bo\n  - ana\n---\n", result)
+	})
+
+	t.Run("Target keeps its value when nothing is added", func(t *testing.T) {
+		content := "---\nauthor: ana\nauthors: ana # lead\n---\n"
+		result, err := frontmatter.MergeKeys(content, "author", "authors")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nauthors: ana # lead\n---\n", result)
+	})
+
+	t.Run("Missing source is a no-op", func(t *testing.T) {
+		content := "---\ntags: [a]\n---\n"
+		result, err := frontmatter.MergeKeys(content, "tag", "tags")
+		assert.NoError(t, err)
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("Maps cannot be merged", func(t *testing.T) {
+		content := "---\nowner: {name: ana}\nowners: [bo]\n---\n"
+		_, err := frontmatter.MergeKeys(content, "owner", "owners")
+		assert.Error(t, err)
+	})
+}
+
+func TestMapValues(t *testing.T) {
+	mapping := map[string]string{"todo": "open", "doing": "active"}
+
+	t.Run("Scalar value is replaced", func(t *testing.T) {
+		content := "---\nstatus: todo\n---\nBody"
+		result, err := frontmatter.MapValues(content, "status", mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nstatus: open\n---\nBody", result)
+	})
+
+	t.Run("List items are replaced and deduplicated", func(t *testing.T) {
+		content := "---\nstatus: [todo, open, doing]\n---\n"
+		result, err := frontmatter.MapValues(content, "status", mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, "---\nstatus: [open, active]\n---\n", result)
+	})
+
+	t.Run("Unmapped values are left alone", func(t *testing.T) {
+		content := "---\nstatus: done # keep\n---\n"
+		result, err := frontmatter.MapValues(content, "status", mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("New values are coerced to the declared type", func(t *testing.T) {
+		types := frontmatter.PropertyTypes{"priority": "number"}
+		result, err := types.MapValues("---\npriority: high\n---\n", "priority", map[string]string{"high": "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "---\npriority: 1\n---\n", result)
+
+		_, err = types.MapValues("---\npriority: high\n---\n", "priority", map[string]string{"high": "urgent"})
+		assert.Error(t, err)
+	})
+}
+
+func TestValueType(t *testing.T) {
+	doc, err := frontmatter.ParseDocument("---\na: text\nb: 3\nc: true\nd: 2026-10-19\ne: 2026-10-19T09:30\nf: [x]\ng: {x: 1}\nh:\n---\n")
+	assert.NoError(t, err)
+
+	want := map[string]string{
+		"a": "text", "b": "number", "c": "checkbox", "d": "date",
+		"e": "datetime", "f": "list", "g": "map", "h": "empty",
+	}
+	for key, expected := range want {
+		value, ok := doc.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, expected, frontmatter.ValueType(value), key)
+	}
+}
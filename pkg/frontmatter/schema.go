@@ -0,0 +1,266 @@
+package frontmatter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaConfigPath is the vault-relative path of the property schema config.
+const SchemaConfigPath = ".notesmd/schema.yaml"
+
+// Property types understood by schemas. "list" also accepts Obsidian's
+// "multitext", "tags" and "aliases", and "boolean" is an alias of "checkbox".
+const (
+	TypeText     = "text"
+	TypeNumber   = "number"
+	TypeCheckbox = "checkbox"
+	TypeDate     = "date"
+	TypeDatetime = "datetime"
+	TypeList     = "list"
+)
+
+// Problem kinds reported by Schema.Validate.
+const (
+	ProblemMissing = "missing"
+	ProblemType    = "type"
+	ProblemEnum    = "enum"
+	ProblemUnknown = "unknown"
+	ProblemInvalid = "invalid"
+)
+
+// SchemaConfig is the contents of .notesmd/schema.yaml.
+type SchemaConfig struct {
+	Schemas []Schema `yaml:"schemas"`
+}
+
+// Schema declares the properties of notes in a folder (and its subfolders).
+// An empty folder applies to the whole vault.
+type Schema struct {
+	Folder       string                    `yaml:"folder"`
+	Properties   map[string]PropertySchema `yaml:"properties"`
+	AllowUnknown bool                      `yaml:"allowUnknown"`
+}
+
+// PropertySchema declares a single property.
+type PropertySchema struct {
+	Type     string   `yaml:"type"`
+	Required bool     `yaml:"required"`
+	Enum     []string `yaml:"enum"`
+}
+
+// Problem is a single schema violation in a note.
+type Problem struct {
+	Property string `json:"property"`
+	Kind     string `json:"problem"`
+	Message  string `json:"message"`
+}
+
+// LoadSchemaConfig reads .notesmd/schema.yaml from the vault. A missing file
+// yields an empty config; an unreadable or invalid one is an error.
+func LoadSchemaConfig(vaultPath string) (SchemaConfig, error) {
+	var config SchemaConfig
+	data, err := os.ReadFile(filepath.Join(vaultPath, filepath.FromSlash(SchemaConfigPath)))
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid %s: %w", SchemaConfigPath, err)
+	}
+	for _, schema := range config.Schemas {
+		for name, property := range schema.Properties {
+			if !knownType(property.Type) {
+				return config, fmt.Errorf("invalid %s: property '%s' in folder '%s' has unknown type '%s'", SchemaConfigPath, name, schema.Folder, property.Type)
+			}
+		}
+	}
+	return config, nil
+}
+
+// ForNote returns the schema of the most specific folder containing relPath,
+// or nil if no schema applies.
+func (c SchemaConfig) ForNote(relPath string) *Schema {
+	relPath = filepath.ToSlash(relPath)
+	var best *Schema
+	bestLen := -1
+	for i := range c.Schemas {
+		folder := strings.Trim(filepath.ToSlash(c.Schemas[i].Folder), "/")
+		if folder != "" && !strings.HasPrefix(relPath, folder+"/") {
+			continue
+		}
+		if len(folder) > bestLen {
+			best, bestLen = &c.Schemas[i], len(folder)
+		}
+	}
+	return best
+}
+
+// Validate checks parsed frontmatter (as returned by Parse) against the
+// schema. Problems are sorted by property name.
+func (s *Schema) Validate(fm map[string]interface{}) []Problem {
+	var problems []Problem
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := s.Properties[name]
+		value, ok := fm[name]
+		if !ok || value == nil {
+			if property.Required {
+				problems = append(problems, Problem{name, ProblemMissing, fmt.Sprintf("missing required property '%s'", name)})
+			}
+			continue
+		}
+		if !matchesType(property.Type, value) {
+			problems = append(problems, Problem{name, ProblemType, fmt.Sprintf("property '%s' should be a %s, got %s", name, property.Type, describe(value))})
+			continue
+		}
+		if len(property.Enum) > 0 {
+			for _, item := range enumValues(value) {
+				if !contains(property.Enum, item) {
+					problems = append(problems, Problem{name, ProblemEnum, fmt.Sprintf("property '%s' has value '%s', expected one of: %s", name, item, strings.Join(property.Enum, ", "))})
+				}
+			}
+		}
+	}
+
+	if !s.AllowUnknown {
+		var unknown []string
+		for name := range fm {
+			if _, ok := s.Properties[name]; !ok {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			problems = append(problems, Problem{name, ProblemUnknown, fmt.Sprintf("unknown property '%s'", name)})
+		}
+	}
+
+	return problems
+}
+
+func knownType(t string) bool {
+	switch normalizeType(t) {
+	case "", TypeText, TypeNumber, TypeCheckbox, TypeDate, TypeDatetime, TypeList:
+		return true
+	}
+	return false
+}
+
+func normalizeType(t string) string {
+	switch strings.ToLower(t) {
+	case "boolean", "bool":
+		return TypeCheckbox
+	case "multitext", "tags", "aliases":
+		return TypeList
+	}
+	return strings.ToLower(t)
+}
+
+// matchesType reports whether a value decoded by Parse has the given type.
+// An empty type accepts anything.
+func matchesType(t string, value interface{}) bool {
+	switch normalizeType(t) {
+	case "":
+		return true
+	case TypeText:
+		_, ok := value.(string)
+		return ok
+	case TypeNumber:
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		}
+		return false
+	case TypeCheckbox:
+		_, ok := value.(bool)
+		return ok
+	case TypeDate:
+		if t, ok := value.(time.Time); ok {
+			return !hasTimeOfDay(t)
+		}
+		return isTime(value, "2006-01-02")
+	case TypeDatetime:
+		return isTime(value, "2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02 15:04:05")
+	case TypeList:
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return false
+}
+
+func isTime(value interface{}, layouts ...string) bool {
+	switch v := value.(type) {
+	case time.Time:
+		return true
+	case string:
+		for _, layout := range layouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasTimeOfDay reports whether t is not at midnight, i.e. holds a datetime
+// rather than a date.
+func hasTimeOfDay(t time.Time) bool {
+	return t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0
+}
+
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		if hasTimeOfDay(v) {
+			return "datetime"
+		}
+		return "date"
+	case string:
+		return "text"
+	case int, int64, uint64, float64:
+		return "number"
+	case bool:
+		return "checkbox"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func enumValues(value interface{}) []string {
+	if list, ok := value.([]interface{}); ok {
+		var items []string
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+		return items
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
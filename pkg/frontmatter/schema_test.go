@@ -0,0 +1,109 @@
+package frontmatter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSchema(t *testing.T, vaultPath, content string) {
+	t.Helper()
+	path := filepath.Join(vaultPath, ".notesmd", "schema.yaml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadSchemaConfig(t *testing.T) {
+	t.Run("Missing file yields empty config", func(t *testing.T) {
+		config, err := frontmatter.LoadSchemaConfig(t.TempDir())
+		assert.NoError(t, err)
+		assert.Empty(t, config.Schemas)
+	})
+
+	t.Run("Reads schemas", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeSchema(t, vaultPath, "schemas:\n  - folder: Meetings\n    properties:\n      date: {type: date, required: true}\n")
+
+		config, err := frontmatter.LoadSchemaConfig(vaultPath)
+		assert.NoError(t, err)
+		assert.Len(t, config.Schemas, 1)
+		assert.Equal(t, frontmatter.PropertySchema{Type: "date", Required: true}, config.Schemas[0].Properties["date"])
+	})
+
+	t.Run("Unknown type is an error", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		writeSchema(t, vaultPath, "schemas:\n  - properties:\n      date: {type: when}\n")
+
+		_, err := frontmatter.LoadSchemaConfig(vaultPath)
+		assert.Error(t, err)
+	})
+}
+
+func TestSchemaConfig_ForNote(t *testing.T) {
+	config := frontmatter.SchemaConfig{Schemas: []frontmatter.Schema{
+		{Folder: ""},
+		{Folder: "Meetings"},
+		{Folder: "Meetings/1on1/"},
+	}}
+
+	assert.Equal(t, "", config.ForNote("inbox.md").Folder)
+	assert.Equal(t, "Meetings", config.ForNote("Meetings/sync.md").Folder)
+	assert.Equal(t, "Meetings/1on1/", config.ForNote("Meetings/1on1/ana.md").Folder)
+	assert.Equal(t, "", config.ForNote("MeetingsArchive/old.md").Folder)
+	assert.Nil(t, frontmatter.SchemaConfig{Schemas: []frontmatter.Schema{{Folder: "Meetings"}}}.ForNote("inbox.md"))
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := frontmatter.Schema{Properties: map[string]frontmatter.PropertySchema{
+		"date":      {Type: "date", Required: true},
+		"attendees": {Type: "list", Required: true},
+		"status":    {Type: "text", Enum: []string{"planned", "done"}},
+		"duration":  {Type: "number"},
+		"tags":      {Type: "tags"},
+	}}
+
+	t.Run("Valid note has no problems", func(t *testing.T) {
+		fm, _, _ := frontmatter.Parse("---\ndate: 2026-10-01\nattendees: [ana]\nstatus: done\nduration: 30\ntags: [meeting]\n---\n")
+		assert.Empty(t, schema.Validate(fm))
+	})
+
+	t.Run("Reports missing, mistyped, enum and unknown properties", func(t *testing.T) {
+		fm, _, _ := frontmatter.Parse("---\ndate: soon\nstatus: started\nduration: half an hour\nroom: 4\n---\n")
+
+		assert.Equal(t, []frontmatter.Problem{
+			{Property: "attendees", Kind: frontmatter.ProblemMissing, Message: "missing required property 'attendees'"},
+			{Property: "date", Kind: frontmatter.ProblemType, Message: "property 'date' should be a date, got text"},
+			{Property: "duration", Kind: frontmatter.ProblemType, Message: "property 'duration' should be a number, got text"},
+			{Property: "status", Kind: frontmatter.ProblemEnum, Message: "property 'status' has value 'started', expected one of: planned, done"},
+			{Property: "room", Kind: frontmatter.ProblemUnknown, Message: "unknown property 'room'"},
+		}, schema.Validate(fm))
+	})
+
+	t.Run("Unknown properties allowed", func(t *testing.T) {
+		lenient := frontmatter.Schema{AllowUnknown: true}
+		fm, _, _ := frontmatter.Parse("---\nroom: 4\n---\n")
+		assert.Empty(t, lenient.Validate(fm))
+	})
+
+	t.Run("TOML date is a date", func(t *testing.T) {
+		fm, _, _ := frontmatter.Parse("+++\ndate = 2026-10-01\nattendees = [\"ana\"]\n+++\n")
+		assert.Empty(t, schema.Validate(fm))
+	})
+
+	t.Run("TOML datetime is not a date", func(t *testing.T) {
+		fm, _, _ := frontmatter.Parse("+++\ndate = 2026-10-01T09:30:00Z\nattendees = [\"ana\"]\n+++\n")
+		assert.Equal(t, []frontmatter.Problem{
+			{Property: "date", Kind: frontmatter.ProblemType, Message: "property 'date' should be a date, got datetime"},
+		}, schema.Validate(fm))
+	})
+
+	t.Run("Empty required property is missing", func(t *testing.T) {
+		fm, _, _ := frontmatter.Parse("---\ndate:\nattendees: [ana]\n---\n")
+		problems := schema.Validate(fm)
+		assert.Len(t, problems, 1)
+		assert.Equal(t, frontmatter.ProblemMissing, problems[0].Kind)
+	})
+}
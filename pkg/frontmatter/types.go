@@ -0,0 +1,151 @@
+package frontmatter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PropertyTypes maps property names to the types Obsidian declares for them in
+// .obsidian/types.json (text, number, checkbox, date, datetime, multitext,
+// aliases, tags). Edits made through a PropertyTypes coerce values to the
+// declared type and reject values that cannot be coerced. Undeclared
+// properties, and a nil PropertyTypes, fall back to inferring the type from
+// the value.
+type PropertyTypes map[string]string
+
+// Value converts a command-line value for key into a YAML node of the key's
+// declared type.
+func (t PropertyTypes) Value(key, value string) (*yaml.Node, error) {
+	declared := t[key]
+	scalar := func(tag, v string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v}
+	}
+
+	switch normalizeType(declared) {
+	case TypeText:
+		return scalar("!!str", value), nil
+
+	case TypeNumber:
+		trimmed := strings.TrimSpace(value)
+		if i, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return scalar("!!int", strconv.FormatInt(i, 10)), nil
+		}
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return scalar("!!float", strconv.FormatFloat(f, 'f', -1, 64)), nil
+		}
+
+	case TypeCheckbox:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "true", "yes":
+			return scalar("!!bool", "true"), nil
+		case "false", "no":
+			return scalar("!!bool", "false"), nil
+		}
+
+	case TypeDate:
+		trimmed := strings.TrimSpace(value)
+		if _, err := time.Parse("2006-01-02", trimmed); err == nil {
+			return scalar("!!timestamp", trimmed), nil
+		}
+
+	case TypeDatetime:
+		trimmed := strings.Replace(strings.TrimSpace(value), " ", "T", 1)
+		if _, err := time.Parse("2006-01-02", trimmed); err == nil {
+			return scalar("!!str", trimmed+"T00:00"), nil
+		}
+		if _, err := time.Parse("2006-01-02T15:04", trimmed); err == nil {
+			return scalar("!!str", trimmed), nil
+		}
+		if _, err := time.Parse("2006-01-02T15:04:05", trimmed); err == nil {
+			return scalar("!!timestamp", trimmed), nil
+		}
+
+	case TypeList:
+		parsed, err := parseValue(value)
+		if err != nil {
+			return nil, err
+		}
+		items := []*yaml.Node{parsed}
+		if parsed.Kind == yaml.SequenceNode {
+			items = parsed.Content
+		}
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range items {
+			if item.Kind != yaml.ScalarNode {
+				return nil, t.coerceError(key, value)
+			}
+			text, _ := t.Item(key, item.Value)
+			list.Content = append(list.Content, text)
+		}
+		return list, nil
+
+	default:
+		return parseValue(value)
+	}
+
+	return nil, t.coerceError(key, value)
+}
+
+// Item converts a command-line value into an item of the list at key. Items
+// of declared list properties are always text; "#" is stripped from tags.
+func (t PropertyTypes) Item(key, value string) (*yaml.Node, error) {
+	declared := t[key]
+	switch normalizeType(declared) {
+	case "":
+		return parseValue(value)
+	case TypeList:
+		if strings.ToLower(declared) == "tags" {
+			value = strings.TrimPrefix(value, "#")
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+	return nil, fmt.Errorf("cannot use %s as a list: property is declared as %s in .obsidian/types.json", key, declared)
+}
+
+// require rejects an operation on key when it is declared with another type.
+func (t PropertyTypes) require(key, want, operation string) error {
+	declared := t[key]
+	if declared == "" || normalizeType(declared) == want {
+		return nil
+	}
+	return fmt.Errorf("cannot %s %s: property is declared as %s in .obsidian/types.json", operation, key, declared)
+}
+
+func (t PropertyTypes) coerceError(key, value string) error {
+	return fmt.Errorf("cannot set %s to %q: property is declared as %s in .obsidian/types.json", key, value, t[key])
+}
+
+// ValueType describes the type of a value the way Obsidian's properties view
+// does: text, number, checkbox, date, datetime, list, map or empty.
+func ValueType(node *yaml.Node) string {
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return TypeList
+	case yaml.MappingNode:
+		return "map"
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return "empty"
+	case "!!bool":
+		return TypeCheckbox
+	case "!!int", "!!float":
+		return TypeNumber
+	case "!!timestamp":
+		if len(node.Value) == len("2006-01-02") {
+			return TypeDate
+		}
+		return TypeDatetime
+	}
+	if datetimePattern.MatchString(node.Value) {
+		return TypeDatetime
+	}
+	return TypeText
+}
@@ -0,0 +1,97 @@
+package frontmatter_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/frontmatter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyTypes_SetKey(t *testing.T) {
+	types := frontmatter.PropertyTypes{
+		"title":    "text",
+		"priority": "number",
+		"done":     "checkbox",
+		"due":      "date",
+		"start":    "datetime",
+		"tags":     "tags",
+		"aliases":  "aliases",
+	}
+
+	tests := []struct {
+		testName string
+		key      string
+		value    string
+		want     string
+	}{
+		{testName: "Text stays a string", key: "title", value: "42", want: "title: \"42\"\n"},
+		{testName: "Number", key: "priority", value: "3", want: "priority: 3\n"},
+		{testName: "Number with leading zero", key: "priority", value: "007", want: "priority: 7\n"},
+		{testName: "Decimal number", key: "priority", value: "1.50", want: "priority: 1.5\n"},
+		{testName: "Checkbox", key: "done", value: "Yes", want: "done: true\n"},
+		{testName: "Date", key: "due", value: "2026-10-01", want: "due: 2026-10-01\n"},
+		{testName: "Datetime from date", key: "start", value: "2026-10-01", want: "start: 2026-10-01T00:00\n"},
+		{testName: "Datetime with space", key: "start", value: "2026-10-01 09:30", want: "start: 2026-10-01T09:30\n"},
+		{testName: "Single tag becomes a list", key: "tags", value: "#meeting", want: "tags:\n  - meeting\n"},
+		{testName: "List items are text", key: "aliases", value: "[1, two]", want: "aliases:\n  - \"1\"\n  - two\n"},
+		{testName: "Undeclared key is inferred", key: "count", value: "3", want: "count: 3\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			result, err := types.SetKey("", test.key, test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, "---\n"+test.want+"---\n", result)
+		})
+	}
+
+	rejected := []struct {
+		testName string
+		key      string
+		value    string
+	}{
+		{testName: "Number", key: "priority", value: "high"},
+		{testName: "Checkbox", key: "done", value: "maybe"},
+		{testName: "Date", key: "due", value: "tomorrow"},
+		{testName: "Datetime", key: "start", value: "09:30"},
+		{testName: "Nested list", key: "tags", value: `[["a"]]`},
+	}
+
+	for _, test := range rejected {
+		t.Run("Rejects invalid "+test.testName, func(t *testing.T) {
+			_, err := types.SetKey("", test.key, test.value)
+			assert.ErrorContains(t, err, "declared as")
+		})
+	}
+}
+
+func TestPropertyTypes_ListAndScalarOperations(t *testing.T) {
+	types := frontmatter.PropertyTypes{"tags": "tags", "priority": "number", "title": "text"}
+
+	t.Run("Tags are added as text without #", func(t *testing.T) {
+		result, err := types.AddToList("---\ntags: [a]\n---\n", "tags", "#2026")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\ntags: [a, \"2026\"]\n---\n", result)
+	})
+
+	t.Run("Cannot add to a non-list property", func(t *testing.T) {
+		_, err := types.AddToList("---\npriority: 1\n---\n", "priority", "2")
+		assert.Error(t, err)
+	})
+
+	t.Run("Cannot toggle a non-checkbox property", func(t *testing.T) {
+		_, err := types.ToggleKey("---\ntitle: x\n---\n", "title")
+		assert.Error(t, err)
+	})
+
+	t.Run("Cannot increment a non-number property", func(t *testing.T) {
+		_, err := types.IncrementKey("---\ntitle: x\n---\n", "title", "1")
+		assert.Error(t, err)
+	})
+
+	t.Run("Increment declared number", func(t *testing.T) {
+		result, err := types.IncrementKey("---\npriority: 1\n---\n", "priority", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "---\npriority: 2\n---\n", result)
+	})
+}
@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	blockIDPattern  = regexp.MustCompile(`(?:^|\s)\^([A-Za-z0-9-]+)\s*$`)
+	listItemPattern = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])(?:\s|$)`)
+)
+
+// BlockID returns the block ID ("^abc123") that ends line, without the caret.
+func BlockID(line string) (string, bool) {
+	m := blockIDPattern.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// TrimBlockID removes a block ID from the end of line.
+func TrimBlockID(line string) string {
+	if loc := blockIDPattern.FindStringIndex(line); loc != nil {
+		return strings.TrimRight(line[:loc[0]], " \t")
+	}
+	return line
+}
+
+// FindBlock locates the block tagged with id ("abc123" or "^abc123") and
+// returns the zero-based index of its first line and the index one past its
+// last line. As in Obsidian, the ID either ends the last line of a paragraph,
+// ends a list item's line (the item's nested lines belong to the block), or
+// stands on its own line after a list, quote, table or code block. In the
+// last case the ID line is not part of the block.
+func FindBlock(lines []string, id string) (int, int, bool) {
+	id = strings.TrimPrefix(id, "^")
+	inFence := false
+	for i := BodyStart(lines); i < len(lines); i++ {
+		line := lines[i]
+		if isFence(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if found, ok := BlockID(line); !ok || found != id {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "^"+id {
+			end := i
+			for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+				end--
+			}
+			if end == 0 {
+				return 0, 0, false
+			}
+			return previousBlockStart(lines, end), end, true
+		}
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			return i, listItemEnd(lines, i, len(m[1])), true
+		}
+
+		start := i
+		for start > 0 {
+			prev := lines[start-1]
+			if strings.TrimSpace(prev) == "" || isFence(prev) || start-1 < BodyStart(lines) {
+				break
+			}
+			if _, ok := ParseHeading(prev); ok {
+				break
+			}
+			start--
+			if listItemPattern.MatchString(prev) {
+				break
+			}
+		}
+		return start, i + 1, true
+	}
+	return 0, 0, false
+}
+
+// previousBlockStart finds the first line of the block that ends just before
+// end: a fenced code block, or the run of non-blank lines before end.
+func previousBlockStart(lines []string, end int) int {
+	if isFence(lines[end-1]) {
+		for start := end - 2; start >= 0; start-- {
+			if isFence(lines[start]) {
+				return start
+			}
+		}
+	}
+	start := end - 1
+	for start > BodyStart(lines) && strings.TrimSpace(lines[start-1]) != "" {
+		if _, ok := ParseHeading(lines[start-1]); ok {
+			break
+		}
+		start--
+	}
+	return start
+}
+
+// listItemEnd returns the index one past the last line of the list item at
+// start, including nested items and continuation lines indented deeper than
+// the item's marker.
+func listItemEnd(lines []string, start, indent int) int {
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(line)-len(strings.TrimLeft(line, " \t")) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
@@ -0,0 +1,76 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindBlock(t *testing.T) {
+	lines := strings.Split(`---
+id: ^notablock
+---
+# Notes
+First line of a paragraph
+that ends with an ID ^para1
+
+- item one ^item1
+  - nested
+    continued
+- item two ^item2
+
+> quoted
+> text
+
+^quote1
+
+| a | b |
+| - | - |
+
+^table1
+
+`+"```"+`
+code ^notablock
+`+"```"+`
+
+^code1`, "\n")
+
+	tests := []struct {
+		id         string
+		start, end int
+	}{
+		{id: "para1", start: 4, end: 6},
+		{id: "^item1", start: 7, end: 10},
+		{id: "item2", start: 10, end: 11},
+		{id: "quote1", start: 12, end: 14},
+		{id: "table1", start: 17, end: 19},
+		{id: "code1", start: 22, end: 25},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			start, end, ok := markdown.FindBlock(lines, test.id)
+			assert.True(t, ok)
+			assert.Equal(t, test.start, start)
+			assert.Equal(t, test.end, end)
+		})
+	}
+
+	t.Run("IDs in frontmatter and code are ignored", func(t *testing.T) {
+		_, _, ok := markdown.FindBlock(lines, "notablock")
+		assert.False(t, ok)
+	})
+}
+
+func TestBlockID(t *testing.T) {
+	id, ok := markdown.BlockID("- [ ] task ^abc-123")
+	assert.True(t, ok)
+	assert.Equal(t, "abc-123", id)
+
+	_, ok = markdown.BlockID("a footnote[^1]")
+	assert.False(t, ok)
+
+	assert.Equal(t, "- [ ] task", markdown.TrimBlockID("- [ ] task ^abc-123"))
+	assert.Equal(t, "no id", markdown.TrimBlockID("no id"))
+}
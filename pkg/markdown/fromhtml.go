@@ -0,0 +1,514 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions controls how FromHTML converts images and links. A function
+// that returns "" leaves the default Markdown in place.
+type HTMLOptions struct {
+	// Image returns the Markdown for an image. By default it is ![alt](src).
+	Image func(src, alt string) string
+	// Link returns the Markdown for a link with text. By default it is
+	// [text](href).
+	Link func(href, text string) string
+}
+
+// HTMLDocument is an HTML page converted to Markdown.
+type HTMLDocument struct {
+	Title    string // the <title>, or the first <h1>
+	Source   string // the page's canonical or "saved from" URL, if recorded
+	Markdown string
+}
+
+// skippedElements have no content worth keeping in a note.
+var skippedElements = set("head", "title", "script", "style", "noscript", "template", "svg", "canvas", "iframe", "object",
+	"button", "select", "textarea", "input")
+
+// blockElements start a new block in Markdown.
+var blockElements = set("address", "article", "aside", "blockquote", "body", "center", "details", "dialog", "dd", "div",
+	"dl", "dt", "fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header",
+	"hgroup", "hr", "html", "li", "main", "nav", "ol", "p", "pre", "section", "summary", "table", "ul")
+
+var (
+	savedFromPattern   = regexp.MustCompile(`saved from url=\(\d+\)(\S+)`)
+	languageClassRegex = regexp.MustCompile(`(?:^|\s)(?:language|lang)-([\w+#.-]+)`)
+	brushPattern       = regexp.MustCompile(`brush:\s*([\w+#.-]+)`)
+	spacePattern       = regexp.MustCompile(`[ \t\r\n\f]+`)
+	listMarkerPattern  = regexp.MustCompile(`^(\d+)([.)])(\s|$)`)
+	spacesPattern      = regexp.MustCompile(` {2,}`)
+)
+
+// FromHTML converts an HTML page to Obsidian-flavoured Markdown: headings,
+// paragraphs, emphasis, highlights, lists and task lists, block quotes, code,
+// tables, links and images. Scripts, styles and form controls are dropped,
+// and other markup is reduced to its text.
+func FromHTML(src string, opts HTMLOptions) HTMLDocument {
+	doc := parseHTML(src)
+	c := converter{opts: opts}
+	root := doc
+	if body := doc.find("body"); body != nil {
+		root = body
+	}
+
+	result := HTMLDocument{Source: pageSource(doc)}
+	if title := doc.find("title"); title != nil {
+		result.Title = collapseSpace(title.text())
+	}
+	if h1 := root.find("h1"); result.Title == "" && h1 != nil {
+		result.Title = collapseSpace(h1.text())
+	}
+	if markdown := c.blocks(root); markdown != "" {
+		result.Markdown = markdown + "\n"
+	}
+	return result
+}
+
+// pageSource finds the URL a saved page came from.
+func pageSource(doc *htmlNode) string {
+	var source string
+	var walk func(n *htmlNode) bool
+	walk = func(n *htmlNode) bool {
+		switch {
+		case n.Tag == "link" && strings.EqualFold(n.attr("rel"), "canonical") && n.attr("href") != "":
+			source = n.attr("href")
+			return true
+		case n.Tag == "meta" && n.attr("property") == "og:url" && n.attr("content") != "":
+			source = n.attr("content")
+			return true
+		case n.Comment && source == "":
+			if m := savedFromPattern.FindStringSubmatch(n.Text); m != nil {
+				source = m[1]
+			}
+		}
+		for _, child := range n.Children {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(doc)
+	return source
+}
+
+type converter struct {
+	opts HTMLOptions
+}
+
+// block is a rendered Markdown block; lists are kept apart so that a nested
+// list can follow its item's text without a blank line.
+type block struct {
+	text string
+	list bool
+}
+
+// blocks renders the children of n as Markdown blocks separated by blank
+// lines.
+func (c *converter) blocks(n *htmlNode) string {
+	return joinBlocks(c.blockList(n), false)
+}
+
+func joinBlocks(blocks []block, tight bool) string {
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			if tight && b.list {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(b.text)
+	}
+	return sb.String()
+}
+
+func (c *converter) blockList(n *htmlNode) []block {
+	var blocks []block
+	var inline strings.Builder
+	flush := func() {
+		if text := paragraph(inline.String()); text != "" {
+			blocks = append(blocks, block{text: text})
+		}
+		inline.Reset()
+	}
+
+	for _, child := range n.Children {
+		if child.Comment || skippedElements[child.Tag] {
+			continue
+		}
+		if !blockElements[child.Tag] {
+			inline.WriteString(c.inline(child))
+			continue
+		}
+		flush()
+		if text := c.block(child); text != "" {
+			blocks = append(blocks, block{text: text, list: child.Tag == "ul" || child.Tag == "ol"})
+		}
+	}
+	flush()
+	return blocks
+}
+
+func (c *converter) block(n *htmlNode) string {
+	switch n.Tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := strings.ReplaceAll(paragraph(c.inlineChildren(n)), "\n", " ")
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", int(n.Tag[1]-'0')) + " " + text
+	case "hr":
+		return "---"
+	case "pre":
+		return c.codeBlock(n)
+	case "blockquote":
+		return prefixLines(c.blocks(n), "> ", ">")
+	case "ul", "ol":
+		return c.list(n)
+	case "table":
+		return c.table(n)
+	case "dt":
+		if text := paragraph(c.inlineChildren(n)); text != "" {
+			return emphasize(text, "**")
+		}
+		return ""
+	}
+	return c.blocks(n)
+}
+
+func (c *converter) codeBlock(n *htmlNode) string {
+	code := n.text()
+	language := codeLanguage(n)
+	if inner := n.find("code"); inner != nil && language == "" {
+		language = codeLanguage(inner)
+	}
+	code = strings.TrimPrefix(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	code = strings.TrimRight(code, "\n ")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// codeLanguage reads a code block's language from its class ("language-go",
+// "lang-go") or Confluence's syntax highlighter parameters ("brush: go").
+func codeLanguage(n *htmlNode) string {
+	if m := languageClassRegex.FindStringSubmatch(n.attr("class")); m != nil {
+		return m[1]
+	}
+	for _, attr := range []string{"data-syntaxhighlighter-params", "class"} {
+		if m := brushPattern.FindStringSubmatch(n.attr(attr)); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func (c *converter) list(n *htmlNode) string {
+	number := 1
+	if start, err := strconv.Atoi(n.attr("start")); err == nil {
+		number = start
+	}
+
+	var items []string
+	for _, child := range n.Children {
+		if child.Tag == "ul" || child.Tag == "ol" {
+			// A list directly inside a list belongs to the previous item.
+			if nested := c.list(child); nested != "" {
+				if len(items) == 0 {
+					items = append(items, nested)
+				} else {
+					items[len(items)-1] += "\n" + indent(nested, "  ")
+				}
+			}
+			continue
+		}
+		if child.Tag != "li" {
+			continue
+		}
+
+		marker := "- "
+		if n.Tag == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		continuation := strings.Repeat(" ", len(marker))
+		if checkbox := taskCheckbox(child); checkbox != nil {
+			if _, checked := checkbox.Attrs["checked"]; checked {
+				marker += "[x] "
+			} else {
+				marker += "[ ] "
+			}
+		}
+		body := joinBlocks(c.blockList(child), true)
+		if body == "" {
+			items = append(items, strings.TrimRight(marker, " "))
+			continue
+		}
+		items = append(items, marker+indent(body, continuation))
+	}
+	return strings.Join(items, "\n")
+}
+
+// taskCheckbox returns the checkbox of a task list item, leaving out those of
+// nested lists.
+func taskCheckbox(item *htmlNode) *htmlNode {
+	for _, child := range item.Children {
+		switch {
+		case child.Tag == "input" && strings.EqualFold(child.attr("type"), "checkbox"):
+			return child
+		case child.Tag == "ul" || child.Tag == "ol":
+			continue
+		case child.Tag != "":
+			if checkbox := taskCheckbox(child); checkbox != nil {
+				return checkbox
+			}
+		}
+	}
+	return nil
+}
+
+func (c *converter) table(n *htmlNode) string {
+	var rows [][]string
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, child := range n.Children {
+			switch child.Tag {
+			case "tr":
+				var row []string
+				for _, cell := range child.Children {
+					if cell.Tag != "td" && cell.Tag != "th" {
+						continue
+					}
+					text := strings.ReplaceAll(c.blocks(cell), "\n\n", "<br>")
+					text = strings.ReplaceAll(text, "\n", "<br>")
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+					if span, err := strconv.Atoi(cell.attr("colspan")); err == nil {
+						for i := 1; i < span && i < 100; i++ {
+							row = append(row, "")
+						}
+					}
+				}
+				rows = append(rows, row)
+			case "thead", "tbody", "tfoot":
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	if caption := n.find("caption"); caption != nil {
+		if text := paragraph(c.inlineChildren(caption)); text != "" {
+			lines = append([]string{text, ""}, lines...)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *converter) inlineChildren(n *htmlNode) string {
+	var sb strings.Builder
+	for _, child := range n.Children {
+		if !child.Comment && !skippedElements[child.Tag] {
+			sb.WriteString(c.inline(child))
+		}
+	}
+	return sb.String()
+}
+
+func (c *converter) inline(n *htmlNode) string {
+	switch n.Tag {
+	case "":
+		return escapeText(spacePattern.ReplaceAllString(n.Text, " "))
+	case "br":
+		return "\n"
+	case "strong", "b":
+		return emphasize(c.inlineChildren(n), "**")
+	case "em", "i", "cite", "dfn":
+		return emphasize(c.inlineChildren(n), "*")
+	case "del", "s", "strike":
+		return emphasize(c.inlineChildren(n), "~~")
+	case "mark":
+		return emphasize(c.inlineChildren(n), "==")
+	case "sub", "sup", "kbd":
+		return "<" + n.Tag + ">" + c.inlineChildren(n) + "</" + n.Tag + ">"
+	case "code", "tt", "samp", "var":
+		return inlineCode(spacePattern.ReplaceAllString(n.text(), " "))
+	case "img":
+		if src := n.attr("src"); src != "" {
+			alt := collapseSpace(n.attr("alt"))
+			if c.opts.Image != nil {
+				if image := c.opts.Image(src, alt); image != "" {
+					return image
+				}
+			}
+			return "![" + escapeLinkText(alt) + "](" + linkDestination(src) + ")"
+		}
+		return ""
+	case "a":
+		text := strings.TrimSpace(c.inlineChildren(n))
+		href := n.attr("href")
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return text
+		}
+		if text == "" {
+			text = escapeLinkText(href)
+		}
+		if c.opts.Link != nil {
+			if link := c.opts.Link(href, text); link != "" {
+				return link
+			}
+		}
+		return "[" + text + "](" + linkDestination(href) + ")"
+	case "span", "font":
+		return c.styledSpan(n)
+	}
+	if blockElements[n.Tag] {
+		// A block inside inline markup, such as a <div> in a link.
+		return "\n" + c.inlineChildren(n) + "\n"
+	}
+	return c.inlineChildren(n)
+}
+
+// styledSpan keeps the bold, italic and strikethrough of inline styles, which
+// is how Google Docs exports formatting.
+func (c *converter) styledSpan(n *htmlNode) string {
+	text := c.inlineChildren(n)
+	style := strings.ReplaceAll(strings.ToLower(n.attr("style")), " ", "")
+	if strings.Contains(style, "text-decoration:line-through") {
+		text = emphasize(text, "~~")
+	}
+	if strings.Contains(style, "font-style:italic") {
+		text = emphasize(text, "*")
+	}
+	if strings.Contains(style, "font-weight:bold") || strings.Contains(style, "font-weight:700") ||
+		strings.Contains(style, "font-weight:800") || strings.Contains(style, "font-weight:900") {
+		text = emphasize(text, "**")
+	}
+	return text
+}
+
+// emphasize wraps text in marker, keeping surrounding spaces outside of it as
+// Markdown requires.
+func emphasize(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+func inlineCode(code string) string {
+	if strings.TrimSpace(code) == "" {
+		return code
+	}
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// textEscaper escapes characters that would otherwise be read as Markdown or
+// Obsidian syntax: emphasis, code, links, tags, highlights, comments and HTML.
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "#", `\#`, "<", `\<`,
+	"==", `\=\=`, "~~", `\~\~`, "%%", `\%\%`,
+)
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+func escapeLinkText(text string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text)
+}
+
+// linkDestination wraps destinations with spaces or parentheses in angle
+// brackets.
+func linkDestination(dest string) string {
+	if strings.ContainsAny(dest, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(dest) + ">"
+	}
+	return dest
+}
+
+// paragraph tidies the inline Markdown of a block: lines are trimmed, blank
+// lines dropped and line starts that would read as block syntax escaped.
+func paragraph(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(spacesPattern.ReplaceAllString(line, " "))
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, ">"), strings.HasPrefix(line, "- "), strings.HasPrefix(line, "+ "), line == "-", line == "+":
+			line = `\` + line
+		case listMarkerPattern.MatchString(line):
+			m := listMarkerPattern.FindStringSubmatchIndex(line)
+			line = line[:m[3]] + `\` + line[m[3]:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func collapseSpace(text string) string {
+	return strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+}
+
+// indent prefixes every line but the first with prefix, leaving blank lines
+// empty.
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixLines prefixes every line with prefix, or with blank for empty lines.
+func prefixLines(text, prefix, blank string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = blank
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
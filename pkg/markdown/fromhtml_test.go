@@ -0,0 +1,71 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		testName string
+		html     string
+		expected string
+	}{
+		{testName: "Headings and paragraphs", html: "<h1>Title</h1><p>One\n  two</p><h3>Sub</h3>", expected: "# Title\n\nOne two\n\n### Sub\n"},
+		{testName: "Inline formatting", html: "<p><b>bold </b>and <em>it</em>, <del>old</del> <mark>hi</mark> <code>a`b</code></p>", expected: "**bold** and *it*, ~~old~~ ==hi== ``a`b``\n"},
+		{testName: "Line breaks", html: "<p>one<br>two</p>", expected: "one\ntwo\n"},
+		{testName: "Unclosed paragraphs and entities", html: "<p>a &amp; b<p>c&nbsp;d", expected: "a & b\n\nc d\n"},
+		{testName: "Markdown syntax in text is escaped", html: "<p>C# 2*3 [x] ==y== &lt;tag&gt;</p><p>- not a list</p><p>1. not a list</p>", expected: "C\\# 2\\*3 \\[x\\] \\=\\=y\\=\\= \\<tag>\n\n\\- not a list\n\n1\\. not a list\n"},
+		{testName: "Nested lists", html: "<ul><li>a<ul><li>b</li></ul></li><li>c</ul>", expected: "- a\n  - b\n- c\n"},
+		{testName: "Ordered list with start", html: `<ol start="3"><li>three<li>four</ol>`, expected: "3. three\n4. four\n"},
+		{testName: "Task list", html: `<ul><li><input type="checkbox" checked> done</li><li><input type="checkbox"> todo</li></ul>`, expected: "- [x] done\n- [ ] todo\n"},
+		{testName: "Code block with language", html: "<pre><code class=\"language-go\">func main() {\n\treturn\n}\n</code></pre>", expected: "```go\nfunc main() {\n\treturn\n}\n```\n"},
+		{testName: "Confluence code block", html: `<pre class="syntaxhighlighter-pre" data-syntaxhighlighter-params="brush: java; gutter: false">int x = 1;</pre>`, expected: "```java\nint x = 1;\n```\n"},
+		{testName: "Block quote", html: "<blockquote><p>one</p><p>two</p></blockquote>", expected: "> one\n>\n> two\n"},
+		{testName: "Table", html: "<table><thead><tr><th>A</th><th>B</th></tr></thead><tbody><tr><td>1|2</td><td colspan=\"2\">3</td></tr></tbody></table>", expected: "| A | B |  |\n| --- | --- | --- |\n| 1\\|2 | 3 |  |\n"},
+		{testName: "Links", html: `<p><a href="https://example.com/a b">site</a> <a href="#top">top</a> <a href="https://go.dev"></a></p>`, expected: "[site](<https://example.com/a b>) top [https://go.dev](https://go.dev)\n"},
+		{testName: "Images", html: `<p><img src="pic.png" alt="A [pic]"></p>`, expected: "![A \\[pic\\]](pic.png)\n"},
+		{testName: "Google Docs styles", html: `<p><span style="font-weight:700">bold</span> <span style="font-style: italic">it</span></p>`, expected: "**bold** *it*\n"},
+		{testName: "Scripts, styles and forms are dropped", html: "<script>if (a < b) {}</script><style>p {}</style><p>text<button>Go</button></p>", expected: "text\n"},
+		{testName: "Horizontal rule and definitions", html: "<hr><dl><dt>Term<dd>Meaning</dl>", expected: "---\n\n**Term**\n\nMeaning\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			doc := markdown.FromHTML(test.html, markdown.HTMLOptions{})
+			assert.Equal(t, test.expected, doc.Markdown)
+		})
+	}
+
+	t.Run("Reads title and source", func(t *testing.T) {
+		doc := markdown.FromHTML(`<!-- saved from url=(0023)https://example.com/post -->
+<html><head><title>Post | Blog</title></head><body><h1>Post</h1></body></html>`, markdown.HTMLOptions{})
+		assert.Equal(t, "Post | Blog", doc.Title)
+		assert.Equal(t, "https://example.com/post", doc.Source)
+		assert.Equal(t, "# Post\n", doc.Markdown)
+
+		doc = markdown.FromHTML(`<link rel="canonical" href="https://example.com/c"><h1>Heading  title</h1>`, markdown.HTMLOptions{})
+		assert.Equal(t, "Heading title", doc.Title)
+		assert.Equal(t, "https://example.com/c", doc.Source)
+	})
+
+	t.Run("Uses image and link callbacks", func(t *testing.T) {
+		doc := markdown.FromHTML(`<p><img src="a.png" alt="A"><img src="b.png"> <a href="other.html">other</a> <a href="https://x.org">x</a></p>`, markdown.HTMLOptions{
+			Image: func(src, alt string) string {
+				if src == "a.png" {
+					return "![[a.png|" + alt + "]]"
+				}
+				return ""
+			},
+			Link: func(href, text string) string {
+				if href == "other.html" {
+					return "[[Other|" + text + "]]"
+				}
+				return ""
+			},
+		})
+		assert.Equal(t, "![[a.png|A]]![](b.png) [[Other|other]] [x](https://x.org)\n", doc.Markdown)
+	})
+}
@@ -0,0 +1,282 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlNode is an element, text or comment in a parsed HTML document. The
+// document itself is an element with an empty tag.
+type htmlNode struct {
+	Tag      string // lower case; "" for text, comments and the document
+	Attrs    map[string]string
+	Text     string // unescaped text, or the comment's contents
+	Comment  bool
+	Children []*htmlNode
+	parent   *htmlNode
+}
+
+func (n *htmlNode) attr(name string) string {
+	return n.Attrs[name]
+}
+
+// find returns the first element with tag in a depth-first walk of n.
+func (n *htmlNode) find(tag string) *htmlNode {
+	for _, child := range n.Children {
+		if child.Tag == tag {
+			return child
+		}
+		if found := child.find(tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// text returns the text of n and its descendants.
+func (n *htmlNode) text() string {
+	if n.Tag == "" && !n.Comment {
+		return n.Text
+	}
+	var sb strings.Builder
+	for _, child := range n.Children {
+		if !child.Comment {
+			sb.WriteString(child.text())
+		}
+	}
+	return sb.String()
+}
+
+// voidElements never have contents or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements contain text up to their end tag, without markup.
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "textarea": true, "title": true, "xmp": true,
+}
+
+// closedBy lists, for elements whose end tag may be left out, the start tags
+// that implicitly end them.
+var closedBy = map[string]map[string]bool{
+	"p":      set("address", "article", "aside", "blockquote", "details", "div", "dl", "fieldset", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "main", "nav", "ol", "p", "pre", "section", "table", "ul"),
+	"li":     set("li"),
+	"dt":     set("dt", "dd"),
+	"dd":     set("dt", "dd"),
+	"tr":     set("tr", "tbody", "thead", "tfoot"),
+	"td":     set("td", "th", "tr", "tbody", "thead", "tfoot"),
+	"th":     set("td", "th", "tr", "tbody", "thead", "tfoot"),
+	"thead":  set("tbody", "tfoot"),
+	"tbody":  set("tbody", "tfoot"),
+	"option": set("option", "optgroup"),
+}
+
+// scopeElements stop the search for an element to close implicitly, so that
+// a nested list's <li> does not end the outer list's item.
+var scopeElements = set("ul", "ol", "dl", "table", "blockquote", "div", "td", "th", "section", "article")
+
+func set(items ...string) map[string]bool {
+	m := make(map[string]bool, len(items))
+	for _, item := range items {
+		m[item] = true
+	}
+	return m
+}
+
+// parseHTML builds a tree from src. Like browsers it never fails: unknown
+// end tags are ignored and unclosed elements end with their parent.
+func parseHTML(src string) *htmlNode {
+	doc := &htmlNode{}
+	current := doc
+	appendChild := func(n *htmlNode) {
+		n.parent = current
+		current.Children = append(current.Children, n)
+	}
+	closeElement := func(tag string) {
+		for n := current; n != doc; n = n.parent {
+			if n.Tag == tag {
+				current = n.parent
+				return
+			}
+		}
+	}
+
+	for i := 0; i < len(src); {
+		if src[i] != '<' {
+			end := strings.IndexByte(src[i:], '<')
+			if end == -1 {
+				end = len(src) - i
+			}
+			appendChild(&htmlNode{Text: html.UnescapeString(src[i : i+end])})
+			i += end
+			continue
+		}
+
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end == -1 {
+				end = len(rest) - 4
+			}
+			appendChild(&htmlNode{Comment: true, Text: rest[4 : 4+end]})
+			i += min(len(rest), 4+end+3)
+			continue
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			// Doctype, CDATA or processing instruction.
+			end := strings.IndexByte(rest, '>')
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			i += end + 1
+			continue
+		case strings.HasPrefix(rest, "</"):
+			tag, _ := readTagName(rest[2:])
+			end := strings.IndexByte(rest, '>')
+			if tag == "" || end == -1 {
+				appendChild(&htmlNode{Text: "<"})
+				i++
+				continue
+			}
+			closeElement(tag)
+			i += end + 1
+			continue
+		}
+
+		tag, n := readTagName(rest[1:])
+		if tag == "" {
+			appendChild(&htmlNode{Text: "<"})
+			i++
+			continue
+		}
+		attrs, selfClosing, length := readAttributes(rest[1+n:])
+		i += 1 + n + length
+
+		if closers := closingParents(tag); closers != nil {
+			for open := current; open != doc && !scopeElements[open.Tag]; open = open.parent {
+				if closers[open.Tag] {
+					current = open.parent
+					break
+				}
+			}
+		}
+
+		element := &htmlNode{Tag: tag, Attrs: attrs}
+		appendChild(element)
+		if voidElements[tag] || selfClosing {
+			continue
+		}
+		if rawTextElements[tag] {
+			end := strings.Index(strings.ToLower(src[i:]), "</"+tag)
+			if end == -1 {
+				end = len(src) - i
+			}
+			text := src[i : i+end]
+			if tag == "title" || tag == "textarea" {
+				text = html.UnescapeString(text)
+			}
+			element.Children = []*htmlNode{{Text: text, parent: element}}
+			i += end
+			if close := strings.IndexByte(src[i:], '>'); close != -1 {
+				i += close + 1
+			}
+			continue
+		}
+		current = element
+	}
+	return doc
+}
+
+// closingParents returns the open elements that a start tag ends.
+func closingParents(tag string) map[string]bool {
+	var parents map[string]bool
+	for parent, closers := range closedBy {
+		if closers[tag] {
+			if parents == nil {
+				parents = map[string]bool{}
+			}
+			parents[parent] = true
+		}
+	}
+	return parents
+}
+
+// readTagName reads a tag name at the start of s and returns it in lower case
+// with its length.
+func readTagName(s string) (string, int) {
+	n := 0
+	for n < len(s) && (isASCIILetter(s[n]) || (n > 0 && (s[n] >= '0' && s[n] <= '9' || s[n] == '-' || s[n] == ':'))) {
+		n++
+	}
+	return strings.ToLower(s[:n]), n
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// readAttributes reads the attributes of a start tag up to and including its
+// closing ">", and returns them with whether the tag ends in "/>" and the
+// number of bytes read.
+func readAttributes(s string) (map[string]string, bool, int) {
+	attrs := map[string]string{}
+	i := 0
+	for i < len(s) {
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		switch {
+		case s[i] == '>':
+			return attrs, false, i + 1
+		case strings.HasPrefix(s[i:], "/>"):
+			return attrs, true, i + 2
+		case s[i] == '/':
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && !strings.HasPrefix(s[i:], "/>") {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end == -1 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i = min(len(s), i+end+2)
+			} else {
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if _, ok := attrs[name]; !ok && name != "" {
+			attrs[name] = html.UnescapeString(value)
+		}
+	}
+	return attrs, false, len(s)
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,543 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RenderOptions connects ToHTML to the vault. Every field is optional.
+type RenderOptions struct {
+	// WikiLink returns the URL of a wikilink target ("Note", "Note#Heading",
+	// "Note#^block"). If it reports false the link is rendered as unresolved.
+	WikiLink func(target string) (string, bool)
+	// Embed returns the HTML for an embed ("![[target|alias]]"). If it
+	// reports false the embed is rendered as a wikilink.
+	Embed func(target, alias string) (string, bool)
+	// Resource rewrites the destination of a Markdown link or image. By
+	// default destinations are kept as they are.
+	Resource func(dest string) string
+	// EscapeHTML escapes raw HTML tags and blocks in the note, so that they
+	// show as text, instead of passing them through. HTML comments are kept.
+	EscapeHTML bool
+	// HeadingIDs holds the heading IDs already used on the page, such as
+	// those from HeadingIDs. ToHTML gives headings other IDs and adds them,
+	// so that notes rendered into the same page get unique IDs.
+	HeadingIDs map[string]int
+}
+
+var (
+	fencePattern      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})\\s*([^`\\s]*)")
+	hrPattern         = regexp.MustCompile(`^ {0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	listPattern       = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(\s+|$)`)
+	taskPattern       = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	tableDelimPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	calloutPattern    = regexp.MustCompile(`^\[!([\w-]+)\]([+-]?)\s*(.*)$`)
+	blockTagPattern   = regexp.MustCompile(`^\s*</?([A-Za-z][A-Za-z0-9-]*)[\s/>]`)
+	standaloneIDLine  = regexp.MustCompile(`^\s*\^([A-Za-z0-9-]+)\s*$`)
+)
+
+// embedMark surrounds embeds rendered as blocks in the inline HTML of a
+// paragraph, which is split around them.
+const embedMark = "\x00"
+
+// ToHTML renders a note's Markdown as HTML, with Obsidian's extensions:
+// wikilinks and embeds, callouts, highlights, task lists, tags, comments,
+// block IDs and math (left for a client-side renderer). Frontmatter is left
+// out. Headings get IDs from HeadingID, and blocks with a block ID get it as
+// their HTML ID ("^abc123").
+func ToHTML(content string, opts RenderOptions) string {
+	content = strings.ReplaceAll(content, embedMark, "")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	r := &renderer{opts: opts, ids: opts.HeadingIDs}
+	if r.ids == nil {
+		r.ids = map[string]int{}
+	}
+	return r.blocks(lines[BodyStart(lines):])
+}
+
+// HeadingIDs returns the IDs of the headings of content, as ToHTML gives
+// them, for RenderOptions.HeadingIDs.
+func HeadingIDs(content string) map[string]int {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	r := &renderer{ids: map[string]int{}}
+	for _, h := range Headings(lines) {
+		r.headingID(h)
+	}
+	return r.ids
+}
+
+// HeadingID returns the HTML ID ToHTML gives a heading: its text in lower
+// case, with runs of other characters than letters and digits replaced by
+// "-".
+func HeadingID(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+type renderer struct {
+	opts        RenderOptions
+	ids         map[string]int // heading IDs used so far
+	blockEmbeds bool           // mark block embeds with embedMark
+}
+
+// blocks renders lines as a sequence of blocks.
+func (r *renderer) blocks(lines []string) string {
+	return r.blockList(lines, false)
+}
+
+// blockList renders lines as a sequence of blocks. The paragraphs of tight
+// list items are not wrapped in <p>.
+func (r *renderer) blockList(lines []string, tight bool) string {
+	var out []string
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case standaloneIDLine.MatchString(line) && len(out) > 0:
+			id := standaloneIDLine.FindStringSubmatch(line)[1]
+			out[len(out)-1] = withID(out[len(out)-1], "^"+id)
+			i++
+		case strings.HasPrefix(trimmed, "%%"):
+			i = skipComment(lines, i)
+		case fencePattern.MatchString(line):
+			var block string
+			block, i = r.codeBlock(lines, i)
+			out = append(out, block)
+		case trimmed == "$$" || (strings.HasPrefix(trimmed, "$$") && !strings.HasSuffix(trimmed[2:], "$$")):
+			var block string
+			block, i = mathBlock(lines, i)
+			out = append(out, block)
+		case strings.HasPrefix(trimmed, "#"):
+			if h, ok := ParseHeading(trimmed); ok {
+				out = append(out, r.heading(h))
+				i++
+				continue
+			}
+			var block string
+			block, i = r.paragraph(lines, i, tight)
+			out = append(out, block)
+		case hrPattern.MatchString(line):
+			out = append(out, "<hr>")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var block string
+			block, i = r.quote(lines, i)
+			out = append(out, block)
+		case listPattern.MatchString(line):
+			var block string
+			block, i = r.list(lines, i)
+			out = append(out, block)
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableDelimPattern.MatchString(lines[i+1]):
+			var block string
+			block, i = r.table(lines, i)
+			out = append(out, block)
+		case !r.opts.EscapeHTML && blockTagPattern.MatchString(line) && htmlBlockTags[strings.ToLower(blockTagPattern.FindStringSubmatch(line)[1])]:
+			start := i
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				i++
+			}
+			out = append(out, strings.Join(lines[start:i], "\n"))
+		default:
+			var block string
+			block, i = r.paragraph(lines, i, tight)
+			out = append(out, block)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// htmlBlockTags start raw HTML blocks, which are passed through.
+var htmlBlockTags = set("address", "article", "aside", "blockquote", "details", "div", "dl", "figure", "footer",
+	"header", "hr", "iframe", "ol", "p", "pre", "section", "summary", "table", "ul", "video", "audio", "center", "br")
+
+func skipComment(lines []string, i int) int {
+	first := strings.TrimSpace(lines[i])[2:]
+	if strings.Contains(first, "%%") {
+		return i + 1
+	}
+	for i++; i < len(lines); i++ {
+		if strings.Contains(lines[i], "%%") {
+			return i + 1
+		}
+	}
+	return i
+}
+
+func (r *renderer) heading(h Heading) string {
+	id := r.headingID(h)
+	return fmt.Sprintf("<h%d id=\"%s\">%s</h%d>", h.Level, html.EscapeString(id), r.inline(TrimBlockID(h.Text)), h.Level)
+}
+
+// headingID returns the ID of h, numbered ("intro-1") if it is already used.
+func (r *renderer) headingID(h Heading) string {
+	id := HeadingID(TrimBlockID(h.Text))
+	if r.ids[id] == 0 {
+		r.ids[id] = 1
+		return id
+	}
+	for {
+		numbered := fmt.Sprintf("%s-%d", id, r.ids[id])
+		r.ids[id]++
+		if r.ids[numbered] == 0 {
+			r.ids[numbered] = 1
+			return numbered
+		}
+	}
+}
+
+func (r *renderer) codeBlock(lines []string, i int) (string, int) {
+	m := fencePattern.FindStringSubmatch(lines[i])
+	indent, fence, language := len(m[1]), m[2], m[3]
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		line := lines[i]
+		for n := 0; n < indent && strings.HasPrefix(line, " "); n++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+
+	class := ""
+	if language != "" {
+		class = fmt.Sprintf(" class=\"language-%s\"", html.EscapeString(language))
+	}
+	body := html.EscapeString(strings.Join(code, "\n"))
+	if len(code) > 0 {
+		body += "\n"
+	}
+	return fmt.Sprintf("<pre><code%s>%s</code></pre>", class, body), i
+}
+
+func mathBlock(lines []string, i int) (string, int) {
+	var math []string
+	first := strings.TrimPrefix(strings.TrimSpace(lines[i]), "$$")
+	if first != "" {
+		math = append(math, first)
+	}
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasSuffix(trimmed, "$$") {
+			if rest := strings.TrimSuffix(trimmed, "$$"); rest != "" {
+				math = append(math, rest)
+			}
+			i++
+			break
+		}
+		math = append(math, lines[i])
+	}
+	return "<div class=\"math\">$$" + html.EscapeString(strings.Join(math, "\n")) + "$$</div>", i
+}
+
+// paragraph renders lines from i up to the next blank line or block start.
+// Line breaks are kept, as Obsidian shows them by default.
+func (r *renderer) paragraph(lines []string, i int, tight bool) (string, int) {
+	var text []string
+	for start := i; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if i > start && (trimmed == "" || interruptsParagraph(line)) {
+			break
+		}
+		text = append(text, trimmed)
+	}
+
+	id := ""
+	if last := text[len(text)-1]; last != "" {
+		if blockID, ok := BlockID(last); ok {
+			id = "^" + blockID
+			text[len(text)-1] = TrimBlockID(last)
+		}
+	}
+	r.blockEmbeds = true
+	body := r.inline(strings.Join(text, "\n"))
+	r.blockEmbeds = false
+	switch {
+	case strings.Contains(body, embedMark):
+		// Embedded notes are blocks, not part of a paragraph.
+		return splitEmbeds(body, id, tight), i
+	case id != "":
+		return withID("<p>"+body+"</p>", id), i
+	case tight:
+		return body, i
+	}
+	return "<p>" + body + "</p>", i
+}
+
+// splitEmbeds renders a paragraph whose inline HTML contains block embeds as
+// the embeds and paragraphs of the text between them. The block ID goes to
+// the last block.
+func splitEmbeds(body, id string, tight bool) string {
+	var blocks []string
+	for n, part := range strings.Split(body, embedMark) {
+		if n%2 == 1 {
+			blocks = append(blocks, part)
+			continue
+		}
+		if part = trimBreaks(part); part == "" {
+			continue
+		}
+		if !tight {
+			part = "<p>" + part + "</p>"
+		}
+		blocks = append(blocks, part)
+	}
+	if id != "" && len(blocks) > 0 {
+		blocks[len(blocks)-1] = withID(blocks[len(blocks)-1], id)
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// trimBreaks trims white space and line breaks from both ends of inline HTML.
+func trimBreaks(s string) string {
+	for {
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "<br>"), "<br>")
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+func interruptsParagraph(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if _, ok := ParseHeading(trimmed); ok {
+		return true
+	}
+	if m := listPattern.FindStringSubmatch(line); m != nil && m[3] != "" {
+		// Only lists starting at 1 interrupt a paragraph, so that a line
+		// starting with a year ("2026. was") does not.
+		if n, err := strconv.Atoi(strings.TrimRight(m[2], ".)")); err != nil || n == 1 {
+			return true
+		}
+	}
+	return strings.HasPrefix(trimmed, ">") || fencePattern.MatchString(line) || hrPattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, "$$") || standaloneIDLine.MatchString(line)
+}
+
+// quote renders a block quote, or a callout ("> [!note] Title").
+func (r *renderer) quote(lines []string, i int) (string, int) {
+	var inner []string
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		trimmed = strings.TrimPrefix(trimmed, " ")
+		inner = append(inner, trimmed)
+	}
+
+	if m := calloutPattern.FindStringSubmatch(strings.TrimSpace(inner[0])); m != nil {
+		kind := strings.ToLower(m[1])
+		title := m[3]
+		if title == "" {
+			title = strings.ToUpper(kind[:1]) + kind[1:]
+		}
+		return fmt.Sprintf("<div class=\"callout\" data-callout=\"%s\">\n<div class=\"callout-title\">%s</div>\n<div class=\"callout-content\">\n%s\n</div>\n</div>",
+			html.EscapeString(kind), r.inline(title), r.blocks(inner[1:])), i
+	}
+	return "<blockquote>\n" + r.blocks(inner) + "\n</blockquote>", i
+}
+
+// list renders the list starting at line i and its nested lists.
+func (r *renderer) list(lines []string, i int) (string, int) {
+	first := listPattern.FindStringSubmatch(lines[i])
+	indent := len(first[1])
+	ordered := isOrdered(first[2])
+
+	type item struct {
+		lines []string
+	}
+	var items []item
+	loose := false
+	for i < len(lines) {
+		m := listPattern.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent || isOrdered(m[2]) != ordered {
+			break
+		}
+		width := len(m[1]) + len(m[2]) + len(m[3])
+		if m[3] == "" || len(m[3]) > 4 {
+			width = len(m[1]) + len(m[2]) + 1
+		}
+		it := item{lines: []string{strings.TrimLeft(lines[i][min(width-1, len(lines[i])):], " ")}}
+		i++
+
+		blank := false
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				blank = true
+				it.lines = append(it.lines, "")
+				i++
+				continue
+			}
+			lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if lineIndent >= width || (lineIndent > indent && listPattern.MatchString(line)) {
+				it.lines = append(it.lines, strings.TrimLeft(line[:min(lineIndent, width)], " \t")+line[min(lineIndent, width):])
+				blank = false
+				i++
+				continue
+			}
+			if !blank && !listPattern.MatchString(line) && !interruptsParagraph(line) {
+				// A lazy continuation of the item's paragraph.
+				it.lines = append(it.lines, strings.TrimSpace(line))
+				i++
+				continue
+			}
+			break
+		}
+		for len(it.lines) > 0 && strings.TrimSpace(it.lines[len(it.lines)-1]) == "" {
+			it.lines = it.lines[:len(it.lines)-1]
+		}
+		if blank && i < len(lines) {
+			if m := listPattern.FindStringSubmatch(lines[i]); m != nil && len(m[1]) == indent && isOrdered(m[2]) == ordered {
+				loose = true
+			}
+		}
+		for _, line := range it.lines {
+			if line == "" {
+				loose = true
+			}
+		}
+		items = append(items, it)
+	}
+
+	var sb strings.Builder
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if start, err := strconv.Atoi(strings.TrimRight(first[2], ".)")); err == nil && start != 1 {
+			sb.WriteString(fmt.Sprintf("<ol start=\"%d\">\n", start))
+		} else {
+			sb.WriteString("<ol>\n")
+		}
+	} else {
+		sb.WriteString("<ul>\n")
+	}
+
+	for _, it := range items {
+		body := it.lines
+		attrs := ""
+		if m := taskPattern.FindStringSubmatch(body[0]); m != nil {
+			checked := ""
+			if m[1] != " " {
+				checked = " checked"
+			}
+			attrs = " class=\"task-list-item\""
+			body = append([]string{"<input type=\"checkbox\" disabled" + checked + "> " + body[0][len(m[0]):]}, body[1:]...)
+		}
+		if id, ok := BlockID(body[0]); ok && !listPattern.MatchString(body[0]) {
+			attrs += fmt.Sprintf(" id=\"^%s\"", html.EscapeString(id))
+			body[0] = TrimBlockID(body[0])
+		}
+
+		sb.WriteString("<li" + attrs + ">" + r.blockList(body, !loose) + "</li>\n")
+	}
+	sb.WriteString("</" + tag + ">")
+	return sb.String(), i
+}
+
+func isOrdered(marker string) bool {
+	return marker != "-" && marker != "*" && marker != "+"
+}
+
+func (r *renderer) table(lines []string, i int) (string, int) {
+	header := splitTableRow(lines[i])
+	var aligns []string
+	for _, cell := range splitTableRow(lines[i+1]) {
+		cell = strings.TrimSpace(cell)
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "center")
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "right")
+		case strings.HasPrefix(cell, ":"):
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	row := func(cells []string, tag string) string {
+		var sb strings.Builder
+		sb.WriteString("<tr>")
+		for n := range aligns {
+			cell := ""
+			if n < len(cells) {
+				cell = cells[n]
+			}
+			style := ""
+			if aligns[n] != "" {
+				style = fmt.Sprintf(" style=\"text-align: %s\"", aligns[n])
+			}
+			sb.WriteString(fmt.Sprintf("<%s%s>%s</%s>", tag, style, r.inline(strings.TrimSpace(cell)), tag))
+		}
+		sb.WriteString("</tr>")
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<thead>\n" + row(header, "th") + "\n</thead>\n<tbody>\n")
+	for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		sb.WriteString(row(splitTableRow(lines[i]), "td") + "\n")
+	}
+	sb.WriteString("</tbody>\n</table>")
+	return sb.String(), i
+}
+
+// splitTableRow splits a table row on pipes that are not escaped.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, cell.String())
+}
+
+// withID adds an id attribute to the first tag of a rendered block.
+func withID(block, id string) string {
+	end := strings.IndexAny(block, " >")
+	if !strings.HasPrefix(block, "<") || end == -1 || strings.Contains(block[:strings.IndexByte(block, '>')+1], " id=") {
+		return block
+	}
+	return block[:end] + fmt.Sprintf(" id=\"%s\"", html.EscapeString(id)) + block[end:]
+}
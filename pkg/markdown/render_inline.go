@@ -0,0 +1,405 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	tagStartPattern = regexp.MustCompile(`^#([\p{L}\p{N}_/-]*[\p{L}_/-][\p{L}\p{N}_/-]*)`)
+	autolinkPattern = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]*:[^\s<>]*)>`)
+	bareURLPattern  = regexp.MustCompile(`^https?://[^\s<>]*[^\s<>.,;:!?"')\]*_]`)
+	rawHTMLPattern  = regexp.MustCompile(`^(?:</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>|<!--[\s\S]*?-->)`)
+	entityPattern   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+// emphasisTags maps emphasis delimiters to their elements, longest first.
+var emphasisTags = []struct {
+	delim, tag string
+}{
+	{"**", "strong"}, {"__", "strong"}, {"~~", "del"}, {"==", "mark"}, {"*", "em"}, {"_", "em"},
+}
+
+// inline renders the inline Markdown of a block.
+func (r *renderer) inline(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+		switch {
+		case c == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			sb.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '\n':
+			sb.WriteString("<br>\n")
+			i++
+			continue
+		case c == '`':
+			if code, n, ok := codeSpan(rest); ok {
+				sb.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += n
+				continue
+			}
+			run := len(rest) - len(strings.TrimLeft(rest, "`"))
+			sb.WriteString(rest[:run])
+			i += run
+			continue
+		case strings.HasPrefix(rest, "%%"):
+			end := strings.Index(rest[2:], "%%")
+			if end == -1 {
+				return sb.String()
+			}
+			i += end + 4
+			continue
+		case c == '$':
+			if math, n, ok := inlineMath(rest); ok {
+				sb.WriteString("<span class=\"math\">" + html.EscapeString(math) + "</span>")
+				i += n
+				continue
+			}
+		case strings.HasPrefix(rest, "![["):
+			if end := strings.Index(rest, "]]"); end != -1 {
+				target, alias, _ := strings.Cut(rest[3:end], "|")
+				sb.WriteString(r.embed(target, alias))
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "[["):
+			if end := strings.Index(rest, "]]"); end != -1 {
+				target, alias, _ := strings.Cut(rest[2:end], "|")
+				sb.WriteString(r.wikiLink(target, alias))
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "!["):
+			if label, dest, n, ok := parseLink(rest[1:]); ok {
+				sb.WriteString(r.image(dest, label))
+				i += n + 1
+				continue
+			}
+		case c == '[':
+			if label, dest, n, ok := parseLink(rest); ok {
+				sb.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(r.resource(dest)), r.inline(label)))
+				i += n
+				continue
+			}
+		case c == '<':
+			if m := autolinkPattern.FindStringSubmatch(rest); m != nil {
+				sb.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(m[1]), html.EscapeString(m[1])))
+				i += len(m[0])
+				continue
+			}
+			if m := rawHTMLPattern.FindString(rest); m != "" {
+				if r.opts.EscapeHTML && !strings.HasPrefix(m, "<!--") {
+					sb.WriteString(html.EscapeString(m))
+				} else {
+					sb.WriteString(m)
+				}
+				i += len(m)
+				continue
+			}
+		case c == '&':
+			if m := entityPattern.FindString(rest); m != "" {
+				sb.WriteString(m)
+				i += len(m)
+				continue
+			}
+		case c == 'h' && wordStart(text, i):
+			if m := bareURLPattern.FindString(rest); m != "" {
+				sb.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(m), html.EscapeString(m)))
+				i += len(m)
+				continue
+			}
+		case c == '#' && (i == 0 || strings.ContainsRune(" \t(", rune(text[i-1]))):
+			if m := tagStartPattern.FindString(rest); m != "" {
+				sb.WriteString("<span class=\"tag\">" + html.EscapeString(m) + "</span>")
+				i += len(m)
+				continue
+			}
+		case c == '*' || c == '_' || c == '~' || c == '=':
+			if out, n, ok := r.emphasis(text, i); ok {
+				sb.WriteString(out)
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		sb.WriteString(html.EscapeString(rest[:size]))
+		i += size
+	}
+	return sb.String()
+}
+
+// emphasis renders the emphasis, strikethrough or highlight that starts at
+// text[i], and returns it with the number of bytes it spans.
+func (r *renderer) emphasis(text string, i int) (string, int, bool) {
+	for _, e := range emphasisTags {
+		if !strings.HasPrefix(text[i:], e.delim) {
+			continue
+		}
+		start := i + len(e.delim)
+		if start >= len(text) || isSpace(text[start]) {
+			continue
+		}
+		if e.delim == "_" || e.delim == "__" {
+			if before, _ := utf8.DecodeLastRuneInString(text[:i]); i > 0 && isWordRune(before) {
+				continue
+			}
+		}
+		end := findCloser(text, start, e.delim)
+		if end == -1 {
+			continue
+		}
+		return "<" + e.tag + ">" + r.inline(text[start:end]) + "</" + e.tag + ">", end + len(e.delim) - i, true
+	}
+	return "", 0, false
+}
+
+// findCloser returns the index of the delimiter that closes an emphasis
+// opened before text[from], or -1. Code spans are skipped.
+func findCloser(text string, from int, delim string) int {
+	for j := from; j < len(text); j++ {
+		switch {
+		case text[j] == '`':
+			if _, n, ok := codeSpan(text[j:]); ok {
+				j += n - 1
+			}
+			continue
+		case text[j] == '\\':
+			j++
+			continue
+		case !strings.HasPrefix(text[j:], delim) || j == from || isSpace(text[j-1]):
+			continue
+		}
+
+		run := len(text[j:]) - len(strings.TrimLeft(text[j:], delim[:1]))
+		if len(delim) == 1 && run == 2 {
+			// The delimiter of a nested strong emphasis.
+			j++
+			continue
+		}
+		closer := j + run - len(delim)
+		if delim[0] == '_' {
+			if after, _ := utf8.DecodeRuneInString(text[closer+len(delim):]); isWordRune(after) {
+				j += run - 1
+				continue
+			}
+		}
+		return closer
+	}
+	return -1
+}
+
+// codeSpan parses the code span at the start of s and returns its code with
+// the number of bytes it spans.
+func codeSpan(s string) (string, int, bool) {
+	run := len(s) - len(strings.TrimLeft(s, "`"))
+	fence := s[:run]
+	for j := run; j < len(s); {
+		end := strings.Index(s[j:], fence)
+		if end == -1 {
+			return "", 0, false
+		}
+		end += j
+		closeRun := len(s[end:]) - len(strings.TrimLeft(s[end:], "`"))
+		if closeRun != run {
+			j = end + closeRun
+			continue
+		}
+		code := strings.ReplaceAll(s[run:end], "\n", " ")
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+			code = code[1 : len(code)-1]
+		}
+		return code, end + run, true
+	}
+	return "", 0, false
+}
+
+// inlineMath parses "$x$" or "$$x$$" at the start of s. The opening "$" must
+// not be followed by a space and the closing one not preceded by one, so
+// that prices ("$5 and $10") stay text.
+func inlineMath(s string) (string, int, bool) {
+	fence := "$"
+	if strings.HasPrefix(s, "$$") {
+		fence = "$$"
+	}
+	body := s[len(fence):]
+	if body == "" || isSpace(body[0]) {
+		return "", 0, false
+	}
+	end := strings.Index(body, fence)
+	if end <= 0 || isSpace(body[end-1]) {
+		return "", 0, false
+	}
+	if fence == "$" && end+1 < len(body) && body[end+1] >= '0' && body[end+1] <= '9' {
+		return "", 0, false
+	}
+	return fence + body[:end] + fence, len(fence)*2 + end, true
+}
+
+// parseLink parses "[label](destination "title")" at the start of s and
+// returns the label and destination with the number of bytes it spans.
+func parseLink(s string) (string, string, int, bool) {
+	depth := 0
+	labelEnd := -1
+	for j := 1; j < len(s) && labelEnd == -1; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if _, n, ok := codeSpan(s[j:]); ok {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				labelEnd = j
+			}
+			depth--
+		}
+	}
+	if labelEnd == -1 || labelEnd+1 >= len(s) || s[labelEnd+1] != '(' {
+		return "", "", 0, false
+	}
+
+	rest := s[labelEnd+2:]
+	inner := strings.TrimLeft(rest, " ")
+	var dest string
+	var j int
+	if strings.HasPrefix(inner, "<") {
+		end := strings.IndexByte(inner, '>')
+		if end == -1 {
+			return "", "", 0, false
+		}
+		dest = inner[1:end]
+		j = end + 1
+	} else {
+		depth := 0
+		for j < len(inner) && !isSpace(inner[j]) {
+			if inner[j] == '(' {
+				depth++
+			} else if inner[j] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+			j++
+		}
+		dest = inner[:j]
+	}
+	close := strings.IndexByte(inner[j:], ')')
+	if close == -1 {
+		return "", "", 0, false
+	}
+	if title := strings.TrimSpace(inner[j : j+close]); title != "" && !strings.ContainsAny(title[:1], `"'(`) {
+		return "", "", 0, false
+	}
+	n := labelEnd + 2 + len(rest) - len(inner) + j + close + 1
+	return s[1:labelEnd], dest, n, true
+}
+
+func (r *renderer) resource(dest string) string {
+	if r.opts.Resource != nil {
+		return r.opts.Resource(dest)
+	}
+	return dest
+}
+
+// image renders a Markdown image. A size after the alt text ("alt|200" or
+// "alt|200x100") sets the image's size, as in Obsidian.
+func (r *renderer) image(dest, alt string) string {
+	size := ""
+	if before, after, ok := strings.Cut(alt, "|"); ok {
+		if width, height, ok := imageSize(after); ok {
+			alt = before
+			size = width
+			if height != "" {
+				size += height
+			}
+		}
+	}
+	return fmt.Sprintf("<img src=\"%s\" alt=\"%s\"%s>", html.EscapeString(r.resource(dest)), html.EscapeString(alt), size)
+}
+
+// imageSize parses an Obsidian image size ("200" or "200x100") and returns it
+// as width and height attributes.
+func imageSize(s string) (string, string, bool) {
+	width, height, hasHeight := strings.Cut(strings.TrimSpace(s), "x")
+	if !isDigits(width) || (hasHeight && !isDigits(height)) {
+		return "", "", false
+	}
+	if hasHeight {
+		return fmt.Sprintf(" width=\"%s\"", width), fmt.Sprintf(" height=\"%s\"", height), true
+	}
+	return fmt.Sprintf(" width=\"%s\"", width), "", true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// embed renders "![[target|alias]]" with the Embed option, or as a wikilink.
+func (r *renderer) embed(target, alias string) string {
+	if r.opts.Embed != nil {
+		if out, ok := r.opts.Embed(strings.TrimSpace(target), alias); ok {
+			if r.blockEmbeds && strings.HasPrefix(out, "<div") {
+				return embedMark + out + embedMark
+			}
+			return out
+		}
+	}
+	return r.wikiLink(target, alias)
+}
+
+// wikiLink renders "[[target|alias]]". Without an alias it shows the target,
+// with subpaths as "Note > Heading" as in Obsidian.
+func (r *renderer) wikiLink(target, alias string) string {
+	target = strings.TrimSpace(target)
+	display := alias
+	if display == "" {
+		display = strings.ReplaceAll(strings.TrimPrefix(target, "#"), "#", " > ")
+	}
+	if r.opts.WikiLink != nil {
+		if href, ok := r.opts.WikiLink(target); ok {
+			return fmt.Sprintf("<a class=\"internal-link\" href=\"%s\">%s</a>", html.EscapeString(href), html.EscapeString(display))
+		}
+	}
+	return "<span class=\"internal-link is-unresolved\">" + html.EscapeString(display) + "</span>"
+}
+
+// wordStart reports whether text[i] starts a word.
+func wordStart(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:i])
+	return unicode.IsSpace(before) || before == '(' || before == '*' || before == '_'
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) != -1
+}
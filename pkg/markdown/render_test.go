@@ -0,0 +1,126 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "Headings get IDs and frontmatter is left out",
+			content:  "---\ntitle: x\n---\n# Hello, World!\n## Hello World\n# Hello World",
+			expected: "<h1 id=\"hello-world\">Hello, World!</h1>\n<h2 id=\"hello-world-1\">Hello World</h2>\n<h1 id=\"hello-world-2\">Hello World</h1>",
+		},
+		{
+			name:     "Paragraphs keep line breaks and block IDs",
+			content:  "one\ntwo ^para\n\nthree\n^after",
+			expected: "<p id=\"^para\">one<br>\ntwo</p>\n<p id=\"^after\">three</p>",
+		},
+		{
+			name:     "Inline formatting",
+			content:  "**b** *i* ***bi*** ==hi== ~~no~~ `a < b` $x^2$ snake_case_name \\*lit\\* a & b &amp;",
+			expected: "<p><strong>b</strong> <em>i</em> <strong><em>bi</em></strong> <mark>hi</mark> <del>no</del> <code>a &lt; b</code> <span class=\"math\">$x^2$</span> snake_case_name *lit* a &amp; b &amp;</p>",
+		},
+		{
+			name:     "Prices are not math",
+			content:  "$5 and $10",
+			expected: "<p>$5 and $10</p>",
+		},
+		{
+			name:     "Links, images, tags and comments",
+			content:  "[site](https://example.com \"Title\") ![alt|100](pic.png) <https://a.b> https://c.d/e. #tag/sub #123 <kbd>x</kbd> %%hidden%%",
+			expected: "<p><a href=\"https://example.com\">site</a> <img src=\"pic.png\" alt=\"alt\" width=\"100\"> <a href=\"https://a.b\">https://a.b</a> <a href=\"https://c.d/e\">https://c.d/e</a>. <span class=\"tag\">#tag/sub</span> #123 <kbd>x</kbd> </p>",
+		},
+		{
+			name:     "Wikilinks without options are unresolved",
+			content:  "[[Note]] [[Note#Heading]] [[Note|alias]] ![[Note]]",
+			expected: "<p><span class=\"internal-link is-unresolved\">Note</span> <span class=\"internal-link is-unresolved\">Note &gt; Heading</span> <span class=\"internal-link is-unresolved\">alias</span> <span class=\"internal-link is-unresolved\">Note</span></p>",
+		},
+		{
+			name:     "Lists, tasks and nesting",
+			content:  "- [ ] todo\n- [x] done\n  - nested\n\n3. three\n4. four",
+			expected: "<ul>\n<li class=\"task-list-item\"><input type=\"checkbox\" disabled> todo</li>\n<li class=\"task-list-item\"><input type=\"checkbox\" disabled checked> done\n<ul>\n<li>nested</li>\n</ul></li>\n</ul>\n<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>",
+		},
+		{
+			name:     "Loose lists wrap items in paragraphs",
+			content:  "- one\n\n- two",
+			expected: "<ul>\n<li><p>one</p></li>\n<li><p>two</p></li>\n</ul>",
+		},
+		{
+			name:     "Quotes and callouts",
+			content:  "> quoted\n\n> [!warning] Careful\n> body\n\n> [!tip]\n> text",
+			expected: "<blockquote>\n<p>quoted</p>\n</blockquote>\n<div class=\"callout\" data-callout=\"warning\">\n<div class=\"callout-title\">Careful</div>\n<div class=\"callout-content\">\n<p>body</p>\n</div>\n</div>\n<div class=\"callout\" data-callout=\"tip\">\n<div class=\"callout-title\">Tip</div>\n<div class=\"callout-content\">\n<p>text</p>\n</div>\n</div>",
+		},
+		{
+			name:     "Tables with alignment and escaped pipes",
+			content:  "| a | b |\n|:--|--:|\n| 1 \\| 2 | **3** |",
+			expected: "<table>\n<thead>\n<tr><th style=\"text-align: left\">a</th><th style=\"text-align: right\">b</th></tr>\n</thead>\n<tbody>\n<tr><td style=\"text-align: left\">1 | 2</td><td style=\"text-align: right\"><strong>3</strong></td></tr>\n</tbody>\n</table>",
+		},
+		{
+			name:     "Code, math, rules, comments and HTML blocks",
+			content:  "```go\nx := [[1]]\n```\n\n$$\na^2\n$$\n\n---\n\n%%\nhidden\n%%\n\n<div>raw *html*</div>",
+			expected: "<pre><code class=\"language-go\">x := [[1]]\n</code></pre>\n<div class=\"math\">$$a^2$$</div>\n<hr>\n<div>raw *html*</div>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, markdown.ToHTML(test.content, markdown.RenderOptions{}))
+		})
+	}
+
+	t.Run("Escapes raw HTML with EscapeHTML", func(t *testing.T) {
+		html := markdown.ToHTML("<script>alert(1)</script>\n\nHi <img src=x onerror=\"alert(1)\"> <!-- note -->\n\n<iframe src=\"https://example.com\"></iframe>", markdown.RenderOptions{EscapeHTML: true})
+
+		assert.Equal(t, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"+
+			"<p>Hi &lt;img src=x onerror=&#34;alert(1)&#34;&gt; <!-- note --></p>\n"+
+			"<p>&lt;iframe src=&#34;https://example.com&#34;&gt;&lt;/iframe&gt;</p>", html)
+	})
+
+	t.Run("Uses the options for links and embeds", func(t *testing.T) {
+		opts := markdown.RenderOptions{
+			WikiLink: func(target string) (string, bool) {
+				return strings.ToLower(target) + ".html", target != "Missing"
+			},
+			Embed: func(target, alias string) (string, bool) {
+				return "<div class=\"embed\">" + target + "</div>", target != "Missing"
+			},
+			Resource: func(dest string) string {
+				return "files/" + dest
+			},
+		}
+
+		html := markdown.ToHTML("[[Note]] [[Missing|gone]] [doc](a.pdf)\n\n![[Other]]\n\n![[Missing]]", opts)
+
+		assert.Equal(t, "<p><a class=\"internal-link\" href=\"note.html\">Note</a> <span class=\"internal-link is-unresolved\">gone</span> <a href=\"files/a.pdf\">doc</a></p>\n"+
+			"<div class=\"embed\">Other</div>\n"+
+			"<p><span class=\"internal-link is-unresolved\">Missing</span></p>", html)
+
+		html = markdown.ToHTML("See\n![[Other]] and ![[Second]]\nafter ^tail\n\n- item ![[Other]]", opts)
+
+		assert.Equal(t, "<p>See</p>\n<div class=\"embed\">Other</div>\n<p>and</p>\n<div class=\"embed\">Second</div>\n<p id=\"^tail\">after</p>\n"+
+			"<ul>\n<li>item\n<div class=\"embed\">Other</div></li>\n</ul>", html)
+	})
+}
+
+func TestHeadingIDs(t *testing.T) {
+	ids := markdown.HeadingIDs("# Intro\n```\n# code\n```\n## Intro\n")
+	assert.Equal(t, map[string]int{"intro": 2, "intro-1": 1}, ids)
+
+	html := markdown.ToHTML("# Intro\n# Intro-1", markdown.RenderOptions{HeadingIDs: ids})
+
+	assert.Equal(t, "<h1 id=\"intro-2\">Intro</h1>\n<h1 id=\"intro-1-1\">Intro-1</h1>", html)
+}
+
+func TestHeadingID(t *testing.T) {
+	assert.Equal(t, "what-s-new-in-2-0", markdown.HeadingID("What's new in 2.0?"))
+	assert.Equal(t, "überblick", markdown.HeadingID("  Überblick "))
+}
@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// Heading is an ATX heading ("## Title") found in a note.
+type Heading struct {
+	Level int
+	Text  string
+	Line  int // zero-based line index
+}
+
+// ParseHeading parses line as an ATX heading.
+func ParseHeading(line string) (Heading, bool) {
+	trimmed := strings.TrimRight(line, " \t\r")
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return Heading{}, false
+	}
+	if level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t' {
+		return Heading{}, false
+	}
+	text := strings.TrimSpace(trimmed[level:])
+	// Optional closing sequence: "## Title ##"
+	if stripped := strings.TrimRight(text, "#"); stripped != text && (stripped == "" || strings.HasSuffix(stripped, " ")) {
+		text = strings.TrimSpace(stripped)
+	}
+	return Heading{Level: level, Text: text}, true
+}
+
+// Headings returns every heading in lines, skipping frontmatter and fenced
+// code blocks.
+func Headings(lines []string) []Heading {
+	var headings []Heading
+	inFence := false
+	for i := BodyStart(lines); i < len(lines); i++ {
+		line := lines[i]
+		if isFence(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if h, ok := ParseHeading(line); ok {
+			h.Line = i
+			headings = append(headings, h)
+		}
+	}
+	return headings
+}
+
+// FindSection locates the section introduced by heading and returns the
+// zero-based index of the heading line and the index one past the section's
+// last line. The section ends at the next heading of the same or higher level.
+//
+// heading may include the leading hashes ("## Log") to match only that level,
+// or be bare text ("Log") to match a heading of any level.
+func FindSection(lines []string, heading string) (int, int, bool) {
+	wantLevel := 0
+	wantText := strings.TrimSpace(heading)
+	if h, ok := ParseHeading(wantText); ok {
+		wantLevel = h.Level
+		wantText = h.Text
+	}
+
+	headings := Headings(lines)
+	for i, h := range headings {
+		if h.Text != wantText || (wantLevel != 0 && h.Level != wantLevel) {
+			continue
+		}
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.Level <= h.Level {
+				end = next.Line
+				break
+			}
+		}
+		return h.Line, end, true
+	}
+	return 0, 0, false
+}
+
+// FindHeadingPath is like FindSection, but also accepts a path of nested
+// headings separated by "#" ("Project#Decisions"), as used in Obsidian links.
+// Each heading is looked for within the section of the one before it.
+func FindHeadingPath(lines []string, headingPath string) (int, int, bool) {
+	if start, end, ok := FindSection(lines, headingPath); ok || strings.HasPrefix(strings.TrimSpace(headingPath), "#") {
+		return start, end, ok
+	}
+
+	offset, section := 0, lines
+	start, end, found := 0, 0, false
+	for _, heading := range strings.Split(headingPath, "#") {
+		if strings.TrimSpace(heading) == "" {
+			continue
+		}
+		s, e, ok := FindSection(section, heading)
+		if !ok {
+			return 0, 0, false
+		}
+		start, end, found = offset+s, offset+e, true
+		// Search the next heading below this one, keeping the line indexes of
+		// the whole note. The heading line is blanked so it is not found again.
+		offset += s
+		section = append([]string{""}, section[s+1:e]...)
+	}
+	return start, end, found
+}
+
+// InsertUnderHeading inserts text at the end of the section introduced by
+// heading, directly after its last non-blank line. If the heading does not
+// exist it is appended to the end of content, followed by text.
+func InsertUnderHeading(content, heading, text string) string {
+	lines := strings.Split(content, "\n")
+	start, end, ok := FindSection(lines, heading)
+	if !ok {
+		return appendHeading(content, heading, text)
+	}
+
+	insertAt := end
+	for insertAt > start+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+
+	inserted := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	result := make([]string, 0, len(lines)+len(inserted))
+	result = append(result, lines[:insertAt]...)
+	result = append(result, inserted...)
+	result = append(result, lines[insertAt:]...)
+	return strings.Join(result, "\n")
+}
+
+// InsertAfterFrontmatter inserts text at the start of the note body, directly
+// after a leading frontmatter block, or at the very start when there is none.
+func InsertAfterFrontmatter(content, text string) string {
+	lines := strings.Split(content, "\n")
+	start := BodyStart(lines)
+	inserted := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if content == "" {
+		return strings.Join(inserted, "\n") + "\n"
+	}
+
+	result := make([]string, 0, len(lines)+len(inserted))
+	result = append(result, lines[:start]...)
+	result = append(result, inserted...)
+	result = append(result, lines[start:]...)
+	return strings.Join(result, "\n")
+}
+
+func appendHeading(content, heading, text string) string {
+	if !strings.HasPrefix(strings.TrimSpace(heading), "#") {
+		heading = "## " + strings.TrimSpace(heading)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(content)
+	if content != "" {
+		if !strings.HasSuffix(content, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(strings.TrimSpace(heading))
+	sb.WriteString("\n")
+	sb.WriteString(text)
+	if !strings.HasSuffix(text, "\n") {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// BodyStart returns the index of the first line after a leading frontmatter
+// block ("---" YAML, "+++" TOML or ";;;" JSON), or 0 when the note has none.
+func BodyStart(lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" && delimiter != ";;;" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
@@ -0,0 +1,133 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeading(t *testing.T) {
+	tests := []struct {
+		testName string
+		line     string
+		ok       bool
+		level    int
+		text     string
+	}{
+		{testName: "Level 2", line: "## Log", ok: true, level: 2, text: "Log"},
+		{testName: "Closing hashes", line: "### Notes ###", ok: true, level: 3, text: "Notes"},
+		{testName: "Tag is not a heading", line: "#tag", ok: false},
+		{testName: "Too many hashes", line: "####### Seven", ok: false},
+		{testName: "Plain text", line: "Log", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			h, ok := markdown.ParseHeading(test.line)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.level, h.Level)
+			assert.Equal(t, test.text, h.Text)
+		})
+	}
+}
+
+func TestFindSection(t *testing.T) {
+	lines := strings.Split("---\n# not: a heading\n---\n# Day\n## Log\nentry\n### Detail\nmore\n```\n## Fenced\n```\n## Tasks\n- [ ] a", "\n")
+
+	t.Run("Section ends at next heading of same level", func(t *testing.T) {
+		start, end, ok := markdown.FindSection(lines, "## Log")
+		assert.True(t, ok)
+		assert.Equal(t, 4, start)
+		assert.Equal(t, 11, end)
+	})
+
+	t.Run("Bare text matches any level", func(t *testing.T) {
+		start, end, ok := markdown.FindSection(lines, "Tasks")
+		assert.True(t, ok)
+		assert.Equal(t, 11, start)
+		assert.Equal(t, len(lines), end)
+	})
+
+	t.Run("Level must match when given", func(t *testing.T) {
+		_, _, ok := markdown.FindSection(lines, "# Log")
+		assert.False(t, ok)
+	})
+
+	t.Run("Ignores frontmatter and fenced headings", func(t *testing.T) {
+		_, _, ok := markdown.FindSection(lines, "Fenced")
+		assert.False(t, ok)
+		_, _, ok = markdown.FindSection(lines, "not: a heading")
+		assert.False(t, ok)
+	})
+}
+
+func TestFindHeadingPath(t *testing.T) {
+	lines := strings.Split("# Project\n## Decisions\nA\n# Other\n## Decisions\nB\n### C# tips\nC", "\n")
+
+	t.Run("Single heading matches the first one", func(t *testing.T) {
+		start, end, ok := markdown.FindHeadingPath(lines, "Decisions")
+		assert.True(t, ok)
+		assert.Equal(t, []int{1, 3}, []int{start, end})
+	})
+
+	t.Run("Path narrows down to a nested heading", func(t *testing.T) {
+		start, end, ok := markdown.FindHeadingPath(lines, "Other#Decisions")
+		assert.True(t, ok)
+		assert.Equal(t, []int{4, 8}, []int{start, end})
+	})
+
+	t.Run("Headings containing # are found as a whole", func(t *testing.T) {
+		start, end, ok := markdown.FindHeadingPath(lines, "C# tips")
+		assert.True(t, ok)
+		assert.Equal(t, []int{6, 8}, []int{start, end})
+	})
+
+	t.Run("Missing nested heading", func(t *testing.T) {
+		_, _, ok := markdown.FindHeadingPath(lines, "Project#C# tips")
+		assert.False(t, ok)
+	})
+}
+
+func TestInsertUnderHeading(t *testing.T) {
+	t.Run("Inserts after last non-blank line of section", func(t *testing.T) {
+		content := "## Todo\n- [ ] a\n\n## Done\n- [x] b\n"
+		result := markdown.InsertUnderHeading(content, "## Todo", "- [ ] c")
+		assert.Equal(t, "## Todo\n- [ ] a\n- [ ] c\n\n## Done\n- [x] b\n", result)
+	})
+
+	t.Run("Inserts directly below empty heading", func(t *testing.T) {
+		content := "## Todo\n\n## Done\n"
+		result := markdown.InsertUnderHeading(content, "## Todo", "- [ ] c")
+		assert.Equal(t, "## Todo\n- [ ] c\n\n## Done\n", result)
+	})
+
+	t.Run("Appends missing heading", func(t *testing.T) {
+		result := markdown.InsertUnderHeading("Some text", "## Todo", "- [ ] c")
+		assert.Equal(t, "Some text\n\n## Todo\n- [ ] c\n", result)
+	})
+
+	t.Run("Appends missing bare heading as level 2", func(t *testing.T) {
+		result := markdown.InsertUnderHeading("", "Todo", "- [ ] c")
+		assert.Equal(t, "## Todo\n- [ ] c\n", result)
+	})
+}
+
+func TestInsertAfterFrontmatter(t *testing.T) {
+	t.Run("Inserts after frontmatter", func(t *testing.T) {
+		content := "---\ntitle: Log\n---\n# Log\n- old\n"
+		result := markdown.InsertAfterFrontmatter(content, "- new")
+		assert.Equal(t, "---\ntitle: Log\n---\n- new\n# Log\n- old\n", result)
+	})
+
+	t.Run("Inserts at the start without frontmatter", func(t *testing.T) {
+		result := markdown.InsertAfterFrontmatter("# Log\n", "- new\n")
+		assert.Equal(t, "- new\n# Log\n", result)
+	})
+
+	t.Run("Empty note", func(t *testing.T) {
+		result := markdown.InsertAfterFrontmatter("", "- new")
+		assert.Equal(t, "- new\n", result)
+	})
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inlineTagPattern matches Obsidian inline tags. Tags may contain letters,
+// digits, "_", "-" and "/" (for nested tags) but cannot be purely numeric.
+var inlineTagPattern = regexp.MustCompile(`(?:^|[\s(])#([\p{L}\p{N}_/-]*[\p{L}_/-][\p{L}\p{N}_/-]*)`)
+
+// InlineTags returns the "#tags" used in content (without the leading "#"),
+// skipping frontmatter, fenced code blocks and inline code.
+func InlineTags(content string) []string {
+	lines := strings.Split(content, "\n")
+	var tags []string
+	seen := map[string]bool{}
+	inFence := false
+	for _, line := range lines[BodyStart(lines):] {
+		if isFence(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, match := range inlineTagPattern.FindAllStringSubmatch(stripInlineCode(line), -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				tags = append(tags, match[1])
+			}
+		}
+	}
+	return tags
+}
+
+// stripInlineCode blanks out `code spans` so their contents are not parsed.
+func stripInlineCode(line string) string {
+	parts := strings.Split(line, "`")
+	for i := 1; i < len(parts); i += 2 {
+		if i == len(parts)-1 {
+			break // unmatched backtick
+		}
+		parts[i] = ""
+	}
+	return strings.Join(parts, "`")
+}
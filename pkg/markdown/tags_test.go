@@ -0,0 +1,14 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInlineTags(t *testing.T) {
+	content := "---\ntitle: #notatag\n---\n# Heading\nSome #meeting and #project/alpha (#inline).\nIssue #123 is not a tag, `#code` neither.\n```\n#fenced\n```\n#meeting again"
+
+	assert.Equal(t, []string{"meeting", "project/alpha", "inline"}, markdown.InlineTags(content))
+}
@@ -0,0 +1,136 @@
+package obsidian
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WriteAttempts is how often a read-modify-write of a note is attempted when
+// the note keeps changing between the read and the write.
+const WriteAttempts = 3
+
+// FileVersion identifies the state of a file when it was read, so that
+// writing it back can detect changes made in the meantime by Obsidian, a sync
+// tool or another process. The zero value stands for a file that did not
+// exist.
+type FileVersion struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+}
+
+// ReadFileVersion reads a file along with its version.
+func ReadFileVersion(path string) ([]byte, FileVersion, error) {
+	// Stat before reading: a change in between then shows up as a newer
+	// modification time with matching contents, which is not a conflict.
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, FileVersion{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, FileVersion{}, err
+	}
+	return data, newFileVersion(info, data), nil
+}
+
+func newFileVersion(info os.FileInfo, data []byte) FileVersion {
+	return FileVersion{exists: true, modTime: info.ModTime(), size: info.Size(), hash: sha256.Sum256(data)}
+}
+
+// Changed reports whether the file at path no longer matches v. Files whose
+// modification time and size are unchanged are assumed unchanged; otherwise
+// their contents are compared.
+func (v FileVersion) Changed(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return v.exists, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !v.exists {
+		return true, nil
+	}
+	if info.ModTime().Equal(v.modTime) && info.Size() == v.size {
+		return false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	return sha256.Sum256(data) != v.hash, nil
+}
+
+// WriteFileAtomic writes data to a temporary file next to path, syncs it and
+// renames it over path, so that a crash never leaves a truncated file behind.
+// An existing file keeps its permissions; a new one is created with perm.
+// Symlinks are followed and their target is replaced. A file that cannot be
+// opened for writing is not replaced either, as with os.WriteFile.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	cleanup := func(err error) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return cleanup(err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// Sync the directory so the rename itself survives a crash. Not all
+	// platforms support this, so failures are ignored.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// WriteFileIfUnchanged is like WriteFileAtomic, but fails with
+// NoteChangedError when the file no longer matches version.
+func WriteFileIfUnchanged(path string, data []byte, perm os.FileMode, version FileVersion) error {
+	changed, err := version.Changed(path)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return errors.New(NoteChangedError)
+	}
+	return WriteFileAtomic(path, data, perm)
+}
@@ -0,0 +1,141 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("Replaces the file and keeps its permissions", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "note.md")
+		if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		err := obsidian.WriteFileAtomic(path, []byte("new"), 0644)
+
+		assert.NoError(t, err)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, "new", string(data))
+		info, _ := os.Stat(path)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		entries, _ := os.ReadDir(dir)
+		assert.Len(t, entries, 1, "no temporary file is left behind")
+	})
+
+	t.Run("Creates a new file with the given permissions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "note.md")
+
+		err := obsidian.WriteFileAtomic(path, []byte("new"), 0640)
+
+		assert.NoError(t, err)
+		info, _ := os.Stat(path)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	})
+
+	t.Run("Writes through symlinks", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "target.md")
+		link := filepath.Join(dir, "link.md")
+		if err := os.WriteFile(target, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Skip("symlinks not supported")
+		}
+
+		err := obsidian.WriteFileAtomic(link, []byte("new"), 0644)
+
+		assert.NoError(t, err)
+		data, _ := os.ReadFile(target)
+		assert.Equal(t, "new", string(data))
+		info, _ := os.Lstat(link)
+		assert.NotZero(t, info.Mode()&os.ModeSymlink)
+	})
+
+	t.Run("Does not replace read-only files", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write read-only files")
+		}
+		dir := t.TempDir()
+		path := filepath.Join(dir, "note.md")
+		if err := os.WriteFile(path, []byte("old"), 0444); err != nil {
+			t.Fatal(err)
+		}
+
+		err := obsidian.WriteFileAtomic(path, []byte("new"), 0644)
+
+		assert.Error(t, err)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, "old", string(data))
+	})
+}
+
+func TestWriteFileIfUnchanged(t *testing.T) {
+	t.Run("Writes when the file is unchanged", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "note.md")
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, version, err := obsidian.ReadFileVersion(path)
+		assert.NoError(t, err)
+
+		err = obsidian.WriteFileIfUnchanged(path, []byte("new"), 0644, version)
+
+		assert.NoError(t, err)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("Refuses to overwrite changed contents", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "note.md")
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, version, err := obsidian.ReadFileVersion(path)
+		assert.NoError(t, err)
+		if err := os.WriteFile(path, []byte("edited elsewhere"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err = obsidian.WriteFileIfUnchanged(path, []byte("new"), 0644, version)
+
+		assert.EqualError(t, err, obsidian.NoteChangedError)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, "edited elsewhere", string(data))
+	})
+
+	t.Run("A newer modification time with the same contents is not a change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "note.md")
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, version, err := obsidian.ReadFileVersion(path)
+		assert.NoError(t, err)
+		later := time.Now().Add(time.Hour)
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatal(err)
+		}
+
+		err = obsidian.WriteFileIfUnchanged(path, []byte("new"), 0644, version)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("A file created since it was found missing is a change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "note.md")
+		if err := os.WriteFile(path, []byte("created elsewhere"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := obsidian.WriteFileIfUnchanged(path, []byte("new"), 0644, obsidian.FileVersion{})
+
+		assert.EqualError(t, err, obsidian.NoteChangedError)
+	})
+}
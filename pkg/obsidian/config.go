@@ -2,17 +2,24 @@ package obsidian
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
 // ObsidianAppConfig represents relevant fields from .obsidian/app.json.
 type ObsidianAppConfig struct {
-	NewFileLocation   string   `json:"newFileLocation"`
-	NewFileFolderPath string   `json:"newFileFolderPath"`
-	UserIgnoreFilters []string `json:"userIgnoreFilters"`
+	NewFileLocation      string   `json:"newFileLocation"`
+	NewFileFolderPath    string   `json:"newFileFolderPath"`
+	UserIgnoreFilters    []string `json:"userIgnoreFilters"`
+	AttachmentFolderPath string   `json:"attachmentFolderPath"`
+}
+
+// ObsidianTypesConfig represents .obsidian/types.json, where Obsidian stores
+// the declared type of each property.
+type ObsidianTypesConfig struct {
+	Types map[string]string `json:"types"`
 }
 
 // DailyNotesConfig represents relevant fields from .obsidian/daily-notes.json.
@@ -22,6 +29,23 @@ type DailyNotesConfig struct {
 	Template string `json:"template"`
 }
 
+// ZkPrefixerConfig represents relevant fields from the Unique note creator
+// core plugin's .obsidian/zk-prefixer.json.
+type ZkPrefixerConfig struct {
+	Folder   string `json:"folder"`
+	Format   string `json:"format"`
+	Template string `json:"template"`
+}
+
+// RolloverTodosConfig represents relevant fields from the Rollover Daily Todos
+// plugin's .obsidian/plugins/rollover-daily-todos/data.json.
+type RolloverTodosConfig struct {
+	TemplateHeading  string `json:"templateHeading"`
+	DeleteOnComplete bool   `json:"deleteOnComplete"`
+	RemoveEmptyTodos bool   `json:"removeEmptyTodos"`
+	RolloverChildren bool   `json:"rolloverChildren"`
+}
+
 // ExcludedPaths reads the userIgnoreFilters from .obsidian/app.json and returns
 // the list of path patterns to exclude. Returns nil if the config is absent or unreadable.
 func ExcludedPaths(vaultPath string) []string {
@@ -59,6 +83,41 @@ func DefaultNoteFolder(vaultPath string) string {
 	return ""
 }
 
+// AttachmentFolder returns the vault-relative folder for attachments of a
+// note in noteFolder, following attachmentFolderPath in .obsidian/app.json:
+// "/" (the default) is the vault root, "./" the note's folder and "./sub" a
+// subfolder of it. Returns "" for the vault root.
+func AttachmentFolder(vaultPath, noteFolder string) string {
+	var config ObsidianAppConfig
+	if data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "app.json")); err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			config = ObsidianAppConfig{}
+		}
+	}
+
+	folder := config.AttachmentFolderPath
+	if folder == "." || strings.HasPrefix(folder, "./") {
+		folder = path.Join(noteFolder, folder)
+	}
+	return strings.Trim(path.Clean("/"+folder), "/")
+}
+
+// ReadPropertyTypes reads the declared property types from
+// .obsidian/types.json. Returns nil if the config is absent or unreadable.
+func ReadPropertyTypes(vaultPath string) map[string]string {
+	data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "types.json"))
+	if err != nil {
+		return nil
+	}
+
+	var config ObsidianTypesConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil
+	}
+
+	return config.Types
+}
+
 // ReadDailyNotesConfig reads the daily notes plugin config from the vault.
 // Returns zero-value config if unreadable.
 func ReadDailyNotesConfig(vaultPath string) DailyNotesConfig {
@@ -75,6 +134,24 @@ func ReadDailyNotesConfig(vaultPath string) DailyNotesConfig {
 	return config
 }
 
+// ReadZkPrefixerConfig reads the Unique note creator plugin config from the
+// vault, defaulting the format to YYYYMMDDHHmm as Obsidian does. Returns the
+// defaults if unreadable.
+func ReadZkPrefixerConfig(vaultPath string) ZkPrefixerConfig {
+	config := ZkPrefixerConfig{}
+	if data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "zk-prefixer.json")); err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			config = ZkPrefixerConfig{}
+		}
+	}
+
+	config.Folder = strings.Trim(config.Folder, "/")
+	if config.Format == "" {
+		config.Format = "YYYYMMDDHHmm"
+	}
+	return config
+}
+
 // ApplyDefaultFolder prepends the configured default note folder to noteName
 // when noteName has no explicit path (no "/"). If the note name already
 // contains a "/", it is treated as an explicit path and returned unchanged.
@@ -89,49 +166,22 @@ func ApplyDefaultFolder(noteName, vaultPath string) string {
 	return noteName
 }
 
-// MomentToGoFormat converts a Moment.js date format string to a Go time layout.
-// It uses a two-pass approach with placeholders to avoid cascading replacements
-// (e.g., replacing "a" inside "January").
-//
-// Note: the Moment.js "dd" token (2-letter weekday like "Mo", "Tu") has no Go
-// equivalent and is not supported.
-func MomentToGoFormat(momentFmt string) string {
-	// Order matters: longer tokens must be replaced before shorter ones.
-	replacements := []struct {
-		moment string
-		goFmt  string
-	}{
-		{"YYYY", "2006"},
-		{"YY", "06"},
-		{"MMMM", "January"},
-		{"MMM", "Jan"},
-		{"MM", "01"},
-		{"M", "1"},
-		{"DD", "02"},
-		{"D", "2"},
-		{"dddd", "Monday"},
-		{"ddd", "Mon"},
-		{"HH", "15"},
-		{"hh", "03"},
-		{"h", "3"},
-		{"mm", "04"},
-		{"ss", "05"},
-		{"A", "PM"},
-		{"a", "pm"},
-	}
-
-	// Pass 1: replace Moment tokens with unique placeholders.
-	result := momentFmt
-	for i, r := range replacements {
-		placeholder := fmt.Sprintf("\x00%d\x00", i)
-		result = strings.ReplaceAll(result, r.moment, placeholder)
-	}
-
-	// Pass 2: replace placeholders with Go format strings.
-	for i, r := range replacements {
-		placeholder := fmt.Sprintf("\x00%d\x00", i)
-		result = strings.ReplaceAll(result, placeholder, r.goFmt)
-	}
-
-	return result
+// ReadRolloverTodosConfig reads the Rollover Daily Todos plugin config from
+// the vault. A "none" heading is returned as "". Returns zero-value config if
+// unreadable.
+func ReadRolloverTodosConfig(vaultPath string) RolloverTodosConfig {
+	data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "plugins", "rollover-daily-todos", "data.json"))
+	if err != nil {
+		return RolloverTodosConfig{}
+	}
+
+	var config RolloverTodosConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return RolloverTodosConfig{}
+	}
+
+	if config.TemplateHeading == "none" {
+		config.TemplateHeading = ""
+	}
+	return config
 }
@@ -199,6 +199,57 @@ func TestReadDailyNotesConfig(t *testing.T) {
 	})
 }
 
+func TestReadZkPrefixerConfig(t *testing.T) {
+	t.Run("Reads full config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "zk-prefixer.json"), []byte(`{
+			"folder": "Zettelkasten/",
+			"format": "YYYYMMDDHHmmss",
+			"template": "Templates/Zettel"
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		config := obsidian.ReadZkPrefixerConfig(tmpDir)
+		assert.Equal(t, obsidian.ZkPrefixerConfig{Folder: "Zettelkasten", Format: "YYYYMMDDHHmmss", Template: "Templates/Zettel"}, config)
+	})
+
+	t.Run("Defaults the format when file is absent", func(t *testing.T) {
+		config := obsidian.ReadZkPrefixerConfig(t.TempDir())
+		assert.Equal(t, obsidian.ZkPrefixerConfig{Format: "YYYYMMDDHHmm"}, config)
+	})
+}
+
+func TestAttachmentFolder(t *testing.T) {
+	tests := map[string]string{
+		"":              "",
+		"/":             "",
+		"Attachments":   "Attachments",
+		"./":            "Clippings",
+		"./assets":      "Clippings/assets",
+		"../../outside": "outside",
+	}
+	for setting, want := range tests {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "app.json"), []byte(`{"attachmentFolderPath": "`+setting+`"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, want, obsidian.AttachmentFolder(tmpDir, "Clippings"), setting)
+	}
+
+	t.Run("Defaults to the vault root", func(t *testing.T) {
+		assert.Equal(t, "", obsidian.AttachmentFolder(t.TempDir(), "Clippings"))
+	})
+}
+
 func TestExcludedPaths(t *testing.T) {
 	t.Run("Returns filters from app.json", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -253,27 +304,24 @@ func TestExcludedPaths(t *testing.T) {
 	})
 }
 
-func TestMomentToGoFormat(t *testing.T) {
-	tests := []struct {
-		name     string
-		moment   string
-		expected string
-	}{
-		{"Default format", "YYYY-MM-DD", "2006-01-02"},
-		{"Year month day with slashes", "YYYY/MM/DD", "2006/01/02"},
-		{"Short year", "YY-MM-DD", "06-01-02"},
-		{"Full month name", "MMMM DD, YYYY", "January 02, 2006"},
-		{"Short month name", "MMM DD, YYYY", "Jan 02, 2006"},
-		{"Day of week", "dddd, MMMM DD, YYYY", "Monday, January 02, 2006"},
-		{"Short day of week", "ddd MMM DD", "Mon Jan 02"},
-		{"With time", "YYYY-MM-DD HH:mm", "2006-01-02 15:04"},
-		{"Plain text passthrough", "notes", "notes"},
-	}
+func TestReadPropertyTypes(t *testing.T) {
+	t.Run("Reads declared property types", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "types.json"), []byte(`{
+			"types": {"priority": "number", "tags": "tags"}
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := obsidian.MomentToGoFormat(tt.moment)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+		types := obsidian.ReadPropertyTypes(tmpDir)
+		assert.Equal(t, map[string]string{"priority": "number", "tags": "tags"}, types)
+	})
+
+	t.Run("Returns nil when config is missing", func(t *testing.T) {
+		assert.Nil(t, obsidian.ReadPropertyTypes(t.TempDir()))
+	})
 }
@@ -6,6 +6,7 @@ const (
 	VaultAccessError                   = "Failed to access vault directory"
 	VaultReadError                     = "Failed to read notes in vault"
 	VaultWriteError                    = "Failed to write to update notes in vault"
+	NoteChangedError                   = "Note was changed by another program since it was read"
 	ObsidianCLIConfigReadError         = "Cannot find vault config, please use set-default-vault command to set default vault or use --vault flag"
 	ObsidianCLIConfigParseError        = "Could not parse vault config file, please use set-default-vault command to set default vault or use --vault flag"
 	ObsidianCLIConfigDirWriteEror      = "Failed to create vault config directory. Please ensure you have the correct permissions."
@@ -0,0 +1,135 @@
+package obsidian
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	offsetPattern = regexp.MustCompile(`^([+-])(\d+)([dwmy])$`)
+	agoPattern    = regexp.MustCompile(`^(\d+) (day|week|month|year)s? ago$`)
+	inPattern     = regexp.MustCompile(`^in (\d+) (day|week|month|year)s?$`)
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+// ParseWeekday returns the weekday for a full or three-letter English day name.
+func ParseWeekday(name string) (time.Weekday, bool) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+	return day, ok
+}
+
+// ParseDate resolves a date expression relative to now and returns midnight of
+// that day in now's location.
+// Supported expressions:
+//   - ISO dates: "2026-10-01"
+//   - "today", "tomorrow", "yesterday"
+//   - Weekday names: "friday" — the next Friday, or today if today is Friday
+//   - "next friday" — the next Friday strictly after today
+//   - "last friday" — the last Friday strictly before today
+//   - Offsets: "-2d", "+1w", "-3m", "+1y", "3 days ago", "in 2 weeks"
+func ParseDate(expr string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	normalized := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+
+	switch normalized {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", normalized, now.Location()); err == nil {
+		return date, nil
+	}
+
+	if day, ok := ParseWeekday(normalized); ok {
+		return today.AddDate(0, 0, daysUntil(today.Weekday(), day, false)), nil
+	}
+
+	if name, ok := strings.CutPrefix(normalized, "next "); ok {
+		if day, ok := ParseWeekday(name); ok {
+			return today.AddDate(0, 0, daysUntil(today.Weekday(), day, true)), nil
+		}
+	}
+
+	if name, ok := strings.CutPrefix(normalized, "last "); ok {
+		if day, ok := ParseWeekday(name); ok {
+			return today.AddDate(0, 0, -daysUntil(day, today.Weekday(), true)), nil
+		}
+	}
+
+	if m := offsetPattern.FindStringSubmatch(normalized); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		return addUnits(today, n, m[3]), nil
+	}
+	if m := agoPattern.FindStringSubmatch(normalized); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return addUnits(today, -n, m[2][:1]), nil
+	}
+	if m := inPattern.FindStringSubmatch(normalized); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return addUnits(today, n, m[2][:1]), nil
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse date %q", expr)
+}
+
+// daysUntil returns how many days lie between from and the next occurrence of
+// to. When strict is set, a matching weekday counts as a week away.
+func daysUntil(from, to time.Weekday, strict bool) int {
+	days := (int(to) - int(from) + 7) % 7
+	if days == 0 && strict {
+		return 7
+	}
+	return days
+}
+
+// addUnits adds n days (d), weeks (w), months (m) or years (y) to date.
+// Months and years clamp to the end of the target month.
+func addUnits(date time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "w":
+		return date.AddDate(0, 0, 7*n)
+	case "m":
+		return AddMonthsClamped(date, n)
+	case "y":
+		return AddMonthsClamped(date, 12*n)
+	}
+	return date.AddDate(0, 0, n)
+}
+
+// AddMonthsClamped adds months to t, clamping to the last day of the target
+// month instead of overflowing (Jan 31 + 1 month = Feb 28). The time of day
+// is kept.
+func AddMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,83 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	// Monday
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		testName string
+		expr     string
+		want     string
+	}{
+		{testName: "ISO date", expr: "2026-10-01", want: "2026-10-01"},
+		{testName: "Today", expr: "today", want: "2026-10-19"},
+		{testName: "Tomorrow", expr: "Tomorrow", want: "2026-10-20"},
+		{testName: "Yesterday", expr: "yesterday", want: "2026-10-18"},
+		{testName: "Weekday later this week", expr: "friday", want: "2026-10-23"},
+		{testName: "Weekday abbreviation", expr: "fri", want: "2026-10-23"},
+		{testName: "Same weekday is today", expr: "monday", want: "2026-10-19"},
+		{testName: "Next same weekday", expr: "next monday", want: "2026-10-26"},
+		{testName: "Next weekday", expr: "next  wednesday", want: "2026-10-21"},
+		{testName: "Last same weekday", expr: "last monday", want: "2026-10-12"},
+		{testName: "Last weekday", expr: "last friday", want: "2026-10-16"},
+		{testName: "Days back", expr: "-2d", want: "2026-10-17"},
+		{testName: "Weeks ahead", expr: "+1w", want: "2026-10-26"},
+		{testName: "Months back", expr: "-1m", want: "2026-09-19"},
+		{testName: "Years ahead", expr: "+1y", want: "2027-10-19"},
+		{testName: "Days ago", expr: "3 days ago", want: "2026-10-16"},
+		{testName: "In weeks", expr: "in 2 weeks", want: "2026-11-02"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			got, err := obsidian.ParseDate(test.expr, now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got.Format("2006-01-02"))
+			assert.Equal(t, 0, got.Hour())
+		})
+	}
+
+	t.Run("Month offsets clamp to the end of the month", func(t *testing.T) {
+		jan31 := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+		for expr, want := range map[string]string{
+			"+1m":         "2026-02-28",
+			"in 3 months": "2026-04-30",
+			"-2m":         "2025-11-30",
+			"1 month ago": "2025-12-31",
+			"+1y":         "2027-01-31",
+		} {
+			got, err := obsidian.ParseDate(expr, jan31)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got.Format("2006-01-02"), expr)
+		}
+
+		got, err := obsidian.ParseDate("+1y", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, "2029-02-28", got.Format("2006-01-02"))
+	})
+
+	t.Run("Invalid expression", func(t *testing.T) {
+		_, err := obsidian.ParseDate("someday", now)
+		assert.Error(t, err)
+	})
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	t.Run("Clamps to the last day of the month", func(t *testing.T) {
+		got := obsidian.AddMonthsClamped(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), 1)
+		assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), got)
+	})
+
+	t.Run("Keeps the time of day", func(t *testing.T) {
+		got := obsidian.AddMonthsClamped(time.Date(2026, 10, 31, 15, 30, 0, 0, time.UTC), -8)
+		assert.Equal(t, time.Date(2026, 2, 28, 15, 30, 0, 0, time.UTC), got)
+	})
+}
@@ -0,0 +1,782 @@
+package obsidian
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// momentTokens lists the Moment.js format tokens FormatMoment and ParseMoment
+// understand, longest first so that "YYYY" wins over "YY".
+var momentTokens = []string{
+	"SSSSSSSSS", "SSSSSSSS", "SSSSSSS", "SSSSSS", "SSSSS",
+	"YYYY", "GGGG", "gggg", "MMMM", "DDDD", "DDDo", "dddd", "SSSS", "LLLL", "llll",
+	"MMM", "DDD", "ddd", "SSS", "LTS", "LLL", "lll",
+	"YY", "GG", "gg", "Qo", "MM", "Mo", "DD", "Do", "dd", "do", "WW", "Wo", "ww", "wo",
+	"HH", "hh", "kk", "mm", "ss", "SS", "ZZ", "LT", "LL", "ll",
+	"Y", "Q", "M", "D", "d", "e", "E", "W", "w", "H", "h", "k", "m", "s", "S",
+	"A", "a", "Z", "X", "x", "L", "l",
+}
+
+// localizedFormats are the long date formats of Moment's English locale.
+var localizedFormats = map[string]string{
+	"LT":   "h:mm A",
+	"LTS":  "h:mm:ss A",
+	"L":    "MM/DD/YYYY",
+	"LL":   "MMMM D, YYYY",
+	"LLL":  "MMMM D, YYYY h:mm A",
+	"LLLL": "dddd, MMMM D, YYYY h:mm A",
+	"l":    "M/D/YYYY",
+	"ll":   "MMM D, YYYY",
+	"lll":  "MMM D, YYYY h:mm A",
+	"llll": "ddd, MMM D, YYYY h:mm A",
+}
+
+// momentPart is a token or a run of literal text in a Moment.js format.
+type momentPart struct {
+	token   string
+	literal string
+}
+
+// tokenizeMoment splits a Moment.js format into tokens and literal text.
+// Text in square brackets and characters escaped with a backslash are
+// literal; localized formats (L, LL, LT, ...) are expanded.
+func tokenizeMoment(format string) []momentPart {
+	var parts []momentPart
+	literal := func(s string) {
+		if n := len(parts); n > 0 && parts[n-1].token == "" {
+			parts[n-1].literal += s
+			return
+		}
+		parts = append(parts, momentPart{literal: s})
+	}
+
+	for i := 0; i < len(format); {
+		switch format[i] {
+		case '[':
+			if end := strings.IndexByte(format[i:], ']'); end != -1 {
+				literal(format[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		case '\\':
+			if i+1 < len(format) {
+				literal(format[i+1 : i+2])
+				i += 2
+				continue
+			}
+		}
+
+		token := matchMomentToken(format[i:])
+		switch {
+		case token == "":
+			literal(format[i : i+1])
+			i++
+			continue
+		case localizedFormats[token] != "":
+			parts = append(parts, tokenizeMoment(localizedFormats[token])...)
+		default:
+			parts = append(parts, momentPart{token: token})
+		}
+		i += len(token)
+	}
+	return parts
+}
+
+func matchMomentToken(s string) string {
+	for _, token := range momentTokens {
+		if strings.HasPrefix(s, token) {
+			return token
+		}
+	}
+	return ""
+}
+
+// FormatMoment formats t with a Moment.js format string, as Moment's default
+// English locale does. Text in square brackets is copied literally
+// ("YYYY-[W]ww"). Week tokens w, gggg and e follow the English locale (weeks
+// start on Sunday; the week containing January 1st is week 1); W, GGGG and E
+// follow ISO 8601.
+func FormatMoment(t time.Time, format string) string {
+	var sb strings.Builder
+	for _, part := range tokenizeMoment(format) {
+		if part.token == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+		sb.WriteString(formatMomentToken(t, part.token))
+	}
+	return sb.String()
+}
+
+func formatMomentToken(t time.Time, token string) string {
+	pad := func(n, width int) string {
+		s := strconv.Itoa(abs(n))
+		for len(s) < width {
+			s = "0" + s
+		}
+		if n < 0 {
+			return "-" + s
+		}
+		return s
+	}
+
+	localeWeek, localeYear := LocaleWeek(t)
+	isoYear, isoWeek := t.ISOWeek()
+
+	switch token {
+	case "YYYY":
+		return pad(t.Year(), 4)
+	case "YY":
+		return pad(t.Year()%100, 2)
+	case "Y":
+		return strconv.Itoa(t.Year())
+	case "Q":
+		return strconv.Itoa(quarter(t))
+	case "Qo":
+		return Ordinal(quarter(t))
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return pad(int(t.Month()), 2)
+	case "Mo":
+		return Ordinal(int(t.Month()))
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "DDDD":
+		return pad(t.YearDay(), 3)
+	case "DDDo":
+		return Ordinal(t.YearDay())
+	case "DDD":
+		return strconv.Itoa(t.YearDay())
+	case "DD":
+		return pad(t.Day(), 2)
+	case "Do":
+		return Ordinal(t.Day())
+	case "D":
+		return strconv.Itoa(t.Day())
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "dd":
+		return t.Weekday().String()[:2]
+	case "do":
+		return Ordinal(int(t.Weekday()))
+	case "d", "e":
+		return strconv.Itoa(int(t.Weekday()))
+	case "E":
+		return strconv.Itoa(isoWeekday(t.Weekday()))
+	case "ww":
+		return pad(localeWeek, 2)
+	case "wo":
+		return Ordinal(localeWeek)
+	case "w":
+		return strconv.Itoa(localeWeek)
+	case "WW":
+		return pad(isoWeek, 2)
+	case "Wo":
+		return Ordinal(isoWeek)
+	case "W":
+		return strconv.Itoa(isoWeek)
+	case "gggg":
+		return pad(localeYear, 4)
+	case "gg":
+		return pad(localeYear%100, 2)
+	case "GGGG":
+		return pad(isoYear, 4)
+	case "GG":
+		return pad(isoYear%100, 2)
+	case "HH":
+		return pad(t.Hour(), 2)
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "hh":
+		return pad(hour12(t), 2)
+	case "h":
+		return strconv.Itoa(hour12(t))
+	case "kk":
+		return pad(hour24(t), 2)
+	case "k":
+		return strconv.Itoa(hour24(t))
+	case "mm":
+		return pad(t.Minute(), 2)
+	case "m":
+		return strconv.Itoa(t.Minute())
+	case "ss":
+		return pad(t.Second(), 2)
+	case "s":
+		return strconv.Itoa(t.Second())
+	case "A":
+		if t.Hour() < 12 {
+			return "AM"
+		}
+		return "PM"
+	case "a":
+		if t.Hour() < 12 {
+			return "am"
+		}
+		return "pm"
+	case "Z", "ZZ":
+		_, offset := t.Zone()
+		sign := "+"
+		if offset < 0 {
+			sign, offset = "-", -offset
+		}
+		separator := ":"
+		if token == "ZZ" {
+			separator = ""
+		}
+		return sign + pad(offset/3600, 2) + separator + pad(offset%3600/60, 2)
+	case "X":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "x":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+
+	if strings.Trim(token, "S") == "" {
+		// Fractional seconds, truncated to the token's length.
+		return fmt.Sprintf("%09d", t.Nanosecond())[:len(token)]
+	}
+	return token
+}
+
+// ParseMoment parses value with a Moment.js format string, in strict mode:
+// the whole value must match the format. It understands the tokens
+// FormatMoment writes. Fields missing from the format default to January 1st
+// at midnight, and to the current year when no year is given. Week-based
+// formats ("gggg-[W]ww", "GGGG-[W]WW") resolve to the first day of the week.
+func ParseMoment(format, value string, loc *time.Location) (time.Time, error) {
+	p := momentParser{value: value, loc: loc}
+	for _, part := range tokenizeMoment(format) {
+		var err error
+		if part.token == "" {
+			err = p.literal(part.literal)
+		} else {
+			err = p.token(part.token)
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse %q as %q: %w", value, format, err)
+		}
+	}
+	if p.value != "" {
+		return time.Time{}, fmt.Errorf("cannot parse %q as %q: unexpected %q", value, format, p.value)
+	}
+
+	t, err := p.result()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %q as %q: %w", value, format, err)
+	}
+	return t, nil
+}
+
+// momentParser consumes value token by token and records the fields it reads
+// by name ("year", "month", "isoWeek", ...).
+type momentParser struct {
+	value string
+	loc   *time.Location
+
+	fields map[string]int
+	unix   *time.Time
+	offset *int
+}
+
+// set records a field, rejecting a value that disagrees with an earlier
+// token for the same field.
+func (p *momentParser) set(field string, value int) error {
+	if p.fields == nil {
+		p.fields = map[string]int{}
+	}
+	if previous, ok := p.fields[field]; ok && previous != value {
+		return fmt.Errorf("conflicting %s values %d and %d", field, previous, value)
+	}
+	p.fields[field] = value
+	return nil
+}
+
+func (p *momentParser) get(field string) (int, bool) {
+	value, ok := p.fields[field]
+	return value, ok
+}
+
+func (p *momentParser) literal(text string) error {
+	if !strings.HasPrefix(p.value, text) {
+		return fmt.Errorf("expected %q", text)
+	}
+	p.value = p.value[len(text):]
+	return nil
+}
+
+// digits consumes between min and max digits.
+func (p *momentParser) digits(min, max int) (int, error) {
+	n := 0
+	for n < len(p.value) && n < max && p.value[n] >= '0' && p.value[n] <= '9' {
+		n++
+	}
+	if n < min {
+		return 0, fmt.Errorf("expected a number at %q", p.value)
+	}
+	value, err := strconv.Atoi(p.value[:n])
+	p.value = p.value[n:]
+	return value, err
+}
+
+// signed consumes an optionally signed number of up to max digits.
+func (p *momentParser) signed(max int) (int, error) {
+	negative := strings.HasPrefix(p.value, "-")
+	if negative || strings.HasPrefix(p.value, "+") {
+		p.value = p.value[1:]
+	}
+	value, err := p.digits(1, max)
+	if negative {
+		value = -value
+	}
+	return value, err
+}
+
+// ordinal consumes a number followed by an English ordinal suffix.
+func (p *momentParser) ordinal(max int) (int, error) {
+	value, err := p.digits(1, max)
+	if err != nil {
+		return 0, err
+	}
+	suffix := ordinalSuffix(value)
+	if !strings.HasPrefix(p.value, suffix) {
+		return 0, fmt.Errorf("expected %d%s", value, suffix)
+	}
+	p.value = p.value[len(suffix):]
+	return value, nil
+}
+
+// name consumes one of names (case-insensitively, longest match first) and
+// returns its index.
+func (p *momentParser) name(names []string, length int) (int, error) {
+	best, bestLen := -1, 0
+	for i, name := range names {
+		if length > 0 {
+			name = name[:length]
+		}
+		if len(name) > bestLen && len(p.value) >= len(name) && strings.EqualFold(p.value[:len(name)], name) {
+			best, bestLen = i, len(name)
+		}
+	}
+	if best == -1 {
+		return 0, fmt.Errorf("unexpected %q", p.value)
+	}
+	p.value = p.value[bestLen:]
+	return best, nil
+}
+
+func (p *momentParser) token(token string) error {
+	var value int
+	var err error
+
+	switch token {
+	case "YYYY", "gggg", "GGGG":
+		value, err = p.digits(4, 4)
+	case "Y":
+		value, err = p.signed(6)
+	case "YY", "gg", "GG":
+		value, err = p.digits(2, 2)
+		value += 2000
+		if value > 2068 {
+			value -= 100
+		}
+	case "MM", "DD", "ww", "WW", "HH", "hh", "kk", "mm", "ss":
+		value, err = p.digits(2, 2)
+	case "DDDD":
+		value, err = p.digits(3, 3)
+	case "DDD":
+		value, err = p.digits(1, 3)
+	case "Q", "d", "e", "E":
+		value, err = p.digits(1, 1)
+	case "M", "D", "w", "W", "H", "h", "k", "m", "s":
+		value, err = p.digits(1, 2)
+	case "Qo", "do":
+		value, err = p.ordinal(1)
+	case "Mo", "Do", "wo", "Wo":
+		value, err = p.ordinal(2)
+	case "DDDo":
+		value, err = p.ordinal(3)
+	case "MMMM":
+		value, err = p.name(monthNames, 0)
+		value++
+	case "MMM":
+		value, err = p.name(monthNames, 3)
+		value++
+	case "dddd":
+		value, err = p.name(dayNames, 0)
+	case "ddd":
+		value, err = p.name(dayNames, 3)
+	case "dd":
+		value, err = p.name(dayNames, 2)
+	case "A", "a":
+		value, err = p.name([]string{"am", "pm"}, 0)
+	case "Z", "ZZ":
+		return p.zone()
+	case "X", "x":
+		return p.timestamp(token)
+	default:
+		if strings.Trim(token, "S") == "" {
+			start := len(p.value)
+			value, err = p.digits(len(token), len(token))
+			if err == nil {
+				digits := start - len(p.value)
+				for ; digits < 9; digits++ {
+					value *= 10
+				}
+			}
+			token = "S"
+			break
+		}
+		return fmt.Errorf("unsupported token %q", token)
+	}
+	if err != nil {
+		return err
+	}
+
+	field := token
+	switch token {
+	case "YYYY", "YY", "Y":
+		field = "year"
+	case "gggg", "gg":
+		field = "localeYear"
+	case "GGGG", "GG":
+		field = "isoYear"
+	case "Q", "Qo":
+		field = "quarter"
+	case "MMMM", "MMM", "MM", "Mo", "M":
+		field = "month"
+	case "DD", "Do", "D":
+		field = "day"
+	case "DDDD", "DDDo", "DDD":
+		field = "yearDay"
+	case "dddd", "ddd", "dd", "do", "d", "e":
+		field = "weekday"
+	case "E":
+		field = "isoWeekday"
+	case "ww", "wo", "w":
+		field = "localeWeek"
+	case "WW", "Wo", "W":
+		field = "isoWeek"
+	case "HH", "H", "kk", "k":
+		field = "hour"
+		value %= 24
+	case "hh", "h":
+		field = "hour12"
+	case "mm", "m":
+		field = "minute"
+	case "ss", "s":
+		field = "second"
+	case "A", "a":
+		field = "pm"
+	case "S":
+		field = "nanosecond"
+	}
+	return p.set(field, value)
+}
+
+func (p *momentParser) zone() error {
+	if p.value == "" || (p.value[0] != '+' && p.value[0] != '-') {
+		return fmt.Errorf("expected a UTC offset at %q", p.value)
+	}
+	sign := 1
+	if p.value[0] == '-' {
+		sign = -1
+	}
+	p.value = p.value[1:]
+	hours, err := p.digits(2, 2)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(p.value, ":") {
+		p.value = p.value[1:]
+	}
+	minutes, err := p.digits(2, 2)
+	if err != nil {
+		return err
+	}
+	offset := sign * (hours*3600 + minutes*60)
+	p.offset = &offset
+	return nil
+}
+
+func (p *momentParser) timestamp(token string) error {
+	negative := strings.HasPrefix(p.value, "-")
+	if negative {
+		p.value = p.value[1:]
+	}
+	n := 0
+	for n < len(p.value) && p.value[n] >= '0' && p.value[n] <= '9' {
+		n++
+	}
+	whole, err := strconv.ParseInt(p.value[:n], 10, 64)
+	if err != nil {
+		return fmt.Errorf("expected a timestamp at %q", p.value)
+	}
+	p.value = p.value[n:]
+
+	var t time.Time
+	if token == "x" {
+		if negative {
+			whole = -whole
+		}
+		t = time.UnixMilli(whole)
+	} else {
+		var nanos int64
+		if strings.HasPrefix(p.value, ".") {
+			p.value = p.value[1:]
+			fraction, err := p.digits(1, 3)
+			if err != nil {
+				return err
+			}
+			nanos = int64(fraction) * 1000000
+		}
+		t = time.Unix(whole, nanos)
+		if negative {
+			t = time.Unix(-whole, -nanos)
+		}
+	}
+	p.unix = &t
+	return nil
+}
+
+// result builds the parsed time and rejects fields that overflow, such as
+// February 30th.
+func (p *momentParser) result() (time.Time, error) {
+	loc := p.loc
+	if loc == nil {
+		loc = time.Local
+	}
+	if p.offset != nil {
+		loc = time.FixedZone("", *p.offset)
+	}
+	if p.unix != nil {
+		return p.unix.In(loc), nil
+	}
+
+	hour, _ := p.get("hour")
+	if h, ok := p.get("hour12"); ok {
+		if h < 1 || h > 12 {
+			return time.Time{}, fmt.Errorf("hour %d out of range", h)
+		}
+		hour = h
+	}
+	if pm, ok := p.get("pm"); ok && hour <= 12 {
+		hour = hour%12 + 12*pm
+	}
+	minute, _ := p.get("minute")
+	second, _ := p.get("second")
+	nanosecond, _ := p.get("nanosecond")
+	if hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, fmt.Errorf("time %02d:%02d:%02d out of range", hour, minute, second)
+	}
+	clock := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(nanosecond)
+
+	// Week-based dates.
+	if week, ok := p.get("isoWeek"); ok {
+		year, ok := p.get("isoYear")
+		if !ok {
+			year = p.year()
+		}
+		weekday, ok := p.get("isoWeekday")
+		if !ok {
+			weekday = 1
+		}
+		date, err := weekDate(year, week, weekday-1, time.Monday, 4, loc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return date.Add(clock), nil
+	}
+	if week, ok := p.get("localeWeek"); ok {
+		year, ok := p.get("localeYear")
+		if !ok {
+			year = p.year()
+		}
+		weekday, _ := p.get("weekday")
+		date, err := weekDate(year, week, weekday, time.Sunday, 6, loc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return date.Add(clock), nil
+	}
+
+	year := p.year()
+	if yearDay, ok := p.get("yearDay"); ok {
+		date := time.Date(year, time.January, yearDay, 0, 0, 0, 0, loc)
+		if yearDay < 1 || date.Year() != year {
+			return time.Time{}, fmt.Errorf("day of year %d out of range", yearDay)
+		}
+		return date.Add(clock), nil
+	}
+
+	month, ok := p.get("month")
+	if !ok {
+		month = 1
+		if q, ok := p.get("quarter"); ok {
+			if q < 1 || q > 4 {
+				return time.Time{}, fmt.Errorf("quarter %d out of range", q)
+			}
+			month = (q-1)*3 + 1
+		}
+	}
+	day, ok := p.get("day")
+	if !ok {
+		day = 1
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if month < 1 || month > 12 || day < 1 || date.Month() != time.Month(month) {
+		return time.Time{}, fmt.Errorf("date %04d-%02d-%02d out of range", year, month, day)
+	}
+	if weekday, ok := p.get("weekday"); ok && int(date.Weekday()) != weekday {
+		return time.Time{}, fmt.Errorf("%s is not a %s", date.Format("2006-01-02"), time.Weekday(weekday%7))
+	}
+	return date.Add(clock), nil
+}
+
+func (p *momentParser) year() int {
+	if year, ok := p.get("year"); ok {
+		return year
+	}
+	return time.Now().In(p.loc).Year()
+}
+
+// weekDate returns the date of weekday (0-6, counted from dow) in week of
+// year, for a week numbering where dow starts the week and doy decides which
+// January day falls in week 1.
+func weekDate(year, week, weekday int, dow time.Weekday, doy int, loc *time.Location) (time.Time, error) {
+	if week < 1 || week > weeksInYear(year, dow, doy) {
+		return time.Time{}, fmt.Errorf("week %d out of range for %d", week, year)
+	}
+	if weekday < 0 || weekday > 6 {
+		return time.Time{}, fmt.Errorf("weekday %d out of range", weekday)
+	}
+	offset := firstWeekOffset(year, dow, doy)
+	return time.Date(year, time.January, 1+offset+(week-1)*7+weekday, 0, 0, 0, 0, loc), nil
+}
+
+var monthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// Ordinal returns n with its English ordinal suffix: 1st, 2nd, 3rd, 11th.
+func Ordinal(n int) string {
+	return strconv.Itoa(n) + ordinalSuffix(n)
+}
+
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+func quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+func isoWeekday(day time.Weekday) int {
+	if day == time.Sunday {
+		return 7
+	}
+	return int(day)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func hour12(t time.Time) int {
+	h := t.Hour() % 12
+	if h == 0 {
+		return 12
+	}
+	return h
+}
+
+func hour24(t time.Time) int {
+	if t.Hour() == 0 {
+		return 24
+	}
+	return t.Hour()
+}
+
+// LocaleWeek returns the week number and week year of t in Moment's default
+// English locale: weeks start on Sunday and week 1 contains January 1st.
+func LocaleWeek(t time.Time) (week, year int) {
+	return weekOfYear(t, time.Sunday, 6)
+}
+
+// weekOfYear mirrors Moment's weekOfYear: dow is the first day of the week and
+// doy decides which January day must fall in week 1 (7 + dow - doy).
+func weekOfYear(t time.Time, dow time.Weekday, doy int) (week, year int) {
+	year = t.Year()
+	offset := firstWeekOffset(year, dow, doy)
+	week = (t.YearDay()-offset-1)/7 + 1
+	if t.YearDay()-offset-1 < 0 {
+		week = 0
+	}
+	switch {
+	case week < 1:
+		year--
+		week += weeksInYear(year, dow, doy)
+	case week > weeksInYear(year, dow, doy):
+		week -= weeksInYear(year, dow, doy)
+		year++
+	}
+	return week, year
+}
+
+// firstWeekOffset returns the offset in days from January 1st to the start of
+// week 1; negative when week 1 starts in December.
+func firstWeekOffset(year int, dow time.Weekday, doy int) int {
+	fwd := 7 + int(dow) - doy
+	fwdlw := (7 + int(time.Date(year, time.January, fwd, 0, 0, 0, 0, time.UTC).Weekday()) - int(dow)) % 7
+	return -fwdlw + fwd - 1
+}
+
+func weeksInYear(year int, dow time.Weekday, doy int) int {
+	days := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	return (days - firstWeekOffset(year, dow, doy) + firstWeekOffset(year+1, dow, doy)) / 7
+}
+
+// MomentResolution returns the smallest unit of time a Moment.js format
+// shows: a second for "YYYYMMDDHHmmss", a minute for "YYYYMMDDHHmm" and a day
+// for formats without a time.
+func MomentResolution(format string) time.Duration {
+	resolution := 24 * time.Hour
+	for _, part := range tokenizeMoment(format) {
+		unit := resolution
+		switch part.token[:min(len(part.token), 1)] {
+		case "S", "x":
+			unit = time.Millisecond
+		case "s", "X":
+			unit = time.Second
+		case "m":
+			unit = time.Minute
+		case "H", "h", "k":
+			unit = time.Hour
+		}
+		resolution = min(resolution, unit)
+	}
+	return resolution
+}
@@ -0,0 +1,217 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMoment(t *testing.T) {
+	date := time.Date(2026, 10, 19, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		testName string
+		date     time.Time
+		format   string
+		want     string
+	}{
+		{testName: "Default daily format", date: date, format: "YYYY-MM-DD", want: "2026-10-19"},
+		{testName: "Names", date: date, format: "dddd, MMMM D YY", want: "Monday, October 19 26"},
+		{testName: "Short names", date: date, format: "ddd MMM M d", want: "Mon Oct 10 1"},
+		{testName: "Time", date: date, format: "HH:mm:ss h A a H:m:s hh", want: "15:04:05 3 PM pm 15:4:5 03"},
+		{testName: "Bracketed literals", date: date, format: "[Week of] YYYY-MM-DD", want: "Week of 2026-10-19"},
+		{testName: "Quarter", date: date, format: "YYYY-[Q]Q", want: "2026-Q4"},
+		{testName: "Locale week", date: date, format: "gggg-[W]ww", want: "2026-W43"},
+		{testName: "ISO week", date: date, format: "GGGG-[W]WW", want: "2026-W43"},
+		{testName: "Locale week belongs to next year", date: time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC), format: "gggg-ww", want: "2025-01"},
+		{testName: "ISO week belongs to previous year", date: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), format: "GGGG-WW", want: "2020-53"},
+		{testName: "Locale week 1 contains January 1st", date: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), format: "gggg-w", want: "2021-1"},
+		{testName: "Folder in format", date: date, format: "YYYY/MM/YYYY-MM-DD", want: "2026/10/2026-10-19"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			assert.Equal(t, test.want, obsidian.FormatMoment(test.date, test.format))
+		})
+	}
+
+	// Outputs from Moment's documentation and its English locale, for
+	// moment("2010-02-14T15:25:50.125Z").
+	docDate := time.Date(2010, 2, 14, 15, 25, 50, 125000000, time.UTC)
+	docTests := []struct {
+		format string
+		want   string
+	}{
+		{format: "dddd, MMMM Do YYYY, h:mm:ss a", want: "Sunday, February 14th 2010, 3:25:50 pm"},
+		{format: "ddd, hA", want: "Sun, 3PM"},
+		{format: "[Today is] dddd", want: "Today is Sunday"},
+		{format: "YYYY [escaped] YYYY", want: "2010 escaped 2010"},
+		{format: "\\Y\\Y YYYY", want: "YY 2010"},
+		{format: "Q Qo", want: "1 1st"},
+		{format: "M Mo MM MMM MMMM", want: "2 2nd 02 Feb February"},
+		{format: "D Do DD", want: "14 14th 14"},
+		{format: "DDD DDDo DDDD", want: "45 45th 045"},
+		{format: "d do dd ddd dddd e E", want: "0 0th Su Sun Sunday 0 7"},
+		{format: "w wo ww W Wo WW", want: "8 8th 08 6 6th 06"},
+		{format: "gg gggg GG GGGG Y YY", want: "10 2010 10 2010 2010 10"},
+		{format: "H HH h hh k kk", want: "15 15 3 03 15 15"},
+		{format: "m mm s ss S SS SSS SSSSSS", want: "25 25 50 50 1 12 125 125000"},
+		{format: "Z ZZ", want: "+00:00 +0000"},
+		{format: "X x", want: "1266161150 1266161150125"},
+		{format: "LT LTS L l", want: "3:25 PM 3:25:50 PM 02/14/2010 2/14/2010"},
+		{format: "LL ll", want: "February 14, 2010 Feb 14, 2010"},
+		{format: "LLL lll", want: "February 14, 2010 3:25 PM Feb 14, 2010 3:25 PM"},
+		{format: "LLLL", want: "Sunday, February 14, 2010 3:25 PM"},
+		{format: "llll", want: "Sun, Feb 14, 2010 3:25 PM"},
+	}
+	for _, test := range docTests {
+		t.Run(test.format, func(t *testing.T) {
+			assert.Equal(t, test.want, obsidian.FormatMoment(docDate, test.format))
+		})
+	}
+
+	// Formats the old Go layout conversion handled, with Go's reference time.
+	layoutDate := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	for format, want := range map[string]string{
+		"YYYY/MM/DD":                "2006/01/02",
+		"YY-MM-DD":                  "06-01-02",
+		"MMMM DD, YYYY":             "January 02, 2006",
+		"MMM DD, YYYY":              "Jan 02, 2006",
+		"dddd, MMMM DD, YYYY":       "Monday, January 02, 2006",
+		"ddd MMM DD":                "Mon Jan 02",
+		"YYYY-MM-DD HH:mm":          "2006-01-02 15:04",
+		"[notes] YYYY-MM-DD hh A a": "notes 2006-01-02 03 PM pm",
+	} {
+		t.Run(format, func(t *testing.T) {
+			assert.Equal(t, want, obsidian.FormatMoment(layoutDate, format))
+		})
+	}
+
+	t.Run("Ordinals", func(t *testing.T) {
+		for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 101: "101st", 111: "111th"} {
+			assert.Equal(t, want, obsidian.Ordinal(n))
+		}
+	})
+
+	t.Run("Midnight is hour 24 with k", func(t *testing.T) {
+		assert.Equal(t, "24 12 AM", obsidian.FormatMoment(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), "k h A"))
+	})
+
+	t.Run("UTC offset", func(t *testing.T) {
+		date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.FixedZone("", -(5*3600+30*60)))
+		assert.Equal(t, "-05:30 -0530", obsidian.FormatMoment(date, "Z ZZ"))
+	})
+}
+
+func TestParseMoment(t *testing.T) {
+	tests := []struct {
+		testName string
+		format   string
+		value    string
+		want     time.Time
+	}{
+		{testName: "Default daily format", format: "YYYY-MM-DD", value: "2026-10-19", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{testName: "Folders and names", format: "YYYY/MMMM/dddd, MMMM Do YYYY", value: "2026/October/Monday, October 19th 2026", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{testName: "Case-insensitive names", format: "DD MMM YYYY", value: "05 oct 2026", want: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{testName: "Two-letter weekday", format: "dd YYYY-MM-DD", value: "Mo 2026-10-19", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{testName: "Escaped literals", format: "[Day] DDDD [of] YYYY", value: "Day 292 of 2026", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{testName: "Locale week", format: "gggg-[W]ww", value: "2026-W43", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{testName: "Locale week in previous year", format: "gggg-[W]ww", value: "2025-W01", want: time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC)},
+		{testName: "ISO week", format: "GGGG-[W]WW", value: "2020-W53", want: time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC)},
+		{testName: "ISO week and weekday", format: "GGGG-[W]WW-E", value: "2026-W43-5", want: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{testName: "Quarter", format: "YYYY-[Q]Q", value: "2026-Q4", want: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{testName: "Month", format: "YYYY-MM", value: "2026-10", want: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{testName: "Year", format: "YYYY", value: "2026", want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{testName: "Time with meridiem", format: "YYYY-MM-DD h:mm A", value: "2026-10-19 12:05 AM", want: time.Date(2026, 10, 19, 0, 5, 0, 0, time.UTC)},
+		{testName: "Fractional seconds", format: "HH:mm:ss.SSS YYYY", value: "15:25:50.125 2010", want: time.Date(2010, 1, 1, 15, 25, 50, 125000000, time.UTC)},
+		{testName: "Unix timestamp", format: "X", value: "1266161150", want: time.Date(2010, 2, 14, 15, 25, 50, 0, time.UTC)},
+		{testName: "Unix milliseconds", format: "x", value: "1266161150125", want: time.Date(2010, 2, 14, 15, 25, 50, 125000000, time.UTC)},
+		{testName: "Short year", format: "YY.MM.DD", value: "26.10.19", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{testName: "Repeated fields", format: "YYYY/MM/YYYY-MM-DD", value: "2026/10/2026-10-19", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			got, err := obsidian.ParseMoment(test.format, test.value, time.UTC)
+			assert.NoError(t, err)
+			assert.True(t, test.want.Equal(got), "got %s", got)
+		})
+	}
+
+	t.Run("UTC offset", func(t *testing.T) {
+		got, err := obsidian.ParseMoment("YYYY-MM-DD HH:mm Z", "2026-10-19 09:00 +02:00", time.UTC)
+		assert.NoError(t, err)
+		assert.True(t, time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC).Equal(got))
+	})
+
+	t.Run("Round-trips formatted values", func(t *testing.T) {
+		date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		for _, format := range []string{"YYYY-MM-DD", "Do MMMM YYYY", "DD-MM-YYYY dddd", "YYYY/MM/[Notes] DDD"} {
+			got, err := obsidian.ParseMoment(format, obsidian.FormatMoment(date, format), time.UTC)
+			assert.NoError(t, err, format)
+			assert.True(t, date.Equal(got), format)
+		}
+	})
+
+	invalid := []struct {
+		testName string
+		format   string
+		value    string
+	}{
+		{testName: "Not a date", format: "YYYY-MM-DD", value: "notes"},
+		{testName: "Trailing text", format: "YYYY-MM-DD", value: "2026-10-19 copy"},
+		{testName: "Day out of range", format: "YYYY-MM-DD", value: "2026-02-30"},
+		{testName: "Month out of range", format: "YYYY-MM", value: "2026-13"},
+		{testName: "Wrong weekday", format: "dddd YYYY-MM-DD", value: "Friday 2026-10-19"},
+		{testName: "Wrong ordinal", format: "Do MMMM YYYY", value: "2th October 2026"},
+		{testName: "Week out of range", format: "GGGG-[W]WW", value: "2026-W54"},
+		{testName: "Missing literal", format: "YYYY-[Q]Q", value: "2026-4"},
+		{testName: "Conflicting months", format: "YYYY/MM/YYYY-MM-DD", value: "2026/11/2026-10-19"},
+		{testName: "Conflicting years", format: "YYYY/YYYY-MM-DD", value: "2025/2026-10-19"},
+	}
+	for _, test := range invalid {
+		t.Run(test.testName, func(t *testing.T) {
+			_, err := obsidian.ParseMoment(test.format, test.value, time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLocaleWeek(t *testing.T) {
+	t.Run("Weeks start on Sunday", func(t *testing.T) {
+		week, year := obsidian.LocaleWeek(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, 43, week)
+		assert.Equal(t, 2026, year)
+
+		week, _ = obsidian.LocaleWeek(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, 42, week)
+	})
+
+	t.Run("Last week of a 53-week year", func(t *testing.T) {
+		week, year := obsidian.LocaleWeek(time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, 53, week)
+		assert.Equal(t, 2022, year)
+	})
+}
+
+func TestMomentResolution(t *testing.T) {
+	tests := []struct {
+		format string
+		want   time.Duration
+	}{
+		{"YYYYMMDDHHmm", time.Minute},
+		{"YYYYMMDDHHmmss", time.Second},
+		{"YYYYMMDDHHmmssSSS", time.Millisecond},
+		{"X", time.Second},
+		{"YYYY-MM-DD", 24 * time.Hour},
+		{"gggg-[W]ww", 24 * time.Hour},
+		{"[Zettel at] LT", time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			assert.Equal(t, test.want, obsidian.MomentResolution(test.format))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NamesConfigPath is the vault-relative path of the note name policy.
+const NamesConfigPath = ".notesmd/names.yaml"
+
+// Characters that break Obsidian links, and characters Windows does not allow
+// in file names.
+const (
+	LinkUnsafeChars    = `#^[]|`
+	WindowsUnsafeChars = `:*?"<>\`
+)
+
+// windowsReservedNames cannot be used as file names on Windows, with or
+// without an extension.
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// NamePolicy decides which note names are unsafe and how Sanitize fixes them.
+// It is read from .notesmd/names.yaml, for example:
+//
+//	replacement: "-"
+//	replace:
+//	  ":": " -"
+//	windows: false
+type NamePolicy struct {
+	// Replacement replaces unsafe characters that have no entry in Replace.
+	// Runs of spaces are collapsed afterwards. Defaults to a space.
+	Replacement string            `yaml:"replacement"`
+	Replace     map[string]string `yaml:"replace"`
+	// Windows also rejects what Windows does not allow in file names.
+	// Defaults to true.
+	Windows bool `yaml:"windows"`
+}
+
+// DefaultNamePolicy returns the policy used without .notesmd/names.yaml.
+func DefaultNamePolicy() NamePolicy {
+	return NamePolicy{Replacement: " ", Windows: true}
+}
+
+// LoadNamePolicy reads .notesmd/names.yaml from the vault. Missing settings
+// keep their defaults; an unreadable or invalid file is an error.
+func LoadNamePolicy(vaultPath string) (NamePolicy, error) {
+	policy := DefaultNamePolicy()
+	data, err := os.ReadFile(filepath.Join(vaultPath, filepath.FromSlash(NamesConfigPath)))
+	if errors.Is(err, os.ErrNotExist) {
+		return policy, nil
+	}
+	if err != nil {
+		return policy, err
+	}
+
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("invalid %s: %w", NamesConfigPath, err)
+	}
+	for char, replacement := range policy.Replace {
+		if len([]rune(char)) != 1 {
+			return policy, fmt.Errorf("invalid %s: replace keys must be single characters, got %q", NamesConfigPath, char)
+		}
+		if policy.unsafe([]rune(replacement)...) {
+			return policy, fmt.Errorf("invalid %s: replacement %q for %q is itself unsafe", NamesConfigPath, replacement, char)
+		}
+	}
+	if policy.unsafe([]rune(policy.Replacement)...) {
+		return policy, fmt.Errorf("invalid %s: replacement %q is itself unsafe", NamesConfigPath, policy.Replacement)
+	}
+	return policy, nil
+}
+
+// unsafe reports whether any of chars is not allowed in a note name.
+func (p NamePolicy) unsafe(chars ...rune) bool {
+	for _, r := range chars {
+		if r == '/' || unicode.IsControl(r) || strings.ContainsRune(LinkUnsafeChars, r) ||
+			(p.Windows && strings.ContainsRune(WindowsUnsafeChars, r)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Problems describes why name, a note's file name without its folder or .md
+// extension, is unsafe. It returns nil for safe names.
+func (p NamePolicy) Problems(name string) []string {
+	var problems []string
+	seen := map[rune]bool{}
+	for _, r := range name {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		switch {
+		case unicode.IsControl(r):
+			problems = append(problems, fmt.Sprintf("contains control character %U", r))
+		case strings.ContainsRune(LinkUnsafeChars, r):
+			problems = append(problems, fmt.Sprintf("contains %q, which breaks links", string(r)))
+		case p.Windows && strings.ContainsRune(WindowsUnsafeChars, r):
+			problems = append(problems, fmt.Sprintf("contains %q, which Windows does not allow", string(r)))
+		}
+	}
+
+	if strings.TrimSpace(name) == "" {
+		problems = append(problems, "is empty")
+	}
+	if p.Windows {
+		if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+			problems = append(problems, "ends with a dot or space, which Windows does not allow")
+		}
+		if base, _, _ := strings.Cut(name, "."); windowsReservedNames[strings.ToUpper(strings.TrimSpace(base))] {
+			problems = append(problems, "is a reserved name on Windows")
+		}
+	}
+	return problems
+}
+
+// Sanitize returns name with its unsafe characters replaced as the policy
+// says, so that Problems reports nothing for the result.
+func (p NamePolicy) Sanitize(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if !p.unsafe(r) {
+			sb.WriteRune(r)
+		} else if replacement, ok := p.Replace[string(r)]; ok {
+			sb.WriteString(replacement)
+		} else {
+			sb.WriteString(p.Replacement)
+		}
+	}
+
+	sanitized := strings.Join(strings.Fields(sb.String()), " ")
+	if p.Windows {
+		sanitized = strings.TrimRight(sanitized, ". ")
+		if base, _, _ := strings.Cut(sanitized, "."); windowsReservedNames[strings.ToUpper(base)] {
+			sanitized = base + "_" + sanitized[len(base):]
+		}
+	}
+	if sanitized == "" {
+		sanitized = "Untitled"
+	}
+	return sanitized
+}
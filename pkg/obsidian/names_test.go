@@ -0,0 +1,95 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamePolicy(t *testing.T) {
+	policy := obsidian.DefaultNamePolicy()
+
+	t.Run("Safe names have no problems", func(t *testing.T) {
+		assert.Empty(t, policy.Problems("Meeting with ACME (2026-10-01)"))
+		assert.Empty(t, policy.Problems("Café ✨ notes"))
+	})
+
+	t.Run("Reports link-breaking and Windows characters", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`contains ":", which Windows does not allow`,
+			`contains "#", which breaks links`,
+		}, policy.Problems("Meeting: #1"))
+	})
+
+	t.Run("Reports Windows endings and reserved names", func(t *testing.T) {
+		assert.Equal(t, []string{"ends with a dot or space, which Windows does not allow"}, policy.Problems("Draft."))
+		assert.Equal(t, []string{"is a reserved name on Windows"}, policy.Problems("nul.backup"))
+	})
+
+	t.Run("Windows checks can be turned off", func(t *testing.T) {
+		linksOnly := obsidian.NamePolicy{Replacement: "-"}
+		assert.Empty(t, linksOnly.Problems("Meeting: CON?"))
+		assert.NotEmpty(t, linksOnly.Problems("C#"))
+	})
+
+	t.Run("Sanitizes names", func(t *testing.T) {
+		tests := map[string]string{
+			"Meeting: ACME":  "Meeting ACME",
+			"What? #1":       "What 1",
+			"[[link]] | x":   "link x",
+			"Draft. ":        "Draft",
+			"CON":            "CON_",
+			"con.txt":        "con_.txt",
+			"###":            "Untitled",
+			"Already fine":   "Already fine",
+			"tab\there":      "tab here",
+			`back\slash "q"`: "back slash q",
+		}
+		for name, want := range tests {
+			got := policy.Sanitize(name)
+			assert.Equal(t, want, got, name)
+			assert.Empty(t, policy.Problems(got), got)
+		}
+	})
+
+	t.Run("Uses configured replacements", func(t *testing.T) {
+		custom := obsidian.NamePolicy{Replacement: "_", Replace: map[string]string{":": " -"}, Windows: true}
+		assert.Equal(t, "Meeting - ACME_1", custom.Sanitize("Meeting: ACME#1"))
+	})
+}
+
+func TestLoadNamePolicy(t *testing.T) {
+	writePolicy := func(t *testing.T, content string) string {
+		vaultPath := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(vaultPath, ".notesmd"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(vaultPath, ".notesmd", "names.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return vaultPath
+	}
+
+	t.Run("Defaults without a config", func(t *testing.T) {
+		policy, err := obsidian.LoadNamePolicy(t.TempDir())
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.DefaultNamePolicy(), policy)
+	})
+
+	t.Run("Reads the config and keeps missing defaults", func(t *testing.T) {
+		policy, err := obsidian.LoadNamePolicy(writePolicy(t, "replace:\n  \":\": \" -\"\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.NamePolicy{Replacement: " ", Replace: map[string]string{":": " -"}, Windows: true}, policy)
+	})
+
+	t.Run("Rejects unsafe replacements", func(t *testing.T) {
+		_, err := obsidian.LoadNamePolicy(writePolicy(t, "replacement: \"#\"\n"))
+		assert.Error(t, err)
+
+		_, err = obsidian.LoadNamePolicy(writePolicy(t, "replace:\n  ab: x\n"))
+		assert.Error(t, err)
+	})
+}
@@ -12,7 +12,11 @@ import (
 	"strings"
 )
 
-type Note struct{}
+// Note reads and writes notes on disk. It remembers the version of each note
+// it reads so that SetContents can refuse to overwrite changes made since.
+type Note struct {
+	versions map[string]FileVersion
+}
 
 func isHiddenDir(d fs.DirEntry) bool {
 	return d.IsDir() && d.Name() != "." && strings.HasPrefix(d.Name(), ".")
@@ -25,7 +29,7 @@ type NoteMatch struct {
 }
 
 type NoteManager interface {
-	Move(string, string) error
+	Move(string, string, io.Writer) error
 	Delete(string) error
 	UpdateLinks(string, string, string) error
 	GetContents(string, string) (string, error)
@@ -35,7 +39,9 @@ type NoteManager interface {
 	FindBacklinks(string, string) ([]NoteMatch, error)
 }
 
-func (m *Note) Move(originalPath string, newPath string) error {
+// Move renames the note at originalPath to newPath and reports the move to
+// out, if it is not nil.
+func (m *Note) Move(originalPath string, newPath string, out io.Writer) error {
 	o := AddMdSuffix(originalPath)
 	n := AddMdSuffix(newPath)
 
@@ -45,11 +51,9 @@ func (m *Note) Move(originalPath string, newPath string) error {
 		return errors.New(NoteDoesNotExistError)
 	}
 
-	message := fmt.Sprintf(`Moved note 
-from %s
-to %s`, o, n)
-
-	fmt.Println(message)
+	if out != nil {
+		fmt.Fprintf(out, "Moved note \nfrom %s\nto %s\n", o, n)
+	}
 	return nil
 }
 func (m *Note) Delete(path string) error {
@@ -62,9 +66,18 @@ func (m *Note) Delete(path string) error {
 	return nil
 }
 
-func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
+// findNotePath resolves a note name to a file in the vault. A path relative
+// to the vault root wins; otherwise the first note with a matching basename
+// is used for backward compatibility.
+func findNotePath(vaultPath string, noteName string) (string, error) {
 	note := AddMdSuffix(noteName)
 
+	if exact, err := ValidatePath(vaultPath, note); err == nil {
+		if info, statErr := os.Stat(exact); statErr == nil && !info.IsDir() {
+			return exact, nil
+		}
+	}
+
 	var notePath string
 	err := filepath.WalkDir(vaultPath, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -92,57 +105,50 @@ func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
 	if err != nil || notePath == "" {
 		return "", errors.New(NoteDoesNotExistError)
 	}
+	return notePath, nil
+}
 
-	file, err := os.Open(notePath)
+func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
+	notePath, err := findNotePath(vaultPath, noteName)
 	if err != nil {
-		return "", errors.New(VaultReadError)
+		return "", err
 	}
-	defer file.Close()
 
-	content, err := io.ReadAll(file)
+	content, version, err := ReadFileVersion(notePath)
 	if err != nil {
 		return "", errors.New(VaultReadError)
 	}
+	if m.versions == nil {
+		m.versions = map[string]FileVersion{}
+	}
+	m.versions[notePath] = version
 
 	return string(content), nil
 }
 
 func (m *Note) SetContents(vaultPath string, noteName string, content string) error {
-	note := AddMdSuffix(noteName)
-
-	var notePath string
-	err := filepath.WalkDir(vaultPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			return nil
-		}
-
-		// Check for full path match first
-		relPath, err := filepath.Rel(vaultPath, path)
-		if err == nil && relPath == note {
-			notePath = path
-			return filepath.SkipDir
-		}
-
-		// Fall back to basename match for backward compatibility
-		if filepath.Base(path) == note {
-			notePath = path
-			return filepath.SkipDir
-		}
-		return nil
-	})
-
-	if err != nil || notePath == "" {
-		return errors.New(NoteDoesNotExistError)
+	notePath, err := findNotePath(vaultPath, noteName)
+	if err != nil {
+		return err
 	}
 
-	err = os.WriteFile(notePath, []byte(content), 0644)
+	// Refuse to overwrite changes made since GetContents read the note.
+	if version, ok := m.versions[notePath]; ok {
+		err = WriteFileIfUnchanged(notePath, []byte(content), 0644, version)
+	} else {
+		err = WriteFileAtomic(notePath, []byte(content), 0644)
+	}
 	if err != nil {
+		if err.Error() == NoteChangedError {
+			return err
+		}
 		return errors.New(VaultWriteError)
 	}
 
+	// Later writes are checked against what was just written.
+	if info, statErr := os.Stat(notePath); statErr == nil && m.versions != nil {
+		m.versions[notePath] = newFileVersion(info, []byte(content))
+	}
 	return nil
 }
 
@@ -156,23 +162,27 @@ func (m *Note) UpdateLinks(vaultPath string, oldNoteName string, newNoteName str
 			return nil
 		}
 
-		originalContent, err := os.ReadFile(path)
-		if err != nil {
-			return errors.New(VaultReadError)
-		}
-
 		replacements := GenerateLinkReplacements(oldNoteName, newNoteName)
-		updatedContent := ReplaceContent(originalContent, replacements)
+		for attempt := 1; ; attempt++ {
+			originalContent, version, err := ReadFileVersion(path)
+			if err != nil {
+				return errors.New(VaultReadError)
+			}
 
-		if bytes.Equal(originalContent, updatedContent) {
-			return nil
-		}
+			updatedContent := ReplaceContent(originalContent, replacements)
+			if bytes.Equal(originalContent, updatedContent) {
+				return nil
+			}
 
-		err = os.WriteFile(path, updatedContent, info.Mode())
-		if err != nil {
-			return errors.New(VaultWriteError)
+			// Start over if the note changed since it was read.
+			err = WriteFileIfUnchanged(path, updatedContent, info.Mode(), version)
+			if err == nil {
+				return nil
+			}
+			if err.Error() != NoteChangedError || attempt == WriteAttempts {
+				return errors.New(VaultWriteError)
+			}
 		}
-		return nil
 	})
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -123,6 +124,27 @@ func TestNote_GetContents(t *testing.T) {
 		assert.Equal(t, fileContents, content2, "Expected contents to match the file contents")
 	})
 
+	t.Run("Full path wins over basename match in another folder", func(t *testing.T) {
+		// Arrange
+		tempDir := t.TempDir()
+		for _, dir := range []string{"a", "b"} {
+			if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, dir, "note.md"), []byte("in "+dir), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Act
+		noteManager := obsidian.Note{}
+		content, err := noteManager.GetContents(tempDir, "b/note")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "in b", content)
+	})
+
 	t.Run("Get contents of non-existent note", func(t *testing.T) {
 		// Arrange
 		noteManager := obsidian.Note{}
@@ -135,6 +157,61 @@ func TestNote_GetContents(t *testing.T) {
 	})
 }
 
+func TestNote_SetContents(t *testing.T) {
+	t.Run("Writes contents and keeps permissions", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		notePath := filepath.Join(vaultPath, "note.md")
+		if err := os.WriteFile(notePath, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		note := obsidian.Note{}
+
+		err := note.SetContents(vaultPath, "note", "new")
+
+		assert.NoError(t, err)
+		data, _ := os.ReadFile(notePath)
+		assert.Equal(t, "new", string(data))
+		info, _ := os.Stat(notePath)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("Refuses to overwrite changes made since the note was read", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		notePath := filepath.Join(vaultPath, "note.md")
+		if err := os.WriteFile(notePath, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		note := obsidian.Note{}
+		_, err := note.GetContents(vaultPath, "note")
+		assert.NoError(t, err)
+		if err := os.WriteFile(notePath, []byte("edited in Obsidian"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err = note.SetContents(vaultPath, "note", "new")
+
+		assert.EqualError(t, err, obsidian.NoteChangedError)
+		data, _ := os.ReadFile(notePath)
+		assert.Equal(t, "edited in Obsidian", string(data))
+	})
+
+	t.Run("Consecutive writes after a read succeed", func(t *testing.T) {
+		vaultPath := t.TempDir()
+		notePath := filepath.Join(vaultPath, "note.md")
+		if err := os.WriteFile(notePath, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		note := obsidian.Note{}
+		_, err := note.GetContents(vaultPath, "note")
+		assert.NoError(t, err)
+
+		assert.NoError(t, note.SetContents(vaultPath, "note", "first"))
+		assert.NoError(t, note.SetContents(vaultPath, "note", "second"))
+		data, _ := os.ReadFile(notePath)
+		assert.Equal(t, "second", string(data))
+	})
+}
+
 func TestMoveNote(t *testing.T) {
 	originalContent := "This is the original content."
 
@@ -170,10 +247,12 @@ func TestMoveNote(t *testing.T) {
 			noteManager := obsidian.Note{}
 
 			// Act
-			err = noteManager.Move(fullOriginalNotePath, fullNewNotePath)
+			var out strings.Builder
+			err = noteManager.Move(fullOriginalNotePath, fullNewNotePath, &out)
 
 			// Assert
 			assert.NoError(t, err, "Expected no error while moving note")
+			assert.Contains(t, out.String(), "to "+expectedNewPath+"\n")
 
 			// Check if the original file has been moved to the new path
 			_, err = os.Stat(existingNoteFullPathToCreate)
@@ -197,7 +276,7 @@ func TestMoveNote(t *testing.T) {
 		// Arrange
 		noteManager := obsidian.Note{}
 		// Act
-		err := noteManager.Move("filepath/that/does/not/exist", "newNote")
+		err := noteManager.Move("filepath/that/does/not/exist", "newNote", nil)
 		// Assert
 		assert.Equal(t, err.Error(), obsidian.NoteDoesNotExistError)
 	})
@@ -0,0 +1,153 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Periods supported by periodic notes.
+const (
+	PeriodDay     = "day"
+	PeriodWeek    = "week"
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// PeriodConfig holds the settings of one period in the Periodic Notes plugin.
+type PeriodConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Folder   string `json:"folder"`
+	Format   string `json:"format"`
+	Template string `json:"template"`
+}
+
+// PeriodicNotesConfig represents relevant fields from
+// .obsidian/plugins/periodic-notes/data.json.
+type PeriodicNotesConfig struct {
+	Daily     PeriodConfig `json:"daily"`
+	Weekly    PeriodConfig `json:"weekly"`
+	Monthly   PeriodConfig `json:"monthly"`
+	Quarterly PeriodConfig `json:"quarterly"`
+	Yearly    PeriodConfig `json:"yearly"`
+}
+
+// defaultPeriodFormats are the Periodic Notes plugin's default name formats.
+var defaultPeriodFormats = map[string]string{
+	PeriodDay:     "YYYY-MM-DD",
+	PeriodWeek:    "gggg-[W]ww",
+	PeriodMonth:   "YYYY-MM",
+	PeriodQuarter: "YYYY-[Q]Q",
+	PeriodYear:    "YYYY",
+}
+
+// ParsePeriod accepts a period name such as "week" or "weekly".
+func ParsePeriod(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "day", "daily":
+		return PeriodDay, nil
+	case "week", "weekly":
+		return PeriodWeek, nil
+	case "month", "monthly":
+		return PeriodMonth, nil
+	case "quarter", "quarterly":
+		return PeriodQuarter, nil
+	case "year", "yearly":
+		return PeriodYear, nil
+	}
+	return "", fmt.Errorf("invalid period '%s': expected day, week, month, quarter or year", name)
+}
+
+// ReadPeriodicNotesConfig reads the Periodic Notes plugin config from the
+// vault. Returns zero-value config if unreadable.
+func ReadPeriodicNotesConfig(vaultPath string) PeriodicNotesConfig {
+	data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "plugins", "periodic-notes", "data.json"))
+	if err != nil {
+		return PeriodicNotesConfig{}
+	}
+
+	var config PeriodicNotesConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return PeriodicNotesConfig{}
+	}
+
+	return config
+}
+
+// PeriodSettings returns the folder, format and template for period. Daily
+// notes use the core Daily Notes config; other periods use the Periodic Notes
+// plugin config. A period that is disabled in the plugin is treated as
+// unconfigured, and an empty format falls back to the plugin's default.
+func PeriodSettings(vaultPath, period string) PeriodConfig {
+	var settings PeriodConfig
+	switch period {
+	case PeriodDay:
+		daily := ReadDailyNotesConfig(vaultPath)
+		settings = PeriodConfig{Folder: daily.Folder, Format: daily.Format, Template: daily.Template}
+	default:
+		config := ReadPeriodicNotesConfig(vaultPath)
+		switch period {
+		case PeriodWeek:
+			settings = config.Weekly
+		case PeriodMonth:
+			settings = config.Monthly
+		case PeriodQuarter:
+			settings = config.Quarterly
+		case PeriodYear:
+			settings = config.Yearly
+		}
+		if !settings.Enabled {
+			settings = PeriodConfig{}
+		}
+	}
+
+	if settings.Format == "" {
+		settings.Format = defaultPeriodFormats[period]
+	}
+	settings.Folder = strings.Trim(settings.Folder, "/")
+	return settings
+}
+
+// PeriodStart returns midnight on the first day of the period containing t.
+// Weeks start on Sunday, or on Monday when format uses ISO week tokens
+// (W or G), matching how the note name is numbered.
+func PeriodStart(t time.Time, period, format string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case PeriodWeek:
+		first := time.Sunday
+		if usesISOWeek(format) {
+			first = time.Monday
+		}
+		return day.AddDate(0, 0, -((int(day.Weekday()) - int(first) + 7) % 7))
+	case PeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	case PeriodQuarter:
+		month := time.Month((int(day.Month())-1)/3*3 + 1)
+		return time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+	case PeriodYear:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+	}
+	return day
+}
+
+// usesISOWeek reports whether format contains an ISO week token outside
+// bracketed literals.
+func usesISOWeek(format string) bool {
+	inLiteral := false
+	for _, r := range format {
+		switch {
+		case r == '[':
+			inLiteral = true
+		case r == ']':
+			inLiteral = false
+		case !inLiteral && (r == 'W' || r == 'G'):
+			return true
+		}
+	}
+	return false
+}
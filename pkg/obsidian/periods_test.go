@@ -0,0 +1,109 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodSettings(t *testing.T) {
+	t.Run("Reads the Periodic Notes plugin config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		pluginDir := filepath.Join(tmpDir, ".obsidian", "plugins", "periodic-notes")
+		if err := os.MkdirAll(pluginDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pluginDir, "data.json"), []byte(`{
+			"weekly": {"enabled": true, "folder": "Weekly/", "format": "GGGG-[W]WW", "template": "Templates/Week"},
+			"monthly": {"enabled": true, "folder": "Monthly"}
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, obsidian.PeriodConfig{Enabled: true, Folder: "Weekly", Format: "GGGG-[W]WW", Template: "Templates/Week"},
+			obsidian.PeriodSettings(tmpDir, obsidian.PeriodWeek))
+		assert.Equal(t, obsidian.PeriodConfig{Enabled: true, Folder: "Monthly", Format: "YYYY-MM"},
+			obsidian.PeriodSettings(tmpDir, obsidian.PeriodMonth))
+	})
+
+	t.Run("Ignores settings of disabled periods", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		pluginDir := filepath.Join(tmpDir, ".obsidian", "plugins", "periodic-notes")
+		if err := os.MkdirAll(pluginDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pluginDir, "data.json"), []byte(`{
+			"weekly": {"enabled": false, "folder": "Weekly", "format": "GGGG-[W]WW", "template": "Templates/Week"}
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, obsidian.PeriodConfig{Format: "gggg-[W]ww"}, obsidian.PeriodSettings(tmpDir, obsidian.PeriodWeek))
+	})
+
+	t.Run("Falls back to defaults without config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		assert.Equal(t, "YYYY-MM-DD", obsidian.PeriodSettings(tmpDir, obsidian.PeriodDay).Format)
+		assert.Equal(t, "gggg-[W]ww", obsidian.PeriodSettings(tmpDir, obsidian.PeriodWeek).Format)
+		assert.Equal(t, "YYYY-[Q]Q", obsidian.PeriodSettings(tmpDir, obsidian.PeriodQuarter).Format)
+		assert.Equal(t, "YYYY", obsidian.PeriodSettings(tmpDir, obsidian.PeriodYear).Format)
+	})
+
+	t.Run("Daily settings come from the Daily Notes config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "daily-notes.json"), []byte(`{"folder": "Daily", "format": "DD-MM-YYYY"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, obsidian.PeriodConfig{Folder: "Daily", Format: "DD-MM-YYYY"}, obsidian.PeriodSettings(tmpDir, obsidian.PeriodDay))
+	})
+}
+
+func TestPeriodStart(t *testing.T) {
+	// Thursday
+	date := time.Date(2026, 10, 22, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		testName string
+		period   string
+		format   string
+		want     string
+	}{
+		{testName: "Day", period: obsidian.PeriodDay, format: "YYYY-MM-DD", want: "2026-10-22"},
+		{testName: "Locale week starts on Sunday", period: obsidian.PeriodWeek, format: "gggg-[W]ww", want: "2026-10-18"},
+		{testName: "ISO week starts on Monday", period: obsidian.PeriodWeek, format: "GGGG-[W]WW", want: "2026-10-19"},
+		{testName: "Month", period: obsidian.PeriodMonth, format: "YYYY-MM", want: "2026-10-01"},
+		{testName: "Quarter", period: obsidian.PeriodQuarter, format: "YYYY-[Q]Q", want: "2026-10-01"},
+		{testName: "Year", period: obsidian.PeriodYear, format: "YYYY", want: "2026-01-01"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			got := obsidian.PeriodStart(date, test.period, test.format)
+			assert.Equal(t, test.want, got.Format("2006-01-02"))
+			assert.Equal(t, 0, got.Hour())
+		})
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	period, err := obsidian.ParsePeriod("Weekly")
+	assert.NoError(t, err)
+	assert.Equal(t, obsidian.PeriodWeek, period)
+
+	period, err = obsidian.ParsePeriod("quarter")
+	assert.NoError(t, err)
+	assert.Equal(t, obsidian.PeriodQuarter, period)
+
+	_, err = obsidian.ParsePeriod("fortnight")
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TemplatesConfig represents relevant fields from .obsidian/templates.json,
+// the core Templates plugin's settings.
+type TemplatesConfig struct {
+	Folder     string `json:"folder"`
+	DateFormat string `json:"dateFormat"`
+	TimeFormat string `json:"timeFormat"`
+}
+
+// ReadTemplatesConfig reads the core Templates plugin config from the vault.
+// Empty or unreadable formats default to Obsidian's "YYYY-MM-DD" and "HH:mm".
+func ReadTemplatesConfig(vaultPath string) TemplatesConfig {
+	var config TemplatesConfig
+	if data, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "templates.json")); err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			config = TemplatesConfig{}
+		}
+	}
+
+	config.Folder = strings.Trim(config.Folder, "/")
+	if config.DateFormat == "" {
+		config.DateFormat = "YYYY-MM-DD"
+	}
+	if config.TimeFormat == "" {
+		config.TimeFormat = "HH:mm"
+	}
+	return config
+}
+
+// templateDateVar matches {{date}}, {{time}} and their variants with an
+// offset and a format, e.g. {{date+1d:dddd}}.
+var templateDateVar = regexp.MustCompile(`(?i)\{\{\s*(date|time)\s*(?:([+-]\d+)([yqmwdhs]))?\s*(?::(.*?))?\s*\}\}`)
+
+var templateTitleVar = regexp.MustCompile(`(?i)\{\{\s*title\s*\}\}`)
+
+var templateDayVar = regexp.MustCompile(`(?i)\{\{\s*(yesterday|tomorrow)\s*\}\}`)
+
+// Render expands the variables of Obsidian's core Templates and Daily Notes
+// plugins in content:
+//   - {{title}}: the note's name
+//   - {{date}}, {{date:YYYY-MM-DD}}: date, in the configured or given format
+//   - {{time}}, {{time:HH:mm:ss}}: now, in the configured or given format
+//   - {{date+1d}}, {{date-1w:dddd}}: offsets in Moment units (y, Q, M, w, d,
+//     h, m for minutes, s)
+//   - {{yesterday}}, {{tomorrow}}: the day before or after date
+//
+// Unknown variables are left untouched.
+func (c TemplatesConfig) Render(content, title string, date, now time.Time) string {
+	content = templateTitleVar.ReplaceAllLiteralString(content, title)
+
+	content = templateDayVar.ReplaceAllStringFunc(content, func(match string) string {
+		days := 1
+		if strings.Contains(strings.ToLower(match), "yesterday") {
+			days = -1
+		}
+		return FormatMoment(date.AddDate(0, 0, days), c.DateFormat)
+	})
+
+	return templateDateVar.ReplaceAllStringFunc(content, func(match string) string {
+		m := templateDateVar.FindStringSubmatch(match)
+		value, format := date, c.DateFormat
+		if strings.EqualFold(m[1], "time") {
+			value, format = now, c.TimeFormat
+		}
+		if m[2] != "" {
+			n, _ := strconv.Atoi(m[2])
+			value = addMomentUnits(value, n, m[3])
+		}
+		if f := strings.TrimSpace(m[4]); f != "" {
+			format = f
+		}
+		return FormatMoment(value, format)
+	})
+}
+
+// addMomentUnits adds n of a Moment.js duration unit to t. Like Moment.js,
+// months, quarters and years clamp to the end of the target month.
+func addMomentUnits(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "y", "Y":
+		return AddMonthsClamped(t, 12*n)
+	case "q", "Q":
+		return AddMonthsClamped(t, 3*n)
+	case "M":
+		return AddMonthsClamped(t, n)
+	case "w", "W":
+		return t.AddDate(0, 0, 7*n)
+	case "d", "D":
+		return t.AddDate(0, 0, n)
+	case "h", "H":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "s", "S":
+		return t.Add(time.Duration(n) * time.Second)
+	}
+	return t
+}
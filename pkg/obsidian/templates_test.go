@@ -0,0 +1,67 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTemplatesConfig(t *testing.T) {
+	t.Run("Reads folder and formats", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		obsDir := filepath.Join(tmpDir, ".obsidian")
+		if err := os.MkdirAll(obsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(obsDir, "templates.json"), []byte(`{
+			"folder": "Templates/",
+			"dateFormat": "DD.MM.YYYY",
+			"timeFormat": "h:mm A"
+		}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, obsidian.TemplatesConfig{Folder: "Templates", DateFormat: "DD.MM.YYYY", TimeFormat: "h:mm A"},
+			obsidian.ReadTemplatesConfig(tmpDir))
+	})
+
+	t.Run("Defaults without config", func(t *testing.T) {
+		assert.Equal(t, obsidian.TemplatesConfig{DateFormat: "YYYY-MM-DD", TimeFormat: "HH:mm"},
+			obsidian.ReadTemplatesConfig(t.TempDir()))
+	})
+}
+
+func TestTemplatesConfigRender(t *testing.T) {
+	config := obsidian.TemplatesConfig{DateFormat: "YYYY-MM-DD", TimeFormat: "HH:mm"}
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 19, 9, 5, 30, 0, time.UTC)
+
+	tests := []struct {
+		testName string
+		content  string
+		want     string
+	}{
+		{testName: "Title", content: "# {{title}}", want: "# Standup"},
+		{testName: "Date and time in configured formats", content: "{{date}} {{time}}", want: "2026-10-19 09:05"},
+		{testName: "Custom formats", content: "{{date:dddd, MMMM D}} at {{time:HH:mm:ss}}", want: "Monday, October 19 at 09:05:30"},
+		{testName: "Whitespace and case", content: "{{ Date : YYYY }} {{ TITLE }}", want: "2026 Standup"},
+		{testName: "Offsets", content: "{{date+1d}} {{date-1w:YYYY-MM-DD}} {{date+1M:MM}} {{time+30m}}", want: "2026-10-20 2026-10-12 11 09:35"},
+		{testName: "Yesterday and tomorrow", content: "[[{{yesterday}}]] [[{{tomorrow}}]]", want: "[[2026-10-18]] [[2026-10-20]]"},
+		{testName: "Unknown variables are kept", content: "{{weather}} {{date}}", want: "{{weather}} 2026-10-19"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			assert.Equal(t, test.want, config.Render(test.content, "Standup", date, now))
+		})
+	}
+
+	t.Run("Month offsets clamp to the end of the month", func(t *testing.T) {
+		endOfMonth := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, "2026-02-28 2026-04-30", config.Render("{{date+1M}} {{date+1Q}}", "Standup", endOfMonth, now))
+	})
+}
@@ -177,6 +177,35 @@ func matchPathOrSegments(path, pattern string) bool {
 	return false
 }
 
+// MatchGlob reports whether relPath (slash-separated, relative to the vault
+// root) matches pattern. Each segment is matched with filepath.Match, and a
+// "**" segment matches any number of folders, so "Meetings/**/*.md" matches
+// both "Meetings/a.md" and "Meetings/2026/a.md".
+func MatchGlob(pattern, relPath string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(relPath), "/"))
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := filepath.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
 func ShouldSkipDirectoryOrFile(info os.FileInfo) bool {
 	isDirectory := info.IsDir()
 	isHidden := info.Name()[0] == '.'
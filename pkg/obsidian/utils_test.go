@@ -306,3 +306,26 @@ func TestOpenInEditor(t *testing.T) {
 		}
 	})
 }
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		testName string
+		pattern  string
+		path     string
+		want     bool
+	}{
+		{testName: "Single segment", pattern: "*.md", path: "note.md", want: true},
+		{testName: "Single segment does not cross folders", pattern: "*.md", path: "sub/note.md", want: false},
+		{testName: "Folder and file", pattern: "Meetings/*.md", path: "Meetings/sync.md", want: true},
+		{testName: "Double star matches zero folders", pattern: "Meetings/**/*.md", path: "Meetings/sync.md", want: true},
+		{testName: "Double star matches nested folders", pattern: "Meetings/**/*.md", path: "Meetings/2026/10/sync.md", want: true},
+		{testName: "Leading double star", pattern: "**/draft-*", path: "a/b/draft-1.md", want: true},
+		{testName: "Different folder", pattern: "Meetings/*.md", path: "Projects/sync.md", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			assert.Equal(t, test.want, obsidian.MatchGlob(test.pattern, test.path))
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/obsidian"
+)
+
+const InvalidRecurrenceError = "unsupported recurrence rule"
+
+type unit int
+
+const (
+	unitDay unit = iota
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// lastDayOfMonth marks a monthly rule anchored to the last day of the month.
+const lastDayOfMonth = -1
+
+// Recurrence is a parsed Tasks plugin recurrence rule such as "every week",
+// "every 2 days when done" or "every month on the 15th".
+type Recurrence struct {
+	Interval int
+	Weekdays []time.Weekday
+	MonthDay int
+	WhenDone bool
+	unit     unit
+}
+
+// ParseRecurrence parses the text following the 🔁 signifier.
+// Supported rules:
+//   - "every [N] day(s)|week(s)|month(s)|year(s)"
+//   - "every weekday"
+//   - "every monday[, friday and ...]" and "every [N] week(s) on monday, ..."
+//   - "every [N] month(s) on the 15th" and "every month on the last"
+//
+// Any rule may end in "when done" to recur from the completion date instead of
+// the task's own dates.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(rule), " "))
+	invalid := fmt.Errorf("%s: %q", InvalidRecurrenceError, rule)
+
+	r := Recurrence{Interval: 1}
+	if trimmed, ok := strings.CutSuffix(text, " when done"); ok {
+		r.WhenDone = true
+		text = trimmed
+	}
+
+	text, ok := strings.CutPrefix(text, "every ")
+	if !ok {
+		return Recurrence{}, invalid
+	}
+
+	if text == "weekday" {
+		r.unit = unitWeek
+		r.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return r, nil
+	}
+
+	if days, err := parseWeekdayList(text); err == nil {
+		r.unit = unitWeek
+		r.Weekdays = days
+		return r, nil
+	}
+
+	period, on, _ := strings.Cut(text, " on ")
+	fields := strings.Fields(period)
+	if len(fields) == 2 {
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n < 1 {
+			return Recurrence{}, invalid
+		}
+		r.Interval = n
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return Recurrence{}, invalid
+	}
+
+	switch strings.TrimSuffix(fields[0], "s") {
+	case "day":
+		r.unit = unitDay
+	case "week":
+		r.unit = unitWeek
+	case "month":
+		r.unit = unitMonth
+	case "year":
+		r.unit = unitYear
+	default:
+		return Recurrence{}, invalid
+	}
+
+	if on == "" {
+		return r, nil
+	}
+
+	switch r.unit {
+	case unitWeek:
+		days, err := parseWeekdayList(on)
+		if err != nil {
+			return Recurrence{}, invalid
+		}
+		r.Weekdays = days
+	case unitMonth:
+		day, err := parseMonthDay(on)
+		if err != nil {
+			return Recurrence{}, invalid
+		}
+		r.MonthDay = day
+	default:
+		return Recurrence{}, invalid
+	}
+
+	return r, nil
+}
+
+// Next returns the first occurrence of the rule after ref.
+func (r Recurrence) Next(ref time.Time) time.Time {
+	switch r.unit {
+	case unitDay:
+		return ref.AddDate(0, 0, r.Interval)
+	case unitWeek:
+		if len(r.Weekdays) == 0 {
+			return ref.AddDate(0, 0, 7*r.Interval)
+		}
+		return r.nextWeekday(ref)
+	case unitMonth:
+		if r.MonthDay == 0 {
+			return obsidian.AddMonthsClamped(ref, r.Interval)
+		}
+		return r.nextMonthDay(ref)
+	default:
+		return obsidian.AddMonthsClamped(ref, 12*r.Interval)
+	}
+}
+
+func (r Recurrence) nextWeekday(ref time.Time) time.Time {
+	refWeek := startOfWeek(ref)
+	candidate := ref
+	for i := 0; i < 7*(r.Interval+1); i++ {
+		candidate = candidate.AddDate(0, 0, 1)
+		weeks := int(startOfWeek(candidate).Sub(refWeek).Hours()/24) / 7
+		if weeks%r.Interval == 0 && containsWeekday(r.Weekdays, candidate.Weekday()) {
+			return candidate
+		}
+	}
+	return candidate
+}
+
+func (r Recurrence) nextMonthDay(ref time.Time) time.Time {
+	first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location())
+	// Months shorter than the requested day are skipped, so search a few years ahead.
+	for k := 0; k <= 48; k++ {
+		month := first.AddDate(0, k*r.Interval, 0)
+		day := r.MonthDay
+		if day == lastDayOfMonth {
+			day = daysIn(month)
+		}
+		if day > daysIn(month) {
+			continue
+		}
+		candidate := month.AddDate(0, 0, day-1)
+		if candidate.After(ref) {
+			return candidate
+		}
+	}
+	return obsidian.AddMonthsClamped(ref, r.Interval)
+}
+
+func daysIn(month time.Time) int {
+	return time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, month.Location()).Day()
+}
+
+// startOfWeek returns the Monday of t's ISO week.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWeekdayList parses "monday", "mon, fri" or "monday, tuesday and friday".
+func parseWeekdayList(text string) ([]time.Weekday, error) {
+	text = strings.ReplaceAll(text, " and ", ",")
+	var days []time.Weekday
+	for _, name := range strings.Split(text, ",") {
+		day, ok := obsidian.ParseWeekday(name)
+		if !ok {
+			return nil, errors.New(InvalidRecurrenceError)
+		}
+		if !containsWeekday(days, day) {
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	return days, nil
+}
+
+// parseMonthDay parses "the 15th", "the 1st" or "the last [day]".
+func parseMonthDay(text string) (int, error) {
+	text = strings.TrimPrefix(text, "the ")
+	if text == "last" || text == "last day" {
+		return lastDayOfMonth, nil
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		text = strings.TrimSuffix(text, suffix)
+	}
+	day, err := strconv.Atoi(text)
+	if err != nil || day < 1 || day > 31 {
+		return 0, errors.New(InvalidRecurrenceError)
+	}
+	return day, nil
+}
@@ -0,0 +1,71 @@
+package tasks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse(tasks.DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	tests := []struct {
+		testName string
+		rule     string
+		ref      string
+		want     string
+	}{
+		{testName: "Every day", rule: "every day", ref: "2026-10-19", want: "2026-10-20"},
+		{testName: "Every 3 days", rule: "every 3 days", ref: "2026-10-30", want: "2026-11-02"},
+		{testName: "Every week", rule: "every week", ref: "2026-10-19", want: "2026-10-26"},
+		{testName: "Every 2 weeks", rule: "every 2 weeks", ref: "2026-10-19", want: "2026-11-02"},
+		{testName: "Every month", rule: "every month", ref: "2026-10-19", want: "2026-11-19"},
+		{testName: "Every month clamps to month end", rule: "every month", ref: "2026-01-31", want: "2026-02-28"},
+		{testName: "Every year", rule: "every year", ref: "2026-10-19", want: "2027-10-19"},
+		{testName: "Every year from leap day", rule: "every year", ref: "2028-02-29", want: "2029-02-28"},
+		{testName: "Every weekday on Friday", rule: "every weekday", ref: "2026-10-23", want: "2026-10-26"},
+		{testName: "Every weekday midweek", rule: "every weekday", ref: "2026-10-20", want: "2026-10-21"},
+		{testName: "Every weekday name", rule: "every friday", ref: "2026-10-19", want: "2026-10-23"},
+		{testName: "Every weekday name on same weekday", rule: "every monday", ref: "2026-10-19", want: "2026-10-26"},
+		{testName: "Every weekday list", rule: "every monday and thursday", ref: "2026-10-19", want: "2026-10-22"},
+		{testName: "Every week on", rule: "every week on Monday, Friday", ref: "2026-10-23", want: "2026-10-26"},
+		{testName: "Every 2 weeks on", rule: "every 2 weeks on tuesday", ref: "2026-10-20", want: "2026-11-03"},
+		{testName: "Every month on the 15th", rule: "every month on the 15th", ref: "2026-10-19", want: "2026-11-15"},
+		{testName: "Every month on the 15th before the 15th", rule: "every month on the 15th", ref: "2026-10-01", want: "2026-10-15"},
+		{testName: "Every month on the last", rule: "every month on the last", ref: "2026-01-31", want: "2026-02-28"},
+		{testName: "Every month on the 31st skips short months", rule: "every month on the 31st", ref: "2026-03-31", want: "2026-05-31"},
+		{testName: "Case insensitive", rule: "Every Week", ref: "2026-10-19", want: "2026-10-26"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			recurrence, err := tasks.ParseRecurrence(test.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, recurrence.Next(date(test.ref)).Format(tasks.DateLayout))
+		})
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("When done suffix", func(t *testing.T) {
+		recurrence, err := tasks.ParseRecurrence("every 2 days when done")
+		assert.NoError(t, err)
+		assert.True(t, recurrence.WhenDone)
+		assert.Equal(t, 2, recurrence.Interval)
+	})
+
+	for _, rule := range []string{"", "daily", "every", "every fortnight", "every 0 days", "every day on monday", "every month on the 32nd"} {
+		t.Run("Rejects "+rule, func(t *testing.T) {
+			_, err := tasks.ParseRecurrence(rule)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tasks
+
+import (
+	"strings"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/markdown"
+)
+
+// MovedStatus marks a task that was carried over to another note.
+const MovedStatus = '>'
+
+// Block is an open task and, optionally, the more indented lines below it
+// (subtasks and notes). Start and End are zero-based line indexes; End is
+// exclusive.
+type Block struct {
+	Start int
+	End   int
+}
+
+// OpenBlocks returns the unchecked ("- [ ]") tasks in lines, skipping
+// frontmatter and fenced code blocks. With children, each block also holds
+// the lines indented below its task; tasks nested inside a block are not
+// reported separately. Tasks without text are skipped when skipEmpty is set.
+func OpenBlocks(lines []string, children, skipEmpty bool) []Block {
+	var blocks []Block
+	inFence := false
+	for i := markdown.BodyStart(lines); i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		task, ok := Parse(strings.TrimSuffix(lines[i], "\r"))
+		if !ok || task.Status != ' ' {
+			continue
+		}
+		if skipEmpty && strings.TrimSpace(task.Body) == "" {
+			continue
+		}
+
+		end := i + 1
+		if children {
+			indent := indentation(lines[i])
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "" && indentation(lines[end]) > indent {
+				end++
+			}
+		}
+		blocks = append(blocks, Block{Start: i, End: end})
+		i = end - 1
+	}
+	return blocks
+}
+
+// indentation returns the width of a line's leading whitespace, counting a
+// tab as four spaces.
+func indentation(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
@@ -0,0 +1,44 @@
+package tasks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBlocks(t *testing.T) {
+	content := strings.Join([]string{
+		"---",
+		"tags: [daily]",
+		"---",
+		"- [ ] Write report",
+		"  - [x] Outline",
+		"  - [ ] Draft",
+		"    notes on the draft",
+		"- [x] Done already",
+		"- [ ] ",
+		"```",
+		"- [ ] not a task",
+		"```",
+		"* [ ] Call bank",
+		"",
+		"  - [ ] Indented after blank",
+	}, "\n")
+	lines := strings.Split(content, "\n")
+
+	t.Run("Open tasks without children", func(t *testing.T) {
+		blocks := tasks.OpenBlocks(lines, false, false)
+		assert.Equal(t, []tasks.Block{
+			{Start: 3, End: 4}, {Start: 5, End: 6}, {Start: 8, End: 9}, {Start: 12, End: 13}, {Start: 14, End: 15},
+		}, blocks)
+	})
+
+	t.Run("Open tasks with children, skipping empty ones", func(t *testing.T) {
+		blocks := tasks.OpenBlocks(lines, true, true)
+		assert.Equal(t, []tasks.Block{
+			{Start: 3, End: 7}, {Start: 12, End: 13}, {Start: 14, End: 15},
+		}, blocks)
+	})
+}
@@ -0,0 +1,174 @@
+package tasks
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Signifiers used by the Obsidian Tasks plugin emoji format.
+const (
+	DueSignifier        = "📅"
+	ScheduledSignifier  = "⏳"
+	StartSignifier      = "🛫"
+	CreatedSignifier    = "➕"
+	DoneSignifier       = "✅"
+	RecurrenceSignifier = "🔁"
+)
+
+const (
+	DateLayout       = "2006-01-02"
+	NotATaskError    = "line is not a task"
+	AlreadyDoneError = "task is already done"
+)
+
+var (
+	taskPattern       = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)(.)\](.*)$`)
+	recurrencePattern = regexp.MustCompile(`🔁\x{FE0F}? *([a-zA-Z0-9, !]+)`)
+	blockIDPattern    = regexp.MustCompile(`\s+\^[a-zA-Z0-9-]+$`)
+)
+
+// Task is a Markdown checkbox list item such as "- [ ] Pay rent 📅 2026-11-01".
+type Task struct {
+	// Prefix holds the indentation, list marker and opening bracket.
+	Prefix string
+	Status rune
+	// Body is everything after the closing bracket, including the leading space.
+	Body string
+}
+
+// Parse parses a single line as a task.
+func Parse(line string) (Task, bool) {
+	match := taskPattern.FindStringSubmatch(line)
+	if match == nil {
+		return Task{}, false
+	}
+	return Task{Prefix: match[1], Status: []rune(match[2])[0], Body: match[3]}, true
+}
+
+// String renders the task back to a Markdown line.
+func (t Task) String() string {
+	return t.Prefix + string(t.Status) + "]" + t.Body
+}
+
+// IsDone reports whether the checkbox is ticked.
+func (t Task) IsDone() bool {
+	return t.Status == 'x' || t.Status == 'X'
+}
+
+// Date returns the date following signifier, if present.
+func (t Task) Date(signifier string) (time.Time, bool) {
+	match := datePattern(signifier).FindStringSubmatch(t.Body)
+	if match == nil {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(DateLayout, match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// SetDate replaces the date following signifier, or appends the field before
+// any trailing block ID when the task has none.
+func (t *Task) SetDate(signifier string, date time.Time) {
+	field := signifier + " " + date.Format(DateLayout)
+	pattern := datePattern(signifier)
+	if pattern.MatchString(t.Body) {
+		t.Body = pattern.ReplaceAllLiteralString(t.Body, field)
+		return
+	}
+
+	body := strings.TrimRight(t.Body, " \t")
+	blockID := blockIDPattern.FindString(body)
+	body = strings.TrimSuffix(body, blockID)
+	t.Body = body + " " + field + blockID
+}
+
+// RemoveDate removes the field for signifier, if present.
+func (t *Task) RemoveDate(signifier string) {
+	t.Body = regexp.MustCompile(` *`+signifier+`\x{FE0F}? *\d{4}-\d{2}-\d{2}`).ReplaceAllString(t.Body, "")
+}
+
+// Recurrence returns the raw recurrence rule following 🔁, if present.
+func (t Task) Recurrence() (string, bool) {
+	match := recurrencePattern.FindStringSubmatch(t.Body)
+	if match == nil {
+		return "", false
+	}
+	rule := strings.TrimSpace(match[1])
+	return rule, rule != ""
+}
+
+// Complete ticks the task and stamps it with a ✅ date. For recurring tasks it
+// also returns the next instance, which the Tasks plugin places on the line
+// above the completed one.
+func (t Task) Complete(today time.Time) (Task, *Task, error) {
+	done := t
+	done.Status = 'x'
+	done.SetDate(DoneSignifier, today)
+
+	rule, ok := t.Recurrence()
+	if !ok {
+		return done, nil, nil
+	}
+
+	recurrence, err := ParseRecurrence(rule)
+	if err != nil {
+		return Task{}, nil, err
+	}
+
+	next := t
+	next.Status = ' '
+	next.RemoveDate(DoneSignifier)
+	next.Body = blockIDPattern.ReplaceAllString(strings.TrimRight(next.Body, " \t"), "")
+	next.shiftDates(recurrence, today)
+
+	return done, &next, nil
+}
+
+// Uncomplete clears the checkbox and removes the ✅ date.
+func (t Task) Uncomplete() Task {
+	t.Status = ' '
+	t.RemoveDate(DoneSignifier)
+	return t
+}
+
+// shiftDates moves the due, scheduled and start dates to the next occurrence.
+// The reference date is the due date, falling back to scheduled then start
+// (or today for "when done" rules); the other dates keep their distance to it.
+func (t *Task) shiftDates(recurrence Recurrence, today time.Time) {
+	signifiers := []string{DueSignifier, ScheduledSignifier, StartSignifier}
+
+	var reference time.Time
+	hasReference := false
+	for _, signifier := range signifiers {
+		if date, ok := t.Date(signifier); ok {
+			reference = date
+			hasReference = true
+			break
+		}
+	}
+	if !hasReference {
+		return
+	}
+
+	next := recurrence.Next(reference)
+	if recurrence.WhenDone {
+		next = recurrence.Next(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, signifier := range signifiers {
+		if date, ok := t.Date(signifier); ok {
+			t.SetDate(signifier, next.AddDate(0, 0, daysBetween(reference, date)))
+		}
+	}
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func datePattern(signifier string) *regexp.Regexp {
+	return regexp.MustCompile(signifier + `\x{FE0F}? *(\d{4}-\d{2}-\d{2})`)
+}
@@ -0,0 +1,109 @@
+package tasks_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/notesmd-cli/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		testName string
+		line     string
+		ok       bool
+		done     bool
+	}{
+		{testName: "Open task", line: "- [ ] Buy milk", ok: true},
+		{testName: "Done task", line: "- [x] Buy milk", ok: true, done: true},
+		{testName: "Uppercase done task", line: "* [X] Buy milk", ok: true, done: true},
+		{testName: "Indented numbered task", line: "    1. [ ] Buy milk", ok: true},
+		{testName: "Plain list item", line: "- Buy milk", ok: false},
+		{testName: "Paragraph", line: "Buy [ ] milk", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			task, ok := tasks.Parse(test.line)
+			assert.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.done, task.IsDone())
+				assert.Equal(t, test.line, task.String())
+			}
+		})
+	}
+}
+
+func TestTaskDates(t *testing.T) {
+	t.Run("Reads dates", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Pay rent 🛫 2026-10-25 📅 2026-11-01")
+		due, ok := task.Date(tasks.DueSignifier)
+		assert.True(t, ok)
+		assert.Equal(t, "2026-11-01", due.Format(tasks.DateLayout))
+		_, ok = task.Date(tasks.ScheduledSignifier)
+		assert.False(t, ok)
+	})
+
+	t.Run("Appends date before block ID", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Pay rent ^rent")
+		task.SetDate(tasks.DueSignifier, date("2026-11-01"))
+		assert.Equal(t, "- [ ] Pay rent 📅 2026-11-01 ^rent", task.String())
+	})
+
+	t.Run("Replaces existing date", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Pay rent 📅 2026-11-01 #home")
+		task.SetDate(tasks.DueSignifier, date("2026-12-01"))
+		assert.Equal(t, "- [ ] Pay rent 📅 2026-12-01 #home", task.String())
+	})
+}
+
+func TestComplete(t *testing.T) {
+	today := date("2026-10-19")
+
+	t.Run("Completes plain task", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Buy milk")
+		done, next, err := task.Complete(today)
+		assert.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, "- [x] Buy milk ✅ 2026-10-19", done.String())
+	})
+
+	t.Run("Creates next instance of recurring task", func(t *testing.T) {
+		task, _ := tasks.Parse("  - [ ] Water plants 🔁 every week ⏳ 2026-10-16 📅 2026-10-18 ^water")
+		done, next, err := task.Complete(today)
+		assert.NoError(t, err)
+		assert.Equal(t, "  - [x] Water plants 🔁 every week ⏳ 2026-10-16 📅 2026-10-18 ✅ 2026-10-19 ^water", done.String())
+		if assert.NotNil(t, next) {
+			assert.Equal(t, "  - [ ] Water plants 🔁 every week ⏳ 2026-10-23 📅 2026-10-25", next.String())
+		}
+	})
+
+	t.Run("Recurs from completion date when done", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Haircut 🔁 every 4 weeks when done 📅 2026-09-01")
+		_, next, err := task.Complete(today)
+		assert.NoError(t, err)
+		if assert.NotNil(t, next) {
+			assert.Equal(t, "- [ ] Haircut 🔁 every 4 weeks when done 📅 2026-11-16", next.String())
+		}
+	})
+
+	t.Run("Recurring task without dates repeats unchanged", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Stretch 🔁 every day")
+		_, next, err := task.Complete(today)
+		assert.NoError(t, err)
+		if assert.NotNil(t, next) {
+			assert.Equal(t, "- [ ] Stretch 🔁 every day", next.String())
+		}
+	})
+
+	t.Run("Invalid recurrence returns error", func(t *testing.T) {
+		task, _ := tasks.Parse("- [ ] Stretch 🔁 every blue moon 📅 2026-10-18")
+		_, _, err := task.Complete(today)
+		assert.Error(t, err)
+	})
+
+	t.Run("Uncomplete removes done date", func(t *testing.T) {
+		task, _ := tasks.Parse("- [x] Buy milk ✅ 2026-10-19 ^milk")
+		assert.Equal(t, "- [ ] Buy milk ^milk", task.Uncomplete().String())
+	})
+}